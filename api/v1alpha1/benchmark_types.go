@@ -0,0 +1,217 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Phases reported in BenchmarkStatus.Phase
+const (
+	BenchmarkPhasePending   = "Pending"
+	BenchmarkPhaseRunning   = "Running"
+	BenchmarkPhaseSucceeded = "Succeeded"
+	BenchmarkPhaseFailed    = "Failed"
+)
+
+// BenchmarkTarget selects the OpenAI-compatible endpoint a benchmark sends requests to
+// +kubebuilder:validation:XValidation:rule="has(self.deploymentRef) != has(self.url)",message="exactly one of deploymentRef and url must be set"
+type BenchmarkTarget struct {
+	// DeploymentRef is the name of an LMDeployment in the namespace of the benchmark, its vLLM router or Ollama service is benchmarked
+	// +kubebuilder:validation:Optional
+	DeploymentRef string `json:"deploymentRef,omitempty"`
+
+	// URL is the base URL of any other OpenAI-compatible API, e.g. http://my-model:8000/v1
+	// +kubebuilder:validation:Optional
+	URL string `json:"url,omitempty"`
+
+	// Model is the model requested. For a vLLM LMDeployment the name of one of its models, resolved to the model identifier.
+	// +kubebuilder:validation:Required
+	Model string `json:"model"`
+
+	// APIKeySecretRef references the API key sent as bearer token, defaults to the vLLM API key of the LMDeployment
+	// +kubebuilder:validation:Optional
+	APIKeySecretRef *corev1.SecretKeySelector `json:"apiKeySecretRef,omitempty"`
+}
+
+// TokenRange is a range of token counts, each request picks a count uniformly from it
+// +kubebuilder:validation:XValidation:rule="self.min <= self.max",message="min must not exceed max"
+type TokenRange struct {
+	// Min is the smallest token count
+	// +kubebuilder:validation:Minimum=1
+	Min int32 `json:"min"`
+
+	// Max is the largest token count
+	// +kubebuilder:validation:Minimum=1
+	Max int32 `json:"max"`
+}
+
+// BenchmarkSpec defines a load benchmark against a model endpoint
+type BenchmarkSpec struct {
+	// Target is the endpoint and model to benchmark
+	// +kubebuilder:validation:Required
+	Target BenchmarkTarget `json:"target"`
+
+	// Concurrency is the number of requests kept in flight
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=4
+	Concurrency int32 `json:"concurrency,omitempty"`
+
+	// Duration is how long new requests are sent, requests in flight are awaited afterwards
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="1m"
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// MaxRequests ends the benchmark after this many requests, before the duration passed
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	MaxRequests int32 `json:"maxRequests,omitempty"`
+
+	// PromptTokens is the range of the prompt lengths, defaults to 128-512
+	// +kubebuilder:validation:Optional
+	PromptTokens *TokenRange `json:"promptTokens,omitempty"`
+
+	// OutputTokens is the range of the response lengths, defaults to 64-256
+	// +kubebuilder:validation:Optional
+	OutputTokens *TokenRange `json:"outputTokens,omitempty"`
+
+	// Image is the image of the benchmark Job, defaults to the operator image
+	// +kubebuilder:validation:Optional
+	Image string `json:"image,omitempty"`
+}
+
+// LatencyPercentiles reports the distribution of a latency over the successful requests
+type LatencyPercentiles struct {
+	// Mean is the average latency
+	Mean metav1.Duration `json:"mean,omitempty"`
+
+	// P50 is the median latency
+	P50 metav1.Duration `json:"p50,omitempty"`
+
+	// P90 is the 90th percentile latency
+	P90 metav1.Duration `json:"p90,omitempty"`
+
+	// P99 is the 99th percentile latency
+	P99 metav1.Duration `json:"p99,omitempty"`
+}
+
+// BenchmarkResults reports the measurements of a benchmark run
+type BenchmarkResults struct {
+	// Requests is the number of requests sent
+	Requests int64 `json:"requests"`
+
+	// Errors is the number of failed requests
+	Errors int64 `json:"errors"`
+
+	// ErrorRate is the percentage of failed requests
+	ErrorRate string `json:"errorRate,omitempty"`
+
+	// Duration is how long the run took, including the requests in flight at its end
+	Duration metav1.Duration `json:"duration,omitempty"`
+
+	// RequestsPerSecond is the rate of successful requests
+	RequestsPerSecond string `json:"requestsPerSecond,omitempty"`
+
+	// PromptTokens is the number of prompt tokens of the successful requests
+	PromptTokens int64 `json:"promptTokens,omitempty"`
+
+	// OutputTokens is the number of tokens generated for the successful requests
+	OutputTokens int64 `json:"outputTokens,omitempty"`
+
+	// OutputTokensPerSecond is the rate of generated tokens over the run
+	OutputTokensPerSecond string `json:"outputTokensPerSecond,omitempty"`
+
+	// TimeToFirstToken is the time until the first token of a response arrived (TTFT)
+	TimeToFirstToken LatencyPercentiles `json:"timeToFirstToken,omitempty"`
+
+	// TimePerOutputToken is the time between the tokens of a response after the first one (TPOT)
+	TimePerOutputToken LatencyPercentiles `json:"timePerOutputToken,omitempty"`
+
+	// Latency is the time until a response was complete
+	Latency LatencyPercentiles `json:"latency,omitempty"`
+}
+
+// BenchmarkStatus defines the observed state of a Benchmark
+type BenchmarkStatus struct {
+	// Phase is Pending, Running, Succeeded or Failed
+	Phase string `json:"phase,omitempty"`
+
+	// Message explains why the benchmark is pending or failed
+	Message string `json:"message,omitempty"`
+
+	// Job is the name of the Job running the benchmark
+	Job string `json:"job,omitempty"`
+
+	// URL is the resolved base URL of the benchmarked API
+	URL string `json:"url,omitempty"`
+
+	// Model is the resolved model identifier requested
+	Model string `json:"model,omitempty"`
+
+	// StartTime is when the benchmark Job started
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is when the benchmark Job finished
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Results are the measurements of the run
+	Results *BenchmarkResults `json:"results,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Model",type="string",JSONPath=".status.model"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="TTFT P50",type="string",JSONPath=".status.results.timeToFirstToken.p50"
+// +kubebuilder:printcolumn:name="TPOT P50",type="string",JSONPath=".status.results.timePerOutputToken.p50"
+// +kubebuilder:printcolumn:name="Tokens/s",type="string",JSONPath=".status.results.outputTokensPerSecond"
+// +kubebuilder:printcolumn:name="Errors %",type="string",JSONPath=".status.results.errorRate"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Namespaced,shortName=llmbench
+
+// Benchmark runs a load benchmark against a model endpoint once and stores the results in its status.
+// Create a new Benchmark for every run to compare them over time.
+type Benchmark struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="a benchmark runs once, create a new Benchmark to run again"
+	Spec   BenchmarkSpec   `json:"spec,omitempty"`
+	Status BenchmarkStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BenchmarkList contains a list of Benchmark
+type BenchmarkList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Benchmark `json:"items"`
+}
+
+// GetJobName returns the name of the Job running the benchmark
+func (b *Benchmark) GetJobName() string {
+	return fmt.Sprintf("%s-benchmark", b.Name)
+}
+
+func init() {
+	SchemeBuilder.Register(&Benchmark{}, &BenchmarkList{})
+}
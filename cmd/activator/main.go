@@ -0,0 +1,121 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/geeper-io/llm-operator/internal/activator"
+)
+
+var (
+	scheme   = runtime.NewScheme()
+	setupLog = ctrl.Log.WithName("setup")
+)
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+}
+
+func main() {
+	var listenAddr string
+	var namespace, deployment string
+	var backend string
+	var models string
+	var readyTimeout, pollInterval, activityInterval time.Duration
+	flag.StringVar(&listenAddr, "listen-address", ":8000", "The address the activator serves the model API on.")
+	flag.StringVar(&namespace, "namespace", "", "The namespace of the model Deployment.")
+	flag.StringVar(&deployment, "deployment", "", "The name of the model Deployment scaled up on demand.")
+	flag.StringVar(&backend, "backend", "", "The URL of the service selecting the model pods.")
+	flag.StringVar(&models, "models", "", "Comma-separated model names listed on /v1/models while the model is scaled to zero.")
+	flag.DurationVar(&readyTimeout, "ready-timeout", 15*time.Minute, "How long a request waits for the model to become ready.")
+	flag.DurationVar(&pollInterval, "poll-interval", 2*time.Second, "How often the readiness of the model is checked.")
+	flag.DurationVar(&activityInterval, "activity-interval", 30*time.Second,
+		"How often the time of the last request is recorded on the model Deployment.")
+	opts := zap.Options{}
+	opts.BindFlags(flag.CommandLine)
+	flag.Parse()
+
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+
+	backendURL, err := url.Parse(backend)
+	if err != nil || namespace == "" || deployment == "" || backend == "" {
+		setupLog.Error(err, "--namespace, --deployment and a valid --backend URL are required")
+		os.Exit(1)
+	}
+
+	c, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: scheme})
+	if err != nil {
+		setupLog.Error(err, "unable to create client")
+		os.Exit(1)
+	}
+
+	var modelNames []string
+	for _, model := range strings.Split(models, ",") {
+		if model = strings.TrimSpace(model); model != "" {
+			modelNames = append(modelNames, model)
+		}
+	}
+
+	a := activator.New(c, activator.Config{
+		Namespace:        namespace,
+		Deployment:       deployment,
+		Backend:          backendURL,
+		Models:           modelNames,
+		ReadyTimeout:     readyTimeout,
+		PollInterval:     pollInterval,
+		ActivityInterval: activityInterval,
+	})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.Handle("/", a)
+	server := &http.Server{Addr: listenAddr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	ctx := ctrl.SetupSignalHandler()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+	go func() {
+		_ = a.Run(ctx)
+	}()
+
+	setupLog.Info("starting activator", "deployment", deployment, "backend", backend)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		setupLog.Error(err, "problem running activator")
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/geeper-io/llm-operator/internal/benchmark"
+)
+
+var setupLog = ctrl.Log.WithName("setup")
+
+func main() {
+	var config benchmark.Config
+	var promptTokens, outputTokens string
+	var terminationLog string
+	flag.StringVar(&config.URL, "url", "", "The base URL of the OpenAI-compatible API, e.g. http://my-model:8000/v1.")
+	flag.StringVar(&config.Model, "model", "", "The model requested.")
+	flag.IntVar(&config.Concurrency, "concurrency", 4, "The number of requests kept in flight.")
+	flag.DurationVar(&config.Duration, "duration", time.Minute, "How long new requests are sent.")
+	flag.IntVar(&config.MaxRequests, "max-requests", 0, "End the run after this many requests, 0 for no limit.")
+	flag.StringVar(&promptTokens, "prompt-tokens", "128-512", "The range of the prompt lengths in tokens.")
+	flag.StringVar(&outputTokens, "output-tokens", "64-256", "The range of the response lengths in tokens.")
+	flag.DurationVar(&config.RequestTimeout, "request-timeout", 10*time.Minute, "How long a single request may take.")
+	flag.StringVar(&terminationLog, "termination-log", "/dev/termination-log",
+		"The file the results are written to, the operator reads them from the termination message of the pod.")
+	opts := zap.Options{}
+	opts.BindFlags(flag.CommandLine)
+	flag.Parse()
+
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+
+	// The API key is read from the environment so it doesn't show up in the pod spec
+	config.APIKey = os.Getenv("API_KEY")
+
+	var err error
+	if config.PromptTokens, err = parseTokenRange(promptTokens); err != nil {
+		fail(terminationLog, fmt.Errorf("invalid --prompt-tokens: %w", err))
+	}
+	if config.OutputTokens, err = parseTokenRange(outputTokens); err != nil {
+		fail(terminationLog, fmt.Errorf("invalid --output-tokens: %w", err))
+	}
+	if config.URL == "" || config.Model == "" {
+		fail(terminationLog, errors.New("--url and --model are required"))
+	}
+
+	setupLog.Info("starting benchmark", "url", config.URL, "model", config.Model, "concurrency", config.Concurrency, "duration", config.Duration)
+	results, err := benchmark.Run(ctrl.SetupSignalHandler(), config)
+	if err != nil {
+		fail(terminationLog, err)
+	}
+
+	output, _ := json.Marshal(results)
+	fmt.Println(string(output))
+	if err := os.WriteFile(terminationLog, output, 0o644); err != nil {
+		setupLog.Error(err, "unable to write the results", "path", terminationLog)
+		os.Exit(1)
+	}
+	if results.Requests > 0 && results.Errors == results.Requests {
+		setupLog.Info("all requests failed")
+		os.Exit(1)
+	}
+}
+
+// parseTokenRange parses a range such as 128-512 or a single count
+func parseTokenRange(value string) ([2]int, error) {
+	from, to, found := strings.Cut(value, "-")
+	if !found {
+		to = from
+	}
+	lower, err := strconv.Atoi(from)
+	if err != nil {
+		return [2]int{}, err
+	}
+	upper, err := strconv.Atoi(to)
+	if err != nil {
+		return [2]int{}, err
+	}
+	if lower < 1 || upper < lower {
+		return [2]int{}, fmt.Errorf("expected 1 <= min <= max, got %s", value)
+	}
+	return [2]int{lower, upper}, nil
+}
+
+// fail writes the error to the termination log, the operator reports it in the status of the Benchmark
+func fail(terminationLog string, err error) {
+	setupLog.Error(err, "benchmark failed")
+	_ = os.WriteFile(terminationLog, []byte(err.Error()), 0o644)
+	os.Exit(1)
+}
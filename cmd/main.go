@@ -21,6 +21,9 @@ import (
 	"flag"
 	"os"
 	"path/filepath"
+	"strings"
+	// Embed the time zone database, schedules are evaluated in their time zone on a distroless image
+	_ "time/tzdata"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -38,7 +41,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+	operatorconfig "github.com/geeper-io/llm-operator/internal/config"
 	"github.com/geeper-io/llm-operator/internal/controller"
+	"github.com/geeper-io/llm-operator/internal/registry"
 	webhookv1alpha1 "github.com/geeper-io/llm-operator/internal/webhook/v1alpha1"
 	// +kubebuilder:scaffold:imports
 )
@@ -64,6 +69,8 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var operatorConfigPath string
+	var plainHTTPRegistries string
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -82,6 +89,11 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.StringVar(&operatorConfigPath, "config", "",
+		"The path of the operator config file with default images, registries and policies. "+
+			"The file is reloaded when it changes, built-in defaults are used if not set.")
+	flag.StringVar(&plainHTTPRegistries, "plain-http-registries", "",
+		"Comma-separated registry hosts accessed without TLS when resolving image digests, e.g. a local registry.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -203,17 +215,40 @@ func main() {
 		os.Exit(1)
 	}
 
+	operatorConfig, err := operatorconfig.NewStore(operatorConfigPath)
+	if err != nil {
+		setupLog.Error(err, "unable to load operator config", "path", operatorConfigPath)
+		os.Exit(1)
+	}
+	if err := mgr.Add(operatorConfig); err != nil {
+		setupLog.Error(err, "unable to add operator config watcher to manager")
+		os.Exit(1)
+	}
+
 	if err := (&controller.LMDeploymentReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
+		Config: operatorConfig,
+		Registry: registry.NewClient(registry.Options{
+			PlainHTTP: strings.FieldsFunc(plainHTTPRegistries, func(r rune) bool { return r == ',' }),
+		}),
+		OperatorNamespace: operatorNamespace(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Deployment")
 		os.Exit(1)
 	}
+	if err := (&controller.BenchmarkReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		Config: operatorConfig,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Benchmark")
+		os.Exit(1)
+	}
 
 	// nolint:goconst
 	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
-		if err := webhookv1alpha1.SetupLMDeploymentWebhookWithManager(mgr); err != nil {
+		if err := webhookv1alpha1.SetupLMDeploymentWebhookWithManager(mgr, operatorConfig); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "LMDeployment")
 			os.Exit(1)
 		}
@@ -251,3 +286,13 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// operatorNamespace returns the namespace the operator runs in, read from its service account.
+// It is empty when the operator runs outside of the cluster.
+func operatorNamespace() string {
+	namespace, err := os.ReadFile("/var/run/secrets/kubernetes.io/serviceaccount/namespace")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(namespace))
+}
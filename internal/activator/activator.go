@@ -0,0 +1,302 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package activator implements the proxy in front of a model that is scaled to zero when idle.
+// It forwards requests to the model, records their time on the model Deployment so the operator
+// can tell when the model is idle, and scales the model back up when a request arrives while it is scaled to zero.
+package activator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+// probePaths are polled by the router, they are answered without scaling the model up
+var probePaths = map[string]bool{
+	"/v1/models": true,
+	"/metrics":   true,
+	"/health":    true,
+	"/version":   true,
+}
+
+// Config configures an Activator
+type Config struct {
+	// Namespace and Deployment identify the model Deployment
+	Namespace  string
+	Deployment string
+
+	// Backend is the URL of the service selecting the model pods
+	Backend *url.URL
+
+	// Models are the model names served, they are listed on /v1/models while the model is scaled to zero
+	Models []string
+
+	// ReadyTimeout is how long a request waits for the model to become ready
+	ReadyTimeout time.Duration
+
+	// PollInterval is how often the readiness of the model is checked
+	PollInterval time.Duration
+
+	// ActivityInterval is how often the time of the last request is recorded on the Deployment
+	ActivityInterval time.Duration
+}
+
+// Activator forwards requests to a model and scales it up from zero on demand
+type Activator struct {
+	client client.Client
+	config Config
+	proxy  *httputil.ReverseProxy
+	now    func() time.Time
+
+	// ready is true while the model has ready replicas
+	ready atomic.Bool
+	// inflight is the number of requests being forwarded
+	inflight atomic.Int64
+	// lastRequest is the time of the last request in unix nanoseconds
+	lastRequest atomic.Int64
+
+	mu sync.Mutex
+	// activation is closed when the running activation finishes, nil when no activation is running
+	activation chan struct{}
+}
+
+// New returns an Activator for the model Deployment described by the config
+func New(c client.Client, config Config) *Activator {
+	a := &Activator{
+		client: c,
+		config: config,
+		now:    time.Now,
+	}
+
+	a.proxy = httputil.NewSingleHostReverseProxy(config.Backend)
+	// Stream generated tokens as they arrive
+	a.proxy.FlushInterval = -1
+	a.proxy.ErrorHandler = func(w http.ResponseWriter, req *http.Request, err error) {
+		// The model may have been scaled down since readiness was last checked, the next request activates it
+		a.ready.Store(false)
+		log.FromContext(req.Context()).Error(err, "Failed to forward request", "deployment", config.Deployment)
+		w.WriteHeader(http.StatusBadGateway)
+	}
+	return a
+}
+
+// ServeHTTP forwards a request to the model, scaling it up first when it has no ready replicas
+func (a *Activator) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	// The router discovers the models served and scrapes their metrics, polling doesn't count as activity
+	if req.Method == http.MethodGet && probePaths[req.URL.Path] {
+		switch {
+		case a.ready.Load():
+			a.proxy.ServeHTTP(w, req)
+		case req.URL.Path == "/v1/models":
+			a.serveModels(w)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+		return
+	}
+
+	a.inflight.Add(1)
+	defer a.inflight.Add(-1)
+	a.lastRequest.Store(a.now().UnixNano())
+
+	if !a.ready.Load() {
+		if err := a.activate(req.Context()); err != nil {
+			http.Error(w, fmt.Sprintf("model is not available: %v", err), http.StatusServiceUnavailable)
+			return
+		}
+	}
+	a.proxy.ServeHTTP(w, req)
+}
+
+// serveModels lists the models served in the OpenAI format without waking the model up
+func (a *Activator) serveModels(w http.ResponseWriter) {
+	models := make([]map[string]string, 0, len(a.config.Models))
+	for _, model := range a.config.Models {
+		models = append(models, map[string]string{"id": model, "object": "model"})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"object": "list", "data": models})
+}
+
+// activate waits for the model to become ready, concurrent requests share a single activation
+func (a *Activator) activate(ctx context.Context) error {
+	a.mu.Lock()
+	if a.activation == nil {
+		done := make(chan struct{})
+		a.activation = done
+		go func() {
+			defer func() {
+				a.mu.Lock()
+				a.activation = nil
+				a.mu.Unlock()
+				close(done)
+			}()
+			// Detached from the request, the model keeps starting when the client gives up
+			if err := a.scaleUp(context.Background()); err != nil {
+				log.Log.Error(err, "Failed to activate model", "deployment", a.config.Deployment)
+			}
+		}()
+	}
+	done := a.activation
+	a.mu.Unlock()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if !a.ready.Load() {
+		return errors.New("timed out waiting for the model to become ready")
+	}
+	return nil
+}
+
+// scaleUp scales the model Deployment up from zero and waits until it has a ready replica
+func (a *Activator) scaleUp(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, a.config.ReadyTimeout)
+	defer cancel()
+
+	start := a.now()
+	deployment := &appsv1.Deployment{}
+	if err := a.client.Get(ctx, a.key(), deployment); err != nil {
+		return fmt.Errorf("failed to get deployment: %w", err)
+	}
+	if deployment.Status.ReadyReplicas > 0 {
+		a.ready.Store(true)
+		return nil
+	}
+
+	activated := deployment.Spec.Replicas != nil && *deployment.Spec.Replicas == 0
+	if activated {
+		// The request time is recorded with the scale so the operator doesn't consider the model idle again
+		patch := client.MergeFrom(deployment.DeepCopy())
+		activations, _ := strconv.Atoi(deployment.Annotations[llmgeeperiov1alpha1.ActivationsAnnotation])
+		setAnnotations(deployment, map[string]string{
+			llmgeeperiov1alpha1.LastRequestAnnotation:    start.UTC().Format(time.RFC3339),
+			llmgeeperiov1alpha1.LastActivationAnnotation: start.UTC().Format(time.RFC3339),
+			llmgeeperiov1alpha1.ActivationsAnnotation:    strconv.Itoa(activations + 1),
+		})
+		deployment.Spec.Replicas = ptr.To(int32(1))
+		if err := a.client.Patch(ctx, deployment, patch); err != nil {
+			return fmt.Errorf("failed to scale up deployment: %w", err)
+		}
+	}
+
+	err := wait.PollUntilContextCancel(ctx, a.config.PollInterval, true, func(ctx context.Context) (bool, error) {
+		if err := a.client.Get(ctx, a.key(), deployment); err != nil {
+			return false, nil
+		}
+		return deployment.Status.ReadyReplicas > 0, nil
+	})
+	if err != nil {
+		return fmt.Errorf("model did not become ready: %w", err)
+	}
+	a.ready.Store(true)
+
+	if activated {
+		patch := client.MergeFrom(deployment.DeepCopy())
+		setAnnotations(deployment, map[string]string{
+			llmgeeperiov1alpha1.LastColdStartAnnotation: a.now().Sub(start).Round(time.Second).String(),
+		})
+		if err := a.client.Patch(ctx, deployment, patch); err != nil {
+			return fmt.Errorf("failed to record cold start: %w", err)
+		}
+	}
+	return nil
+}
+
+// Run tracks the readiness of the model and records the time of the last request until the context is done
+func (a *Activator) Run(ctx context.Context) error {
+	readiness := time.NewTicker(a.config.PollInterval)
+	defer readiness.Stop()
+	activity := time.NewTicker(a.config.ActivityInterval)
+	defer activity.Stop()
+
+	var reported int64
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-readiness.C:
+			deployment := &appsv1.Deployment{}
+			if err := a.client.Get(ctx, a.key(), deployment); err != nil {
+				log.FromContext(ctx).Error(err, "Failed to get deployment", "deployment", a.config.Deployment)
+				continue
+			}
+			a.ready.Store(deployment.Status.ReadyReplicas > 0)
+		case <-activity.C:
+			// Long running requests, e.g. streamed completions, keep the model active
+			if a.inflight.Load() > 0 {
+				a.lastRequest.Store(a.now().UnixNano())
+			}
+			last := a.lastRequest.Load()
+			if last == reported {
+				continue
+			}
+			if err := a.recordActivity(ctx, time.Unix(0, last)); err != nil {
+				log.FromContext(ctx).Error(err, "Failed to record activity", "deployment", a.config.Deployment)
+				continue
+			}
+			reported = last
+		}
+	}
+}
+
+// recordActivity records the time of the last request on the model Deployment
+func (a *Activator) recordActivity(ctx context.Context, last time.Time) error {
+	deployment := &appsv1.Deployment{}
+	if err := a.client.Get(ctx, a.key(), deployment); err != nil {
+		return err
+	}
+	patch := client.MergeFrom(deployment.DeepCopy())
+	setAnnotations(deployment, map[string]string{
+		llmgeeperiov1alpha1.LastRequestAnnotation: last.UTC().Format(time.RFC3339),
+	})
+	return a.client.Patch(ctx, deployment, patch)
+}
+
+// key returns the key of the model Deployment
+func (a *Activator) key() types.NamespacedName {
+	return types.NamespacedName{Name: a.config.Deployment, Namespace: a.config.Namespace}
+}
+
+// setAnnotations sets annotations on a Deployment
+func setAnnotations(deployment *appsv1.Deployment, annotations map[string]string) {
+	if deployment.Annotations == nil {
+		deployment.Annotations = map[string]string{}
+	}
+	for key, value := range annotations {
+		deployment.Annotations[key] = value
+	}
+}
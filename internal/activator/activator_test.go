@@ -0,0 +1,150 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package activator
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+func newTestActivator(t *testing.T, replicas int32) (*Activator, client.Client) {
+	t.Helper()
+
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _ = io.WriteString(w, "backend "+req.URL.Path)
+	}))
+	t.Cleanup(backend.Close)
+	backendURL, err := url.Parse(backend.URL)
+	require.NoError(t, err)
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "model", Namespace: "default"},
+		Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(replicas)},
+		Status:     appsv1.DeploymentStatus{ReadyReplicas: replicas},
+	}
+	// The fake client has no controller, scaling up makes the replica ready right away
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment).WithInterceptorFuncs(interceptor.Funcs{
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			if err := c.Patch(ctx, obj, patch, opts...); err != nil {
+				return err
+			}
+			d := obj.(*appsv1.Deployment)
+			d.Status.ReadyReplicas = *d.Spec.Replicas
+			return c.Status().Update(ctx, d)
+		},
+	}).Build()
+
+	return New(c, Config{
+		Namespace:        "default",
+		Deployment:       "model",
+		Backend:          backendURL,
+		Models:           []string{"meta-llama/Llama-3.1-8B-Instruct"},
+		ReadyTimeout:     5 * time.Second,
+		PollInterval:     10 * time.Millisecond,
+		ActivityInterval: 10 * time.Millisecond,
+	}), c
+}
+
+func TestActivator_ServeHTTP(t *testing.T) {
+	t.Run("should list the models without scaling up", func(t *testing.T) {
+		a, c := newTestActivator(t, 0)
+
+		rec := httptest.NewRecorder()
+		a.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/models", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var models struct {
+			Data []struct {
+				ID string `json:"id"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &models))
+		require.Len(t, models.Data, 1)
+		assert.Equal(t, "meta-llama/Llama-3.1-8B-Instruct", models.Data[0].ID)
+
+		deployment := &appsv1.Deployment{}
+		require.NoError(t, c.Get(t.Context(), a.key(), deployment))
+		assert.Equal(t, int32(0), *deployment.Spec.Replicas)
+	})
+
+	t.Run("should scale the model up and forward the request", func(t *testing.T) {
+		a, c := newTestActivator(t, 0)
+
+		rec := httptest.NewRecorder()
+		a.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "backend /v1/chat/completions", rec.Body.String())
+
+		deployment := &appsv1.Deployment{}
+		require.NoError(t, c.Get(t.Context(), a.key(), deployment))
+		assert.Equal(t, int32(1), *deployment.Spec.Replicas)
+		assert.Equal(t, "1", deployment.Annotations[llmgeeperiov1alpha1.ActivationsAnnotation])
+		assert.NotEmpty(t, deployment.Annotations[llmgeeperiov1alpha1.LastActivationAnnotation])
+		assert.NotEmpty(t, deployment.Annotations[llmgeeperiov1alpha1.LastColdStartAnnotation])
+	})
+
+	t.Run("should forward requests to a running model", func(t *testing.T) {
+		a, c := newTestActivator(t, 2)
+
+		rec := httptest.NewRecorder()
+		a.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/completions", nil))
+		assert.Equal(t, "backend /v1/completions", rec.Body.String())
+
+		deployment := &appsv1.Deployment{}
+		require.NoError(t, c.Get(t.Context(), a.key(), deployment))
+		assert.Equal(t, int32(2), *deployment.Spec.Replicas)
+		assert.Empty(t, deployment.Annotations[llmgeeperiov1alpha1.ActivationsAnnotation])
+	})
+}
+
+func TestActivator_Run(t *testing.T) {
+	a, c := newTestActivator(t, 1)
+	a.lastRequest.Store(time.Now().UnixNano())
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	go func() { _ = a.Run(ctx) }()
+
+	assert.Eventually(t, func() bool {
+		deployment := &appsv1.Deployment{}
+		if err := c.Get(t.Context(), a.key(), deployment); err != nil {
+			return false
+		}
+		return deployment.Annotations[llmgeeperiov1alpha1.LastRequestAnnotation] != ""
+	}, 5*time.Second, 10*time.Millisecond, "the last request should be recorded on the model Deployment")
+	assert.True(t, a.ready.Load())
+}
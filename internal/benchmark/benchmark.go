@@ -0,0 +1,292 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package benchmark implements the load generator run by Benchmark Jobs.
+// It streams completions from an OpenAI-compatible API at a fixed concurrency and measures
+// the time to the first token, the time per output token and the throughput.
+package benchmark
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+// words make up the generated prompts, common short words are about one token each
+var words = []string{
+	"the", "model", "answer", "question", "time", "water", "light", "house", "river", "city",
+	"green", "small", "large", "open", "write", "read", "story", "about", "world", "people",
+}
+
+// Config configures a benchmark run
+type Config struct {
+	// URL is the base URL of the OpenAI-compatible API, e.g. http://my-model:8000/v1
+	URL string
+
+	// Model is the model requested
+	Model string
+
+	// APIKey is sent as bearer token when set
+	APIKey string
+
+	// Concurrency is the number of requests kept in flight
+	Concurrency int
+
+	// Duration is how long new requests are sent
+	Duration time.Duration
+
+	// MaxRequests ends the run after this many requests, zero for no limit
+	MaxRequests int
+
+	// PromptTokens and OutputTokens are the ranges the lengths of each request are picked from
+	PromptTokens, OutputTokens [2]int
+
+	// RequestTimeout limits a single request
+	RequestTimeout time.Duration
+
+	// Client sends the requests, http.DefaultClient when nil
+	Client *http.Client
+}
+
+// sample is the measurement of a single request
+type sample struct {
+	err                        error
+	ttft, latency              time.Duration
+	promptTokens, outputTokens int
+}
+
+// Run sends requests until the duration passed or the maximum number of requests was sent and summarizes them
+func Run(ctx context.Context, config Config) (*llmgeeperiov1alpha1.BenchmarkResults, error) {
+	if config.Concurrency <= 0 {
+		return nil, errors.New("concurrency must be positive")
+	}
+	if config.Client == nil {
+		config.Client = http.DefaultClient
+	}
+	if config.RequestTimeout == 0 {
+		config.RequestTimeout = 10 * time.Minute
+	}
+
+	start := time.Now()
+	deadline := start.Add(config.Duration)
+	var sent atomic.Int64
+	var mu sync.Mutex
+	var samples []sample
+
+	var wg sync.WaitGroup
+	for range config.Concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil && time.Now().Before(deadline) {
+				if n := sent.Add(1); config.MaxRequests > 0 && n > int64(config.MaxRequests) {
+					return
+				}
+				s := send(ctx, config)
+				mu.Lock()
+				samples = append(samples, s)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return summarize(samples, time.Since(start)), nil
+}
+
+// send streams a single completion and measures it
+func send(ctx context.Context, config Config) sample {
+	ctx, cancel := context.WithTimeout(ctx, config.RequestTimeout)
+	defer cancel()
+
+	promptWords := pick(config.PromptTokens)
+	maxTokens := pick(config.OutputTokens)
+	prompt := make([]string, promptWords)
+	for i := range prompt {
+		prompt[i] = words[rand.IntN(len(words))]
+	}
+	body, _ := json.Marshal(map[string]any{
+		"model":      config.Model,
+		"prompt":     strings.Join(prompt, " "),
+		"max_tokens": maxTokens,
+		// vLLM generates exactly max_tokens, other servers ignore it
+		"ignore_eos":     true,
+		"stream":         true,
+		"stream_options": map[string]bool{"include_usage": true},
+	})
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(config.URL, "/")+"/completions", bytes.NewReader(body))
+	if err != nil {
+		return sample{err: err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if config.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+config.APIKey)
+	}
+
+	start := time.Now()
+	resp, err := config.Client.Do(req)
+	if err != nil {
+		return sample{err: err}
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return sample{err: fmt.Errorf("unexpected status %s", resp.Status)}
+	}
+
+	s := sample{promptTokens: promptWords}
+	var chunks int
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue
+		}
+		data = strings.TrimSpace(data)
+		if data == "[DONE]" {
+			break
+		}
+
+		var chunk struct {
+			Choices []struct {
+				Text string `json:"text"`
+			} `json:"choices"`
+			Usage *struct {
+				PromptTokens     int `json:"prompt_tokens"`
+				CompletionTokens int `json:"completion_tokens"`
+			} `json:"usage"`
+			Error json.RawMessage `json:"error"`
+		}
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return sample{err: fmt.Errorf("invalid stream chunk: %w", err)}
+		}
+		if len(chunk.Error) > 0 {
+			return sample{err: fmt.Errorf("stream error: %s", chunk.Error)}
+		}
+		if len(chunk.Choices) > 0 && chunk.Choices[0].Text != "" {
+			if chunks == 0 {
+				s.ttft = time.Since(start)
+			}
+			chunks++
+		}
+		if chunk.Usage != nil {
+			s.promptTokens = chunk.Usage.PromptTokens
+			s.outputTokens = chunk.Usage.CompletionTokens
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return sample{err: err}
+	}
+	if chunks == 0 {
+		return sample{err: errors.New("no tokens received")}
+	}
+	// Servers without usage reporting send about one token per chunk
+	if s.outputTokens == 0 {
+		s.outputTokens = chunks
+	}
+	s.latency = time.Since(start)
+	return s
+}
+
+// pick returns a count from the range, uniformly
+func pick(r [2]int) int {
+	if r[1] <= r[0] {
+		return max(r[0], 1)
+	}
+	return r[0] + rand.IntN(r[1]-r[0]+1)
+}
+
+// summarize computes the results of a run from its samples
+func summarize(samples []sample, elapsed time.Duration) *llmgeeperiov1alpha1.BenchmarkResults {
+	results := &llmgeeperiov1alpha1.BenchmarkResults{
+		Requests: int64(len(samples)),
+		Duration: metav1.Duration{Duration: elapsed.Round(time.Millisecond)},
+	}
+
+	var ttfts, tpots, latencies []time.Duration
+	for _, s := range samples {
+		if s.err != nil {
+			results.Errors++
+			continue
+		}
+		results.PromptTokens += int64(s.promptTokens)
+		results.OutputTokens += int64(s.outputTokens)
+		ttfts = append(ttfts, s.ttft)
+		latencies = append(latencies, s.latency)
+		if s.outputTokens > 1 {
+			tpots = append(tpots, (s.latency-s.ttft)/time.Duration(s.outputTokens-1))
+		}
+	}
+
+	if results.Requests > 0 {
+		results.ErrorRate = formatFloat(float64(results.Errors) * 100 / float64(results.Requests))
+	}
+	if seconds := elapsed.Seconds(); seconds > 0 {
+		results.RequestsPerSecond = formatFloat(float64(len(latencies)) / seconds)
+		results.OutputTokensPerSecond = formatFloat(float64(results.OutputTokens) / seconds)
+	}
+	results.TimeToFirstToken = percentiles(ttfts)
+	results.TimePerOutputToken = percentiles(tpots)
+	results.Latency = percentiles(latencies)
+	return results
+}
+
+// percentiles returns the mean and the nearest-rank percentiles of the durations
+func percentiles(durations []time.Duration) llmgeeperiov1alpha1.LatencyPercentiles {
+	if len(durations) == 0 {
+		return llmgeeperiov1alpha1.LatencyPercentiles{}
+	}
+	slices.Sort(durations)
+
+	var sum time.Duration
+	for _, d := range durations {
+		sum += d
+	}
+	rank := func(p int) metav1.Duration {
+		i := (len(durations)*p + 99) / 100
+		return metav1.Duration{Duration: durations[max(i-1, 0)].Round(time.Microsecond)}
+	}
+	return llmgeeperiov1alpha1.LatencyPercentiles{
+		Mean: metav1.Duration{Duration: (sum / time.Duration(len(durations))).Round(time.Microsecond)},
+		P50:  rank(50),
+		P90:  rank(90),
+		P99:  rank(99),
+	}
+}
+
+// formatFloat formats a rate or percentage with two decimals
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
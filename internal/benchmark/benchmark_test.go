@@ -0,0 +1,112 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCompletionServer streams the requested number of tokens, every fifth request fails
+func newCompletionServer(t *testing.T) *httptest.Server {
+	var requests atomic.Int64
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/completions", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		if requests.Add(1)%5 == 0 {
+			http.Error(w, "overloaded", http.StatusServiceUnavailable)
+			return
+		}
+
+		var body struct {
+			Model     string `json:"model"`
+			MaxTokens int    `json:"max_tokens"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "llama", body.Model)
+
+		flusher := w.(http.Flusher)
+		w.Header().Set("Content-Type", "text/event-stream")
+		for range body.MaxTokens {
+			_, _ = fmt.Fprint(w, "data: {\"choices\":[{\"text\":\" hi\"}]}\n\n")
+			flusher.Flush()
+			time.Sleep(time.Millisecond)
+		}
+		_, _ = fmt.Fprintf(w, "data: {\"choices\":[],\"usage\":{\"prompt_tokens\":12,\"completion_tokens\":%d}}\n\n", body.MaxTokens)
+		_, _ = fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+}
+
+func TestRun(t *testing.T) {
+	server := newCompletionServer(t)
+	defer server.Close()
+
+	results, err := Run(context.Background(), Config{
+		URL:          server.URL + "/v1",
+		Model:        "llama",
+		APIKey:       "secret",
+		Concurrency:  2,
+		Duration:     time.Minute,
+		MaxRequests:  10,
+		PromptTokens: [2]int{8, 16},
+		OutputTokens: [2]int{4, 4},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(10), results.Requests)
+	assert.Equal(t, int64(2), results.Errors)
+	assert.Equal(t, "20.00", results.ErrorRate)
+	assert.Equal(t, int64(8*12), results.PromptTokens)
+	assert.Equal(t, int64(8*4), results.OutputTokens)
+	assert.Positive(t, results.TimeToFirstToken.P50.Duration)
+	assert.Positive(t, results.TimePerOutputToken.P50.Duration)
+	assert.GreaterOrEqual(t, results.Latency.P99.Duration, results.Latency.P50.Duration)
+	assert.NotEmpty(t, results.OutputTokensPerSecond)
+}
+
+func TestSummarize(t *testing.T) {
+	var samples []sample
+	for i := 1; i <= 100; i++ {
+		samples = append(samples, sample{
+			ttft:         time.Duration(i) * time.Millisecond,
+			latency:      time.Duration(i)*time.Millisecond + 99*10*time.Millisecond,
+			promptTokens: 10,
+			outputTokens: 100,
+		})
+	}
+	samples = append(samples, sample{err: errors.New("timeout")})
+
+	results := summarize(samples, 10*time.Second)
+	assert.Equal(t, int64(101), results.Requests)
+	assert.Equal(t, "0.99", results.ErrorRate)
+	assert.Equal(t, "10.00", results.RequestsPerSecond)
+	assert.Equal(t, "1000.00", results.OutputTokensPerSecond)
+	assert.Equal(t, 50*time.Millisecond, results.TimeToFirstToken.P50.Duration)
+	assert.Equal(t, 90*time.Millisecond, results.TimeToFirstToken.P90.Duration)
+	assert.Equal(t, 99*time.Millisecond, results.TimeToFirstToken.P99.Duration)
+	assert.Equal(t, 10*time.Millisecond, results.TimePerOutputToken.P50.Duration)
+}
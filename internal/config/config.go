@@ -0,0 +1,210 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package config contains the operator-level configuration shared by the webhooks and the reconciler.
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+// Images defines the default image of every component
+type Images struct {
+	// Ollama is the default Ollama image
+	Ollama string `json:"ollama,omitempty"`
+
+	// OllamaROCm is the default Ollama image for the amd flavor
+	OllamaROCm string `json:"ollamaROCm,omitempty"`
+
+	// VLLM is the default vLLM model server image
+	VLLM string `json:"vllm,omitempty"`
+
+	// VLLMRouter is the default vLLM router image
+	VLLMRouter string `json:"vllmRouter,omitempty"`
+
+	// OpenWebUI is the default OpenWebUI image
+	OpenWebUI string `json:"openwebui,omitempty"`
+
+	// Pipelines is the default OpenWebUI Pipelines image
+	Pipelines string `json:"pipelines,omitempty"`
+
+	// Redis is the default Redis image
+	Redis string `json:"redis,omitempty"`
+
+	// Postgres is the default image of the PostgreSQL database of OpenWebUI
+	Postgres string `json:"postgres,omitempty"`
+
+	// Qdrant is the default image of the Qdrant vector database of OpenWebUI
+	Qdrant string `json:"qdrant,omitempty"`
+
+	// TEI is the default text-embeddings-inference image serving embedding models
+	TEI string `json:"tei,omitempty"`
+
+	// Tabby is the default Tabby image
+	Tabby string `json:"tabby,omitempty"`
+
+	// Init is the image used by the generated init containers
+	Init string `json:"init,omitempty"`
+
+	// AuthProxy is the nginx image authenticating requests to the exposed Ollama API
+	AuthProxy string `json:"authProxy,omitempty"`
+
+	// Activator is the image of the activator scaling idle models up on demand, it ships with the operator
+	Activator string `json:"activator,omitempty"`
+
+	// SmokeTest is the image of the Jobs sending a completion request to a model, it needs curl and a shell
+	SmokeTest string `json:"smokeTest,omitempty"`
+
+	// Benchmark is the image of the Jobs running load benchmarks, it ships with the operator
+	Benchmark string `json:"benchmark,omitempty"`
+}
+
+// Resources defines the default resource requirements of every component.
+// They are used when a component does not specify any requests or limits.
+type Resources struct {
+	Ollama     llmgeeperiov1alpha1.ResourceRequirements `json:"ollama,omitempty"`
+	VLLM       llmgeeperiov1alpha1.ResourceRequirements `json:"vllm,omitempty"`
+	VLLMRouter llmgeeperiov1alpha1.ResourceRequirements `json:"vllmRouter,omitempty"`
+	OpenWebUI  llmgeeperiov1alpha1.ResourceRequirements `json:"openwebui,omitempty"`
+	Pipelines  llmgeeperiov1alpha1.ResourceRequirements `json:"pipelines,omitempty"`
+	Redis      llmgeeperiov1alpha1.ResourceRequirements `json:"redis,omitempty"`
+	Postgres   llmgeeperiov1alpha1.ResourceRequirements `json:"postgres,omitempty"`
+	Qdrant     llmgeeperiov1alpha1.ResourceRequirements `json:"qdrant,omitempty"`
+	TEI        llmgeeperiov1alpha1.ResourceRequirements `json:"tei,omitempty"`
+	Tabby      llmgeeperiov1alpha1.ResourceRequirements `json:"tabby,omitempty"`
+}
+
+// OperatorConfig is the operator-level configuration loaded from the config file
+type OperatorConfig struct {
+	// Images are the default images of the components
+	Images Images `json:"images,omitempty"`
+
+	// RegistryMirror is prepended to the default images, e.g. registry.internal/mirror
+	RegistryMirror string `json:"registryMirror,omitempty"`
+
+	// Resources are the default resource requirements of the components
+	Resources Resources `json:"resources,omitempty"`
+
+	// StorageClass is the storage class used for PVCs that do not specify one
+	StorageClass string `json:"storageClass,omitempty"`
+
+	// LangfusePipelineURL is the URL of the Langfuse filter pipeline installed when Langfuse is enabled
+	LangfusePipelineURL string `json:"langfusePipelineURL,omitempty"`
+}
+
+// Default returns the built-in operator configuration
+func Default() *OperatorConfig {
+	return &OperatorConfig{
+		Images: Images{
+			Ollama:     "ollama/ollama:latest",
+			OllamaROCm: "ollama/ollama:rocm",
+			VLLM:       "vllm/vllm-openai:latest",
+			VLLMRouter: "lmcache/lmstack-router:latest",
+			OpenWebUI:  "ghcr.io/open-webui/open-webui:main",
+			Pipelines:  "ghcr.io/open-webui/pipelines:main",
+			Redis:      "redis:7-alpine",
+			Postgres:   "postgres:16-alpine",
+			Qdrant:     "qdrant/qdrant:v1.13.4",
+			TEI:        "ghcr.io/huggingface/text-embeddings-inference:cpu-1.7",
+			Tabby:      "tabbyml/tabby:latest",
+			Init:       "busybox:1.35",
+			AuthProxy:  "nginxinc/nginx-unprivileged:1.27-alpine",
+			Activator:  "ghcr.io/geeper-io/llm-operator:latest",
+			SmokeTest:  "curlimages/curl:8.11.1",
+			Benchmark:  "ghcr.io/geeper-io/llm-operator:latest",
+		},
+		LangfusePipelineURL: "https://github.com/open-webui/pipelines/blob/main/examples/filters/langfuse_filter_pipeline.py",
+	}
+}
+
+// Parse parses a YAML operator configuration, unset images and URLs fall back to the built-in defaults
+func Parse(data []byte) (*OperatorConfig, error) {
+	cfg := Default()
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse operator config: %w", err)
+	}
+
+	defaults := Default()
+	fallback := func(value *string, defaultValue string) {
+		if *value == "" {
+			*value = defaultValue
+		}
+	}
+	fallback(&cfg.Images.Ollama, defaults.Images.Ollama)
+	fallback(&cfg.Images.OllamaROCm, defaults.Images.OllamaROCm)
+	fallback(&cfg.Images.VLLM, defaults.Images.VLLM)
+	fallback(&cfg.Images.VLLMRouter, defaults.Images.VLLMRouter)
+	fallback(&cfg.Images.OpenWebUI, defaults.Images.OpenWebUI)
+	fallback(&cfg.Images.Pipelines, defaults.Images.Pipelines)
+	fallback(&cfg.Images.Redis, defaults.Images.Redis)
+	fallback(&cfg.Images.Postgres, defaults.Images.Postgres)
+	fallback(&cfg.Images.Qdrant, defaults.Images.Qdrant)
+	fallback(&cfg.Images.TEI, defaults.Images.TEI)
+	fallback(&cfg.Images.Tabby, defaults.Images.Tabby)
+	fallback(&cfg.Images.Init, defaults.Images.Init)
+	fallback(&cfg.Images.AuthProxy, defaults.Images.AuthProxy)
+	fallback(&cfg.Images.Activator, defaults.Images.Activator)
+	fallback(&cfg.Images.SmokeTest, defaults.Images.SmokeTest)
+	fallback(&cfg.Images.Benchmark, defaults.Images.Benchmark)
+	fallback(&cfg.LangfusePipelineURL, defaults.LangfusePipelineURL)
+	cfg.RegistryMirror = strings.TrimSuffix(cfg.RegistryMirror, "/")
+
+	return cfg, nil
+}
+
+// Load reads the operator configuration from a file
+func Load(path string) (*OperatorConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read operator config %s: %w", path, err)
+	}
+	return Parse(data)
+}
+
+// Image returns the given default image prefixed with the registry mirror, if any
+func (c *OperatorConfig) Image(image string) string {
+	if c.RegistryMirror == "" || image == "" || strings.HasPrefix(image, c.RegistryMirror+"/") {
+		return image
+	}
+	return c.RegistryMirror + "/" + image
+}
+
+// ResourcesOrDefault returns the given resources, or the defaults when neither requests nor limits are set
+func ResourcesOrDefault(resources, defaults llmgeeperiov1alpha1.ResourceRequirements) llmgeeperiov1alpha1.ResourceRequirements {
+	if len(resources.Requests) == 0 && len(resources.Limits) == 0 {
+		return defaults
+	}
+	return resources
+}
+
+// StorageClassOrDefault returns the given storage class or the default one.
+// Nil is returned when neither is set so that the cluster default storage class is used.
+func (c *OperatorConfig) StorageClassOrDefault(storageClass string) *string {
+	if storageClass != "" {
+		return &storageClass
+	}
+	if c.StorageClass != "" {
+		storageClass = c.StorageClass
+		return &storageClass
+	}
+	return nil
+}
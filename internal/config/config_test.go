@@ -0,0 +1,142 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("should fall back to the built-in defaults", func(t *testing.T) {
+		cfg, err := Parse([]byte(`
+images:
+  tabby: registry.internal/tabbyml/tabby:0.30.0
+storageClass: fast
+resources:
+  ollama:
+    limits:
+      memory: 8Gi
+`))
+		require.NoError(t, err)
+
+		assert.Equal(t, "registry.internal/tabbyml/tabby:0.30.0", cfg.Images.Tabby)
+		assert.Equal(t, "ollama/ollama:latest", cfg.Images.Ollama)
+		assert.Equal(t, "busybox:1.35", cfg.Images.Init)
+		assert.Equal(t, "nginxinc/nginx-unprivileged:1.27-alpine", cfg.Images.AuthProxy)
+		assert.Equal(t, "ghcr.io/geeper-io/llm-operator:latest", cfg.Images.Activator)
+		assert.Equal(t, "curlimages/curl:8.11.1", cfg.Images.SmokeTest)
+		assert.Equal(t, "ghcr.io/geeper-io/llm-operator:latest", cfg.Images.Benchmark)
+		assert.Equal(t, "postgres:16-alpine", cfg.Images.Postgres)
+		assert.Equal(t, "qdrant/qdrant:v1.13.4", cfg.Images.Qdrant)
+		assert.Equal(t, "ghcr.io/huggingface/text-embeddings-inference:cpu-1.7", cfg.Images.TEI)
+		assert.Equal(t, "fast", cfg.StorageClass)
+		assert.Equal(t, resource.MustParse("8Gi"), cfg.Resources.Ollama.Limits[corev1.ResourceMemory])
+		assert.Equal(t, Default().LangfusePipelineURL, cfg.LangfusePipelineURL)
+	})
+
+	t.Run("should reject unknown fields", func(t *testing.T) {
+		_, err := Parse([]byte("imagez: {}"))
+		assert.Error(t, err)
+	})
+
+	t.Run("should accept an empty file", func(t *testing.T) {
+		cfg, err := Parse(nil)
+		require.NoError(t, err)
+		assert.Equal(t, Default(), cfg)
+	})
+}
+
+func TestOperatorConfig_Image(t *testing.T) {
+	cfg := Default()
+	assert.Equal(t, "redis:7-alpine", cfg.Image(cfg.Images.Redis))
+
+	cfg.RegistryMirror = "registry.internal/mirror"
+	assert.Equal(t, "registry.internal/mirror/redis:7-alpine", cfg.Image(cfg.Images.Redis))
+	assert.Equal(t, "registry.internal/mirror/redis:7-alpine", cfg.Image("registry.internal/mirror/redis:7-alpine"), "already mirrored images are kept")
+}
+
+func TestOperatorConfig_StorageClassOrDefault(t *testing.T) {
+	cfg := Default()
+	assert.Nil(t, cfg.StorageClassOrDefault(""), "the cluster default storage class is used when nothing is set")
+	assert.Equal(t, "standard", *cfg.StorageClassOrDefault("standard"))
+
+	cfg.StorageClass = "fast"
+	assert.Equal(t, "fast", *cfg.StorageClassOrDefault(""))
+	assert.Equal(t, "standard", *cfg.StorageClassOrDefault("standard"))
+}
+
+func TestStore(t *testing.T) {
+	t.Run("should serve the built-in defaults when nil", func(t *testing.T) {
+		var store *Store
+		assert.Equal(t, Default(), store.Get())
+	})
+
+	t.Run("should reload the config file when it changes", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("registryMirror: mirror.internal/\n"), 0o600))
+
+		store, err := NewStore(path)
+		require.NoError(t, err)
+		assert.Equal(t, "mirror.internal", store.Get().RegistryMirror)
+
+		changed, err := store.reload()
+		require.NoError(t, err)
+		assert.False(t, changed)
+
+		require.NoError(t, os.WriteFile(path, []byte("storageClass: fast\n"), 0o600))
+		changed, err = store.reload()
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, "fast", store.Get().StorageClass)
+		assert.Empty(t, store.Get().RegistryMirror)
+	})
+
+	t.Run("should keep the last valid config", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("storageClass: fast\n"), 0o600))
+
+		store, err := NewStore(path)
+		require.NoError(t, err)
+
+		require.NoError(t, os.WriteFile(path, []byte("storageClass: [\n"), 0o600))
+		_, err = store.reload()
+		assert.Error(t, err)
+		assert.Equal(t, "fast", store.Get().StorageClass)
+	})
+
+	t.Run("should start with the defaults when the file is missing", func(t *testing.T) {
+		store, err := NewStore(filepath.Join(t.TempDir(), "missing.yaml"))
+		require.NoError(t, err)
+		assert.Equal(t, Default(), store.Get())
+	})
+
+	t.Run("should notify about changes without blocking", func(t *testing.T) {
+		store, err := NewStore("")
+		require.NoError(t, err)
+
+		store.Set(Default())
+		store.Set(Default())
+		assert.Len(t, store.Changes(), 1)
+	})
+}
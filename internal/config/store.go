@@ -0,0 +1,145 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"sync/atomic"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// DefaultReloadInterval is how often the config file is checked for changes
+const DefaultReloadInterval = 10 * time.Second
+
+var storelog = logf.Log.WithName("operator-config")
+
+// Store holds the current operator configuration and reloads it when the config file changes.
+// A nil Store serves the built-in defaults.
+type Store struct {
+	path     string
+	interval time.Duration
+	current  atomic.Pointer[OperatorConfig]
+	data     []byte
+	changes  chan event.GenericEvent
+}
+
+// NewStore creates a Store serving the built-in defaults, or the content of the config file when a path is given
+func NewStore(path string) (*Store, error) {
+	s := &Store{
+		path:     path,
+		interval: DefaultReloadInterval,
+		changes:  make(chan event.GenericEvent, 1),
+	}
+	s.current.Store(Default())
+
+	if path != "" {
+		if _, err := s.reload(); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Get returns the current operator configuration
+func (s *Store) Get() *OperatorConfig {
+	if s == nil {
+		return Default()
+	}
+	return s.current.Load()
+}
+
+// Set replaces the current operator configuration and notifies the subscribers
+func (s *Store) Set(cfg *OperatorConfig) {
+	s.current.Store(cfg)
+	s.notify()
+}
+
+// Changes returns a channel receiving an event every time the configuration is reloaded.
+// It is meant to be consumed by a single controller.
+func (s *Store) Changes() <-chan event.GenericEvent {
+	return s.changes
+}
+
+// Start polls the config file until the context is cancelled.
+// Invalid configurations are logged and the last valid one is kept.
+func (s *Store) Start(ctx context.Context) error {
+	if s.path == "" {
+		return nil
+	}
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			changed, err := s.reload()
+			if err != nil {
+				storelog.Error(err, "Failed to reload operator config, keeping the previous one", "path", s.path)
+				continue
+			}
+			if changed {
+				storelog.Info("Reloaded operator config", "path", s.path)
+				s.notify()
+			}
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, the webhooks read the config on every replica
+func (s *Store) NeedLeaderElection() bool {
+	return false
+}
+
+// reload reads the config file and reports whether its content changed.
+// A missing file is treated as empty so that the operator starts with the built-in defaults.
+func (s *Store) reload() (bool, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+	if data == nil {
+		data = []byte{}
+	}
+	if s.data != nil && bytes.Equal(data, s.data) {
+		return false, nil
+	}
+
+	cfg, err := Parse(data)
+	if err != nil {
+		return false, err
+	}
+	s.data = data
+	s.current.Store(cfg)
+	return true, nil
+}
+
+// notify sends a change event without blocking, a pending event already covers the latest change
+func (s *Store) notify() {
+	select {
+	case s.changes <- event.GenericEvent{Object: &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "operator-config"}}}:
+	default:
+	}
+}
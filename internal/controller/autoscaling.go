@@ -0,0 +1,401 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+const (
+	// autoscalingEngineHPA scales a workload with a HorizontalPodAutoscaler
+	autoscalingEngineHPA = "HPA"
+
+	// autoscalingEngineKEDA scales a workload with a KEDA ScaledObject
+	autoscalingEngineKEDA = "KEDA"
+
+	// kedaHPAPrefix is the prefix of the HorizontalPodAutoscaler KEDA creates for a ScaledObject
+	kedaHPAPrefix = "keda-hpa-"
+)
+
+// Names of the vLLM metrics in the custom metrics API, as exposed by the usual prometheus-adapter rules
+const (
+	vllmRunningRequestsMetric = "vllm_num_requests_running"
+	vllmWaitingRequestsMetric = "vllm_num_requests_waiting"
+	vllmKVCacheUsageMetric    = "vllm_gpu_cache_usage_perc"
+)
+
+// KEDA is an optional installation, ScaledObjects are handled as unstructured objects like HTTPRoutes
+var scaledObjectGVK = schema.GroupVersionKind{Group: "keda.sh", Version: "v1alpha1", Kind: "ScaledObject"}
+
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=keda.sh,resources=scaledobjects,verbs=get;list;watch;create;update;patch;delete
+
+// newScaledObject returns an empty ScaledObject object
+func newScaledObject() *unstructured.Unstructured {
+	scaledObject := &unstructured.Unstructured{}
+	scaledObject.SetGroupVersionKind(scaledObjectGVK)
+	return scaledObject
+}
+
+// kedaAvailable reports whether the ScaledObject CRD is installed in the cluster
+func (r *LMDeploymentReconciler) kedaAvailable() (bool, error) {
+	_, err := r.RESTMapper().RESTMapping(scaledObjectGVK.GroupKind(), scaledObjectGVK.Version)
+	if meta.IsNoMatchError(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// scaledReplicas returns the replica count of a workload that may be autoscaled.
+// Autoscaled workloads get no replica count, the one set by the autoscaler is kept.
+// Workloads set to KEDA keep their replicas while the KEDA CRDs are missing, nothing would scale them.
+func (r *LMDeploymentReconciler) scaledReplicas(deployment *llmgeeperiov1alpha1.LMDeployment, replicas int32, autoscaling *llmgeeperiov1alpha1.AutoscalingSpec) *int32 {
+	if autoscaling != nil && !deployment.Spec.Suspend && !r.kedaMissing(autoscaling) {
+		return nil
+	}
+	return r.desiredReplicas(deployment, replicas)
+}
+
+// kedaMissing reports whether KEDA is selected explicitly but its CRDs are not installed
+func (r *LMDeploymentReconciler) kedaMissing(autoscaling *llmgeeperiov1alpha1.AutoscalingSpec) bool {
+	if autoscaling.Engine != autoscalingEngineKEDA {
+		return false
+	}
+	available, err := r.kedaAvailable()
+	return err == nil && !available
+}
+
+// autoscalingEngine returns the engine scaling a workload, KEDA is preferred when installed and able to read the targets
+func (r *LMDeploymentReconciler) autoscalingEngine(autoscaling *llmgeeperiov1alpha1.AutoscalingSpec) (string, error) {
+	if autoscaling.Engine != "" {
+		return autoscaling.Engine, nil
+	}
+	available, err := r.kedaAvailable()
+	if err != nil {
+		return "", fmt.Errorf("failed to discover KEDA: %w", err)
+	}
+	if available && (autoscaling.PrometheusAddress != "" || !usesInferenceMetrics(autoscaling)) {
+		return autoscalingEngineKEDA, nil
+	}
+	return autoscalingEngineHPA, nil
+}
+
+// usesInferenceMetrics reports whether any target is read from the vLLM metrics
+func usesInferenceMetrics(autoscaling *llmgeeperiov1alpha1.AutoscalingSpec) bool {
+	return autoscaling.TargetRunningRequests != nil || autoscaling.TargetWaitingRequests != nil || autoscaling.TargetKVCacheUtilization != nil
+}
+
+// minReplicas returns the lower limit of replicas of an autoscaled workload
+func minReplicas(autoscaling *llmgeeperiov1alpha1.AutoscalingSpec) int32 {
+	if autoscaling.MinReplicas == nil {
+		return 1
+	}
+	return *autoscaling.MinReplicas
+}
+
+// reconcileAutoscaler creates the HPA or ScaledObject of a workload and removes the ones no longer used.
+// Suspended deployments are not autoscaled, the autoscaler would scale them back up.
+func (r *LMDeploymentReconciler) reconcileAutoscaler(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment, workload *appsv1.Deployment, autoscaling *llmgeeperiov1alpha1.AutoscalingSpec) error {
+	engine := ""
+	if autoscaling != nil && !deployment.Spec.Suspend {
+		var err error
+		if engine, err = r.autoscalingEngine(autoscaling); err != nil {
+			return err
+		}
+	}
+
+	switch engine {
+	case autoscalingEngineHPA:
+		if err := r.createOrUpdateHPA(ctx, r.buildHPA(deployment, workload, autoscaling)); err != nil {
+			return err
+		}
+	case autoscalingEngineKEDA:
+		if err := r.createOrUpdateScaledObject(ctx, r.buildScaledObject(deployment, workload, autoscaling)); err != nil {
+			return err
+		}
+	}
+
+	if engine != autoscalingEngineHPA {
+		if err := r.deleteControlled(ctx, deployment, &autoscalingv2.HorizontalPodAutoscaler{}, workload.Name); err != nil {
+			return err
+		}
+	}
+	if engine != autoscalingEngineKEDA {
+		available, err := r.kedaAvailable()
+		if err != nil {
+			return fmt.Errorf("failed to discover KEDA: %w", err)
+		}
+		if available {
+			if err := r.deleteControlled(ctx, deployment, newScaledObject(), workload.Name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// deleteControlled deletes the named object if the deployment controls it
+func (r *LMDeploymentReconciler) deleteControlled(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment, obj client.Object, name string) error {
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: deployment.Namespace}, obj)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(obj, deployment) {
+		return nil
+	}
+	if err := r.Delete(ctx, obj); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete %s: %w", obj.GetName(), err)
+	}
+	return nil
+}
+
+// buildHPA builds the HorizontalPodAutoscaler of a workload, inference targets are read from the custom metrics API
+func (r *LMDeploymentReconciler) buildHPA(deployment *llmgeeperiov1alpha1.LMDeployment, workload *appsv1.Deployment, autoscaling *llmgeeperiov1alpha1.AutoscalingSpec) *autoscalingv2.HorizontalPodAutoscaler {
+	podsMetric := func(name string, target resource.Quantity) autoscalingv2.MetricSpec {
+		return autoscalingv2.MetricSpec{
+			Type: autoscalingv2.PodsMetricSourceType,
+			Pods: &autoscalingv2.PodsMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{Name: name},
+				Target: autoscalingv2.MetricTarget{
+					Type:         autoscalingv2.AverageValueMetricType,
+					AverageValue: &target,
+				},
+			},
+		}
+	}
+
+	var metrics []autoscalingv2.MetricSpec
+	if autoscaling.TargetRunningRequests != nil {
+		metrics = append(metrics, podsMetric(vllmRunningRequestsMetric, *resource.NewQuantity(int64(*autoscaling.TargetRunningRequests), resource.DecimalSI)))
+	}
+	if autoscaling.TargetWaitingRequests != nil {
+		metrics = append(metrics, podsMetric(vllmWaitingRequestsMetric, *resource.NewQuantity(int64(*autoscaling.TargetWaitingRequests), resource.DecimalSI)))
+	}
+	if autoscaling.TargetKVCacheUtilization != nil {
+		// vLLM reports the KV-cache usage as a ratio
+		metrics = append(metrics, podsMetric(vllmKVCacheUsageMetric, *resource.NewMilliQuantity(int64(*autoscaling.TargetKVCacheUtilization)*10, resource.DecimalSI)))
+	}
+	if autoscaling.TargetCPUUtilization != nil {
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: corev1.ResourceCPU,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: autoscaling.TargetCPUUtilization,
+				},
+			},
+		})
+	}
+
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      workload.Name,
+			Namespace: workload.Namespace,
+			Labels:    mergeStringMaps(workload.Labels),
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       workload.Name,
+			},
+			MinReplicas: ptr.To(minReplicas(autoscaling)),
+			MaxReplicas: autoscaling.MaxReplicas,
+			Metrics:     metrics,
+		},
+	}
+
+	// Set owner reference
+	_ = controllerutil.SetControllerReference(deployment, hpa, r.Scheme)
+	return hpa
+}
+
+// buildScaledObject builds the KEDA ScaledObject of a workload, inference targets are queried from Prometheus
+func (r *LMDeploymentReconciler) buildScaledObject(deployment *llmgeeperiov1alpha1.LMDeployment, workload *appsv1.Deployment, autoscaling *llmgeeperiov1alpha1.AutoscalingSpec) *unstructured.Unstructured {
+	selector := fmt.Sprintf(`namespace=%q,pod=~"%s-[a-z0-9]+-[a-z0-9]+"`, workload.Namespace, workload.Name)
+	prometheusTrigger := func(name, query, metricType, threshold string) interface{} {
+		return map[string]interface{}{
+			"type":       "prometheus",
+			"name":       name,
+			"metricType": metricType,
+			"metadata": map[string]interface{}{
+				"serverAddress": autoscaling.PrometheusAddress,
+				"query":         query,
+				"threshold":     threshold,
+			},
+		}
+	}
+
+	var triggers []interface{}
+	if autoscaling.PrometheusAddress != "" {
+		if target := autoscaling.TargetRunningRequests; target != nil {
+			query := fmt.Sprintf("sum(vllm:num_requests_running{%s})", selector)
+			triggers = append(triggers, prometheusTrigger("running-requests", query, "AverageValue", strconv.Itoa(int(*target))))
+		}
+		if target := autoscaling.TargetWaitingRequests; target != nil {
+			query := fmt.Sprintf("sum(vllm:num_requests_waiting{%s})", selector)
+			triggers = append(triggers, prometheusTrigger("waiting-requests", query, "AverageValue", strconv.Itoa(int(*target))))
+		}
+		if target := autoscaling.TargetKVCacheUtilization; target != nil {
+			query := fmt.Sprintf("avg(vllm:gpu_cache_usage_perc{%s})", selector)
+			triggers = append(triggers, prometheusTrigger("kv-cache-utilization", query, "Value", strconv.FormatFloat(float64(*target)/100, 'f', -1, 64)))
+		}
+	}
+	if target := autoscaling.TargetCPUUtilization; target != nil {
+		triggers = append(triggers, map[string]interface{}{
+			"type":       "cpu",
+			"metricType": "Utilization",
+			"metadata": map[string]interface{}{
+				"value": strconv.Itoa(int(*target)),
+			},
+		})
+	}
+
+	scaledObject := newScaledObject()
+	scaledObject.SetName(workload.Name)
+	scaledObject.SetNamespace(workload.Namespace)
+	scaledObject.SetLabels(mergeStringMaps(workload.Labels))
+	scaledObject.Object["spec"] = map[string]interface{}{
+		"scaleTargetRef": map[string]interface{}{
+			"name": workload.Name,
+		},
+		"minReplicaCount": int64(minReplicas(autoscaling)),
+		"maxReplicaCount": int64(autoscaling.MaxReplicas),
+		"triggers":        triggers,
+	}
+
+	// Set owner reference
+	_ = controllerutil.SetControllerReference(deployment, scaledObject, r.Scheme)
+	return scaledObject
+}
+
+// createOrUpdateHPA creates or updates a HorizontalPodAutoscaler using patch helper to avoid unnecessary reconciliations
+func (r *LMDeploymentReconciler) createOrUpdateHPA(ctx context.Context, hpa *autoscalingv2.HorizontalPodAutoscaler) error {
+	existing := &autoscalingv2.HorizontalPodAutoscaler{}
+	err := r.Get(ctx, types.NamespacedName{Name: hpa.Name, Namespace: hpa.Namespace}, existing)
+	if err != nil && errors.IsNotFound(err) {
+		// Create new HorizontalPodAutoscaler
+		if err := r.Create(ctx, hpa); err != nil {
+			return err
+		}
+	} else if err == nil {
+		// Update existing HorizontalPodAutoscaler using patch helper
+		if !reflect.DeepEqual(existing.Spec, hpa.Spec) {
+			patchHelper, err := patch.NewHelper(existing, r.Client)
+			if err != nil {
+				return fmt.Errorf("failed to create patch helper for HorizontalPodAutoscaler %s: %w", hpa.Name, err)
+			}
+
+			existing.Spec = hpa.Spec
+			if err := patchHelper.Patch(ctx, existing); err != nil {
+				return fmt.Errorf("failed to patch HorizontalPodAutoscaler %s: %w", hpa.Name, err)
+			}
+		}
+	} else {
+		return err
+	}
+	return nil
+}
+
+// createOrUpdateScaledObject creates or updates a ScaledObject, it fails when the KEDA CRDs are not installed
+func (r *LMDeploymentReconciler) createOrUpdateScaledObject(ctx context.Context, scaledObject *unstructured.Unstructured) error {
+	available, err := r.kedaAvailable()
+	if err != nil {
+		return fmt.Errorf("failed to discover KEDA: %w", err)
+	}
+	if !available {
+		return fmt.Errorf("autoscaling engine KEDA is selected for %s but the KEDA CRDs are not installed", scaledObject.GetName())
+	}
+
+	existing := newScaledObject()
+	err = r.Get(ctx, types.NamespacedName{Name: scaledObject.GetName(), Namespace: scaledObject.GetNamespace()}, existing)
+	if err != nil && errors.IsNotFound(err) {
+		// Create new ScaledObject
+		if err := r.Create(ctx, scaledObject); err != nil {
+			return err
+		}
+	} else if err == nil {
+		// Update existing ScaledObject using patch helper
+		if !reflect.DeepEqual(existing.Object["spec"], scaledObject.Object["spec"]) {
+			patchHelper, err := patch.NewHelper(existing, r.Client)
+			if err != nil {
+				return fmt.Errorf("failed to create patch helper for ScaledObject %s: %w", scaledObject.GetName(), err)
+			}
+
+			existing.Object["spec"] = scaledObject.Object["spec"]
+			if err := patchHelper.Patch(ctx, existing); err != nil {
+				return fmt.Errorf("failed to patch ScaledObject %s: %w", scaledObject.GetName(), err)
+			}
+		}
+	} else {
+		return err
+	}
+	return nil
+}
+
+// setAutoscalingStatus records the scale of an autoscaled workload in the component status.
+// The scale is read from the workload's HorizontalPodAutoscaler, KEDA scales through an HPA of its own.
+func (r *LMDeploymentReconciler) setAutoscalingStatus(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment, workloadName string, autoscaling *llmgeeperiov1alpha1.AutoscalingSpec, statuses *[]llmgeeperiov1alpha1.AutoscalingStatus) {
+	if autoscaling == nil || deployment.Spec.Suspend {
+		return
+	}
+
+	status := llmgeeperiov1alpha1.AutoscalingStatus{
+		Name:        workloadName,
+		MinReplicas: minReplicas(autoscaling),
+		MaxReplicas: autoscaling.MaxReplicas,
+	}
+	for _, candidate := range []struct{ engine, name string }{
+		{autoscalingEngineHPA, workloadName},
+		{autoscalingEngineKEDA, kedaHPAPrefix + workloadName},
+	} {
+		hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+		if err := r.Get(ctx, types.NamespacedName{Name: candidate.name, Namespace: deployment.Namespace}, hpa); err != nil {
+			continue
+		}
+		status.Engine = candidate.engine
+		status.CurrentReplicas = hpa.Status.CurrentReplicas
+		status.DesiredReplicas = hpa.Status.DesiredReplicas
+		status.LastScaleTime = hpa.Status.LastScaleTime
+		break
+	}
+	*statuses = append(*statuses, status)
+}
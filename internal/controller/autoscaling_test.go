@@ -0,0 +1,200 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+func newAutoscalingTestDeployment() *llmgeeperiov1alpha1.LMDeployment {
+	return &llmgeeperiov1alpha1.LMDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "default", UID: "uid"},
+		Spec: llmgeeperiov1alpha1.LMDeploymentSpec{
+			VLLM: llmgeeperiov1alpha1.VLLMSpec{
+				Enabled: true,
+				Models: []llmgeeperiov1alpha1.VLLMModelSpec{
+					{
+						Name:     "llama",
+						Model:    "meta-llama/Llama-3.1-8B-Instruct",
+						Replicas: 1,
+						Autoscaling: &llmgeeperiov1alpha1.AutoscalingSpec{
+							MinReplicas:              ptr.To(int32(2)),
+							MaxReplicas:              6,
+							TargetRunningRequests:    ptr.To(int32(8)),
+							TargetKVCacheUtilization: ptr.To(int32(80)),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestAutoscaling_HPA(t *testing.T) {
+	scheme := newTestScheme(t)
+	deployment := newAutoscalingTestDeployment()
+	modelSpec := deployment.Spec.VLLM.Models[0]
+	reconciler := &LMDeploymentReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Scheme: scheme,
+	}
+	workload := reconciler.buildVLLMModelDeployment(deployment, modelSpec)
+	key := types.NamespacedName{Name: workload.Name, Namespace: "default"}
+
+	t.Run("should leave the replicas to the autoscaler", func(t *testing.T) {
+		assert.Nil(t, workload.Spec.Replicas)
+
+		existing := workload.DeepCopy()
+		existing.Spec.Replicas = ptr.To(int32(4))
+		require.NoError(t, reconciler.Create(t.Context(), existing))
+
+		require.NoError(t, reconciler.createOrUpdateDeployment(t.Context(), reconciler.buildVLLMModelDeployment(deployment, modelSpec)))
+		current := &appsv1.Deployment{}
+		require.NoError(t, reconciler.Get(t.Context(), key, current))
+		assert.Equal(t, int32(4), *current.Spec.Replicas)
+	})
+
+	t.Run("should create an HPA on the vLLM metrics without KEDA", func(t *testing.T) {
+		require.NoError(t, reconciler.reconcileAutoscaler(t.Context(), deployment, workload, modelSpec.Autoscaling))
+
+		hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+		require.NoError(t, reconciler.Get(t.Context(), key, hpa))
+		assert.Equal(t, workload.Name, hpa.Spec.ScaleTargetRef.Name)
+		assert.Equal(t, int32(2), *hpa.Spec.MinReplicas)
+		assert.Equal(t, int32(6), hpa.Spec.MaxReplicas)
+		require.Len(t, hpa.Spec.Metrics, 2)
+		assert.Equal(t, vllmRunningRequestsMetric, hpa.Spec.Metrics[0].Pods.Metric.Name)
+		assert.Equal(t, 0, hpa.Spec.Metrics[0].Pods.Target.AverageValue.Cmp(resource.MustParse("8")))
+		assert.Equal(t, 0, hpa.Spec.Metrics[1].Pods.Target.AverageValue.Cmp(resource.MustParse("0.8")))
+	})
+
+	t.Run("should report the scale of the autoscaler", func(t *testing.T) {
+		hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+		require.NoError(t, reconciler.Get(t.Context(), key, hpa))
+		hpa.Status.CurrentReplicas = 3
+		hpa.Status.DesiredReplicas = 4
+		require.NoError(t, reconciler.Update(t.Context(), hpa))
+
+		var statuses []llmgeeperiov1alpha1.AutoscalingStatus
+		reconciler.setAutoscalingStatus(t.Context(), deployment, workload.Name, modelSpec.Autoscaling, &statuses)
+		require.Len(t, statuses, 1)
+		assert.Equal(t, "HPA", statuses[0].Engine)
+		assert.Equal(t, int32(3), statuses[0].CurrentReplicas)
+		assert.Equal(t, int32(4), statuses[0].DesiredReplicas)
+		assert.Equal(t, int32(6), statuses[0].MaxReplicas)
+	})
+
+	t.Run("should remove the HPA while suspended", func(t *testing.T) {
+		deployment.Spec.Suspend = true
+		defer func() { deployment.Spec.Suspend = false }()
+
+		assert.Equal(t, int32(0), *reconciler.buildVLLMModelDeployment(deployment, modelSpec).Spec.Replicas)
+		require.NoError(t, reconciler.reconcileAutoscaler(t.Context(), deployment, workload, modelSpec.Autoscaling))
+		err := reconciler.Get(t.Context(), key, &autoscalingv2.HorizontalPodAutoscaler{})
+		assert.True(t, errors.IsNotFound(err))
+	})
+}
+
+func TestAutoscaling_KEDA(t *testing.T) {
+	scheme := newTestScheme(t)
+	deployment := newAutoscalingTestDeployment()
+	modelSpec := deployment.Spec.VLLM.Models[0]
+	modelSpec.Autoscaling.PrometheusAddress = "http://prometheus.monitoring:9090"
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(scaledObjectGVK, meta.RESTScopeNamespace)
+	mapper.Add(autoscalingv2.SchemeGroupVersion.WithKind("HorizontalPodAutoscaler"), meta.RESTScopeNamespace)
+	reconciler := &LMDeploymentReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).Build(),
+		Scheme: scheme,
+	}
+	workload := reconciler.buildVLLMModelDeployment(deployment, modelSpec)
+	key := types.NamespacedName{Name: workload.Name, Namespace: "default"}
+
+	t.Run("should fall back to an HPA without a Prometheus address", func(t *testing.T) {
+		engine, err := reconciler.autoscalingEngine(&llmgeeperiov1alpha1.AutoscalingSpec{MaxReplicas: 2, TargetWaitingRequests: ptr.To(int32(1))})
+		require.NoError(t, err)
+		assert.Equal(t, "HPA", engine)
+	})
+
+	t.Run("should query the vLLM metrics of the model pods", func(t *testing.T) {
+		require.NoError(t, reconciler.reconcileAutoscaler(t.Context(), deployment, workload, modelSpec.Autoscaling))
+
+		scaledObject := newScaledObject()
+		require.NoError(t, reconciler.Get(t.Context(), key, scaledObject))
+		spec := scaledObject.Object["spec"].(map[string]interface{})
+		assert.Equal(t, int64(2), spec["minReplicaCount"])
+		assert.Equal(t, int64(6), spec["maxReplicaCount"])
+
+		triggers := spec["triggers"].([]interface{})
+		require.Len(t, triggers, 2)
+		running := triggers[0].(map[string]interface{})["metadata"].(map[string]interface{})
+		assert.Equal(t, `sum(vllm:num_requests_running{namespace="default",pod=~"test-deployment-vllm-llama-[a-z0-9]+-[a-z0-9]+"})`, running["query"])
+		assert.Equal(t, "8", running["threshold"])
+		kvCache := triggers[1].(map[string]interface{})
+		assert.Equal(t, "Value", kvCache["metricType"])
+		assert.Equal(t, "0.8", kvCache["metadata"].(map[string]interface{})["threshold"])
+	})
+
+	t.Run("should replace the ScaledObject when switching to an HPA", func(t *testing.T) {
+		modelSpec.Autoscaling.Engine = "HPA"
+		require.NoError(t, reconciler.reconcileAutoscaler(t.Context(), deployment, workload, modelSpec.Autoscaling))
+
+		assert.True(t, errors.IsNotFound(reconciler.Get(t.Context(), key, newScaledObject())))
+		assert.NoError(t, reconciler.Get(t.Context(), key, &autoscalingv2.HorizontalPodAutoscaler{}))
+	})
+}
+
+func TestAutoscaling_KEDAMissing(t *testing.T) {
+	scheme := newTestScheme(t)
+	deployment := newAutoscalingTestDeployment()
+	modelSpec := deployment.Spec.VLLM.Models[0]
+	modelSpec.Autoscaling.Engine = "KEDA"
+	modelSpec.Autoscaling.PrometheusAddress = "http://prometheus.monitoring:9090"
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(autoscalingv2.SchemeGroupVersion.WithKind("HorizontalPodAutoscaler"), meta.RESTScopeNamespace)
+	reconciler := &LMDeploymentReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).Build(),
+		Scheme: scheme,
+	}
+	workload := reconciler.buildVLLMModelDeployment(deployment, modelSpec)
+
+	t.Run("should keep the spec replicas", func(t *testing.T) {
+		require.NotNil(t, workload.Spec.Replicas)
+		assert.Equal(t, int32(1), *workload.Spec.Replicas)
+	})
+
+	t.Run("should fail instead of skipping the ScaledObject", func(t *testing.T) {
+		err := reconciler.reconcileAutoscaler(t.Context(), deployment, workload, modelSpec.Autoscaling)
+		assert.ErrorContains(t, err, "KEDA CRDs are not installed")
+	})
+}
@@ -0,0 +1,327 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/cluster-api/util/patch"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+	operatorconfig "github.com/geeper-io/llm-operator/internal/config"
+)
+
+const (
+	// benchmarkPendingRequeue is how often a benchmark waiting for its target is retried
+	benchmarkPendingRequeue = 30 * time.Second
+
+	// benchmarkGracePeriod is added to the duration of a benchmark for the requests in flight at its end
+	benchmarkGracePeriod = 15 * time.Minute
+)
+
+// BenchmarkReconciler reconciles a Benchmark object
+type BenchmarkReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// Config provides the operator-level defaults, the built-in defaults are used when nil
+	Config *operatorconfig.Store
+}
+
+// benchmarkTarget is the resolved endpoint of a benchmark
+type benchmarkTarget struct {
+	url    string
+	model  string
+	apiKey *corev1.SecretKeySelector
+}
+
+// +kubebuilder:rbac:groups=llm.geeper.io,resources=benchmarks,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=llm.geeper.io,resources=benchmarks/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=llm.geeper.io,resources=benchmarks/finalizers,verbs=update
+
+// Reconcile starts the benchmark Job once its target exists and reports the results of the Job
+func (r *BenchmarkReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	benchmark := &llmgeeperiov1alpha1.Benchmark{}
+	if err := r.Get(ctx, req.NamespacedName, benchmark); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// A benchmark runs once, its results stay in the status
+	if benchmark.Status.Phase == llmgeeperiov1alpha1.BenchmarkPhaseSucceeded || benchmark.Status.Phase == llmgeeperiov1alpha1.BenchmarkPhaseFailed {
+		return ctrl.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(benchmark, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to create patch helper: %w", err)
+	}
+
+	job := &batchv1.Job{}
+	err = r.Get(ctx, types.NamespacedName{Name: benchmark.GetJobName(), Namespace: benchmark.Namespace}, job)
+	if errors.IsNotFound(err) {
+		target, reason, err := r.resolveBenchmarkTarget(ctx, benchmark)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if target == nil {
+			logger.Info("Benchmark target is not ready", "reason", reason)
+			benchmark.Status.Phase = llmgeeperiov1alpha1.BenchmarkPhasePending
+			benchmark.Status.Message = reason
+			if err := patchHelper.Patch(ctx, benchmark); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to update benchmark status: %w", err)
+			}
+			return ctrl.Result{RequeueAfter: benchmarkPendingRequeue}, nil
+		}
+
+		job = r.buildBenchmarkJob(benchmark, target)
+		if err := r.Create(ctx, job); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to create benchmark job: %w", err)
+		}
+		logger.Info("Started benchmark", "job", job.Name, "url", target.url, "model", target.model)
+		benchmark.Status.URL = target.url
+		benchmark.Status.Model = target.model
+	} else if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get benchmark job: %w", err)
+	}
+
+	r.setBenchmarkStatus(ctx, benchmark, job)
+	if err := patchHelper.Patch(ctx, benchmark); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update benchmark status: %w", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// resolveBenchmarkTarget returns the endpoint of a benchmark, or nil and the reason while the LMDeployment doesn't serve the model
+func (r *BenchmarkReconciler) resolveBenchmarkTarget(ctx context.Context, benchmark *llmgeeperiov1alpha1.Benchmark) (*benchmarkTarget, string, error) {
+	spec := benchmark.Spec.Target
+	if spec.URL != "" {
+		return &benchmarkTarget{url: spec.URL, model: spec.Model, apiKey: spec.APIKeySecretRef}, "", nil
+	}
+
+	deployment := &llmgeeperiov1alpha1.LMDeployment{}
+	if err := r.Get(ctx, types.NamespacedName{Name: spec.DeploymentRef, Namespace: benchmark.Namespace}, deployment); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, fmt.Sprintf("LMDeployment %s not found", spec.DeploymentRef), nil
+		}
+		return nil, "", fmt.Errorf("failed to get LMDeployment %s: %w", spec.DeploymentRef, err)
+	}
+
+	switch {
+	case deployment.Spec.VLLM.Enabled:
+		// Requests go through the router like the ones of the clients, the model name resolves to the served model
+		target := &benchmarkTarget{
+			url:    fmt.Sprintf("http://%s:%d/v1", deployment.GetVLLMRouterServiceName(), vllmRouterPort(deployment)),
+			apiKey: spec.APIKeySecretRef,
+		}
+		for _, model := range deployment.Spec.VLLM.Models {
+			if model.Name == spec.Model || model.Model == spec.Model {
+				target.model = model.Model
+			}
+		}
+		if target.model == "" {
+			return nil, fmt.Sprintf("LMDeployment %s does not serve the model %s", deployment.Name, spec.Model), nil
+		}
+		if target.apiKey == nil {
+			target.apiKey = &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: deployment.GetVLLMApiKeySecretName()},
+				Key:                  llmgeeperiov1alpha1.VLLMApiKeySecretKey,
+			}
+		}
+		return target, "", nil
+	case deployment.Spec.Ollama.Enabled:
+		// Ollama serves the OpenAI-compatible API next to its own
+		return &benchmarkTarget{
+			url:    fmt.Sprintf("http://%s:%d/v1", deployment.GetOllamaServiceName(), deployment.GetOllamaServicePort()),
+			model:  spec.Model,
+			apiKey: spec.APIKeySecretRef,
+		}, "", nil
+	default:
+		return nil, fmt.Sprintf("LMDeployment %s serves no models", deployment.Name), nil
+	}
+}
+
+// buildBenchmarkJob builds the Job running the benchmark, it writes the results to its termination message
+func (r *BenchmarkReconciler) buildBenchmarkJob(benchmark *llmgeeperiov1alpha1.Benchmark, target *benchmarkTarget) *batchv1.Job {
+	spec := benchmark.Spec
+	labels := map[string]string{
+		"app":       "benchmark",
+		"benchmark": benchmark.Name,
+	}
+	if spec.Target.DeploymentRef != "" {
+		// Lets the network policies of the LMDeployment admit the benchmark
+		labels["llm-deployment"] = spec.Target.DeploymentRef
+	}
+
+	duration := time.Minute
+	if spec.Duration != nil {
+		duration = spec.Duration.Duration
+	}
+	concurrency := spec.Concurrency
+	if concurrency == 0 {
+		concurrency = 4
+	}
+	args := []string{
+		"--url=" + target.url,
+		"--model=" + target.model,
+		"--concurrency=" + strconv.Itoa(int(concurrency)),
+		"--duration=" + duration.String(),
+		"--prompt-tokens=" + formatTokenRange(spec.PromptTokens, 128, 512),
+		"--output-tokens=" + formatTokenRange(spec.OutputTokens, 64, 256),
+	}
+	if spec.MaxRequests > 0 {
+		args = append(args, "--max-requests="+strconv.Itoa(int(spec.MaxRequests)))
+	}
+
+	container := corev1.Container{
+		Name:    "benchmark",
+		Image:   r.benchmarkImage(spec.Image),
+		Command: []string{"/benchmark"},
+		Args:    args,
+		// The results are read from the termination message, the logs hold the error when the binary couldn't write it
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}
+	if target.apiKey != nil {
+		container.Env = []corev1.EnvVar{
+			{
+				Name:      "API_KEY",
+				ValueFrom: &corev1.EnvVarSource{SecretKeyRef: target.apiKey},
+			},
+		}
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      benchmark.GetJobName(),
+			Namespace: benchmark.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          ptr.To(int32(0)),
+			ActiveDeadlineSeconds: ptr.To(int64((duration + benchmarkGracePeriod).Seconds())),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers:    []corev1.Container{container},
+				},
+			},
+		},
+	}
+
+	// Set owner reference
+	_ = controllerutil.SetControllerReference(benchmark, job, r.Scheme)
+	return job
+}
+
+// setBenchmarkStatus reports the state of the benchmark Job and the results once it finished
+func (r *BenchmarkReconciler) setBenchmarkStatus(ctx context.Context, benchmark *llmgeeperiov1alpha1.Benchmark, job *batchv1.Job) {
+	status := &benchmark.Status
+	status.Job = job.Name
+	status.Phase = llmgeeperiov1alpha1.BenchmarkPhaseRunning
+	status.Message = ""
+	if job.Status.StartTime != nil {
+		status.StartTime = job.Status.StartTime
+	}
+
+	result, finished := jobResult(job)
+	switch result {
+	case llmgeeperiov1alpha1.SmokeTestSucceeded:
+		status.CompletionTime = &metav1.Time{Time: finished}
+		message, err := r.benchmarkTerminationMessage(ctx, job)
+		if err != nil {
+			status.Phase = llmgeeperiov1alpha1.BenchmarkPhaseFailed
+			status.Message = err.Error()
+			return
+		}
+		results := &llmgeeperiov1alpha1.BenchmarkResults{}
+		if err := json.Unmarshal([]byte(message), results); err != nil {
+			status.Phase = llmgeeperiov1alpha1.BenchmarkPhaseFailed
+			status.Message = fmt.Sprintf("failed to parse the results: %v", err)
+			return
+		}
+		status.Phase = llmgeeperiov1alpha1.BenchmarkPhaseSucceeded
+		status.Results = results
+	case llmgeeperiov1alpha1.SmokeTestFailed:
+		status.CompletionTime = &metav1.Time{Time: finished}
+		status.Phase = llmgeeperiov1alpha1.BenchmarkPhaseFailed
+		status.Message = "benchmark job failed"
+		if message, err := r.benchmarkTerminationMessage(ctx, job); err == nil && message != "" {
+			status.Message = message
+		}
+	}
+}
+
+// benchmarkTerminationMessage returns the termination message of the pod of a finished benchmark Job
+func (r *BenchmarkReconciler) benchmarkTerminationMessage(ctx context.Context, job *batchv1.Job) (string, error) {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(job.Namespace), client.MatchingLabels{"job-name": job.Name}); err != nil {
+		return "", fmt.Errorf("failed to list benchmark pods: %w", err)
+	}
+	for _, pod := range pods.Items {
+		for _, container := range pod.Status.ContainerStatuses {
+			if container.State.Terminated != nil && container.State.Terminated.Message != "" {
+				return container.State.Terminated.Message, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no results found in the pods of job %s", job.Name)
+}
+
+// benchmarkImage returns the image of the benchmark Job, the operator image by default
+func (r *BenchmarkReconciler) benchmarkImage(image string) string {
+	if image != "" {
+		return image
+	}
+	config := r.Config.Get()
+	return config.Image(config.Images.Benchmark)
+}
+
+// formatTokenRange formats a token range as the benchmark binary expects it
+func formatTokenRange(tokens *llmgeeperiov1alpha1.TokenRange, defaultMin, defaultMax int32) string {
+	if tokens == nil {
+		return fmt.Sprintf("%d-%d", defaultMin, defaultMax)
+	}
+	return fmt.Sprintf("%d-%d", tokens.Min, tokens.Max)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *BenchmarkReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&llmgeeperiov1alpha1.Benchmark{}).
+		Owns(&batchv1.Job{}).
+		Named("benchmark").
+		Complete(r)
+}
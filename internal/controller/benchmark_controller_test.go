@@ -0,0 +1,163 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+func TestBenchmarkReconciler(t *testing.T) {
+	ctx := context.Background()
+	scheme := newTestScheme(t)
+	benchmark := &llmgeeperiov1alpha1.Benchmark{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default", UID: "uid"},
+		Spec: llmgeeperiov1alpha1.BenchmarkSpec{
+			Target:       llmgeeperiov1alpha1.BenchmarkTarget{DeploymentRef: "test-deployment", Model: "llama"},
+			Concurrency:  8,
+			Duration:     &metav1.Duration{Duration: 5 * time.Minute},
+			OutputTokens: &llmgeeperiov1alpha1.TokenRange{Min: 100, Max: 100},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(benchmark).WithStatusSubresource(benchmark).Build()
+	reconciler := &BenchmarkReconciler{Client: c, Scheme: scheme}
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Name: "llama", Namespace: "default"}}
+	get := func() *llmgeeperiov1alpha1.Benchmark {
+		current := &llmgeeperiov1alpha1.Benchmark{}
+		require.NoError(t, c.Get(ctx, request.NamespacedName, current))
+		return current
+	}
+
+	t.Run("should wait for the LMDeployment", func(t *testing.T) {
+		result, err := reconciler.Reconcile(ctx, request)
+		require.NoError(t, err)
+		assert.Equal(t, benchmarkPendingRequeue, result.RequeueAfter)
+		assert.Equal(t, llmgeeperiov1alpha1.BenchmarkPhasePending, get().Status.Phase)
+		assert.Contains(t, get().Status.Message, "test-deployment not found")
+	})
+
+	t.Run("should run the benchmark against the vLLM router", func(t *testing.T) {
+		require.NoError(t, c.Create(ctx, &llmgeeperiov1alpha1.LMDeployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "default"},
+			Spec: llmgeeperiov1alpha1.LMDeploymentSpec{
+				VLLM: llmgeeperiov1alpha1.VLLMSpec{
+					Enabled: true,
+					Models:  []llmgeeperiov1alpha1.VLLMModelSpec{{Name: "llama", Model: "meta-llama/Llama-3.1-8B-Instruct"}},
+				},
+			},
+		}))
+
+		_, err := reconciler.Reconcile(ctx, request)
+		require.NoError(t, err)
+
+		job := &batchv1.Job{}
+		require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "llama-benchmark", Namespace: "default"}, job))
+		container := job.Spec.Template.Spec.Containers[0]
+		assert.Equal(t, "ghcr.io/geeper-io/llm-operator:latest", container.Image)
+		assert.Equal(t, []string{
+			"--url=http://test-deployment-vllm-router:8000/v1",
+			"--model=meta-llama/Llama-3.1-8B-Instruct",
+			"--concurrency=8",
+			"--duration=5m0s",
+			"--prompt-tokens=128-512",
+			"--output-tokens=100-100",
+		}, container.Args)
+		assert.Equal(t, "test-deployment-vllm-api-key", container.Env[0].ValueFrom.SecretKeyRef.Name)
+		assert.Equal(t, "test-deployment", job.Spec.Template.Labels["llm-deployment"])
+		assert.Equal(t, int64(20*60), *job.Spec.ActiveDeadlineSeconds)
+
+		status := get().Status
+		assert.Equal(t, llmgeeperiov1alpha1.BenchmarkPhaseRunning, status.Phase)
+		assert.Equal(t, "meta-llama/Llama-3.1-8B-Instruct", status.Model)
+		assert.Empty(t, status.Message)
+	})
+
+	t.Run("should report the results of the finished job", func(t *testing.T) {
+		require.NoError(t, c.Create(ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "llama-benchmark-abcde", Namespace: "default", Labels: map[string]string{"job-name": "llama-benchmark"}},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name: "benchmark",
+					State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+						Message: `{"requests":120,"errors":6,"errorRate":"5.00","outputTokensPerSecond":"812.40","timeToFirstToken":{"p50":"180ms"}}`,
+					}},
+				}},
+			},
+		}))
+		job := &batchv1.Job{}
+		require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "llama-benchmark", Namespace: "default"}, job))
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue, LastTransitionTime: metav1.Now()}}
+		require.NoError(t, c.Status().Update(ctx, job))
+
+		_, err := reconciler.Reconcile(ctx, request)
+		require.NoError(t, err)
+
+		status := get().Status
+		assert.Equal(t, llmgeeperiov1alpha1.BenchmarkPhaseSucceeded, status.Phase)
+		require.NotNil(t, status.Results)
+		assert.Equal(t, int64(120), status.Results.Requests)
+		assert.Equal(t, "5.00", status.Results.ErrorRate)
+		assert.Equal(t, 180*time.Millisecond, status.Results.TimeToFirstToken.P50.Duration)
+		assert.NotNil(t, status.CompletionTime)
+	})
+
+	t.Run("should not run a finished benchmark again", func(t *testing.T) {
+		require.NoError(t, c.Delete(ctx, &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "llama-benchmark", Namespace: "default"}}))
+		_, err := reconciler.Reconcile(ctx, request)
+		require.NoError(t, err)
+		jobs := &batchv1.JobList{}
+		require.NoError(t, c.List(ctx, jobs, client.InNamespace("default")))
+		assert.Empty(t, jobs.Items)
+	})
+}
+
+func TestBenchmarkReconciler_URLTarget(t *testing.T) {
+	scheme := newTestScheme(t)
+	reconciler := &BenchmarkReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).Build(), Scheme: scheme}
+	benchmark := &llmgeeperiov1alpha1.Benchmark{
+		ObjectMeta: metav1.ObjectMeta{Name: "external", Namespace: "default"},
+		Spec: llmgeeperiov1alpha1.BenchmarkSpec{
+			Target:      llmgeeperiov1alpha1.BenchmarkTarget{URL: "http://stand-in:8080/v1", Model: "tiny"},
+			MaxRequests: 50,
+			Image:       "registry.internal/llm-operator:dev",
+		},
+	}
+
+	target, reason, err := reconciler.resolveBenchmarkTarget(context.Background(), benchmark)
+	require.NoError(t, err)
+	require.NotNil(t, target, reason)
+	job := reconciler.buildBenchmarkJob(benchmark, target)
+	container := job.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "registry.internal/llm-operator:dev", container.Image)
+	assert.Contains(t, container.Args, "--url=http://stand-in:8080/v1")
+	assert.Contains(t, container.Args, "--max-requests=50")
+	assert.Empty(t, container.Env, "no API key is sent without a secret reference")
+	assert.NotContains(t, job.Spec.Template.Labels, "llm-deployment")
+}
@@ -0,0 +1,304 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+// canaryRevision returns the hash identifying the revision a canary rolls out
+func canaryRevision(canary *llmgeeperiov1alpha1.CanarySpec) string {
+	hasher := newConfigHasher()
+	hasher.addString("model", canary.Model)
+	hasher.addString("image", canary.Image)
+	hasher.addString("args", strings.Join(canary.Args, "\x00"))
+	return hasher.sum()
+}
+
+// findCanaryStatus returns the reported canary of a model, nil when none is reported
+func findCanaryStatus(statuses []llmgeeperiov1alpha1.CanaryStatus, name string) *llmgeeperiov1alpha1.CanaryStatus {
+	for i := range statuses {
+		if statuses[i].Name == name {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
+
+// canaryPromoted reports whether the canary of a model is promoted, either requested in the spec
+// or after its smoke test succeeded as reported in the status
+func canaryPromoted(deployment *llmgeeperiov1alpha1.LMDeployment, modelSpec llmgeeperiov1alpha1.VLLMModelSpec) bool {
+	canary := modelSpec.Canary
+	if canary == nil || canary.Abort {
+		return false
+	}
+	if canary.Promote {
+		return true
+	}
+	status := findCanaryStatus(deployment.Status.VLLMStatus.Canaries, modelSpec.Name)
+	return status != nil && status.Revision == canaryRevision(canary) && status.Phase == llmgeeperiov1alpha1.CanaryPhasePromoted
+}
+
+// canaryRunning reports whether a model runs a canary Deployment next to the stable one
+func canaryRunning(deployment *llmgeeperiov1alpha1.LMDeployment, modelSpec llmgeeperiov1alpha1.VLLMModelSpec) bool {
+	return modelSpec.Canary != nil && !modelSpec.Canary.Abort && !canaryPromoted(deployment, modelSpec)
+}
+
+// canaryWeight returns the share of the replicas running the canary in percent
+func canaryWeight(canary *llmgeeperiov1alpha1.CanarySpec) int32 {
+	if canary.Weight <= 0 {
+		return 10
+	}
+	return min(canary.Weight, 100)
+}
+
+// splitCanaryReplicas splits the replicas of a model between the stable revision and the canary.
+// The canary share is rounded down so it never receives more traffic than its weight, and no replicas are added.
+// The webhook rejects weights that leave the canary without a replica.
+func splitCanaryReplicas(replicas, weight int32) (stable, canary int32) {
+	if replicas <= 0 {
+		return 0, 0
+	}
+	canary = replicas * weight / 100
+	return replicas - canary, canary
+}
+
+// vllmCanaryLabels returns the labels of the canary pods of a model.
+// They keep the app and deployment labels so the router discovers them, the model label differs from the stable selector.
+func vllmCanaryLabels(deployment *llmgeeperiov1alpha1.LMDeployment, modelSpec llmgeeperiov1alpha1.VLLMModelSpec) map[string]string {
+	return map[string]string{
+		"app":             "vllm",
+		"llm-deployment":  deployment.Name,
+		"vllm-model":      modelSpec.Name + "-canary",
+		"vllm-model-name": modelSpec.Model,
+	}
+}
+
+// applyVLLMRevision makes the vLLM container of a model run the revision of its canary.
+// A different model is served under the name of the stable model so clients keep requesting the same name.
+func applyVLLMRevision(workload *appsv1.Deployment, modelSpec llmgeeperiov1alpha1.VLLMModelSpec) {
+	canary := modelSpec.Canary
+	for i := range workload.Spec.Template.Spec.Containers {
+		container := &workload.Spec.Template.Spec.Containers[i]
+		if container.Name != "vllm" {
+			continue
+		}
+		if canary.Image != "" {
+			container.Image = canary.Image
+		}
+		if canary.Model != "" && canary.Model != modelSpec.Model {
+			container.Command = []string{"vllm", "serve", canary.Model, "--served-model-name", modelSpec.Model}
+		}
+		if canary.Args != nil {
+			revision := modelSpec
+			revision.Args = canary.Args
+			container.Args = vllmArgs(revision)
+		}
+	}
+}
+
+// buildVLLMModelDeployments builds the Deployment of a model and, while its canary runs, the canary Deployment.
+// The replicas are split by the canary weight, a promoted canary replaces the revision of the stable Deployment.
+func (r *LMDeploymentReconciler) buildVLLMModelDeployments(deployment *llmgeeperiov1alpha1.LMDeployment, modelSpec llmgeeperiov1alpha1.VLLMModelSpec) (*appsv1.Deployment, *appsv1.Deployment) {
+	stable := r.buildVLLMModelDeployment(deployment, modelSpec)
+	if canaryPromoted(deployment, modelSpec) {
+		applyVLLMRevision(stable, modelSpec)
+		return stable, nil
+	}
+	if !canaryRunning(deployment, modelSpec) {
+		return stable, nil
+	}
+
+	canary := r.buildVLLMModelDeployment(deployment, modelSpec)
+	labels := vllmCanaryLabels(deployment, modelSpec)
+	canary.Name = deployment.GetVLLMModelCanaryName(modelSpec.Name)
+	canary.Labels = labels
+	canary.Spec.Selector.MatchLabels = labels
+	canary.Spec.Template.Labels = mergeStringMaps(canary.Spec.Template.Labels, labels)
+	applyVLLMRevision(canary, modelSpec)
+
+	// The model cache of the stable revision stays with it, a different model would fill it up
+	for i := range canary.Spec.Template.Spec.Volumes {
+		volume := &canary.Spec.Template.Spec.Volumes[i]
+		if volume.Name == "vllm-data" {
+			volume.VolumeSource = corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}
+		}
+	}
+
+	var replicas int32
+	if stable.Spec.Replicas != nil {
+		replicas = *stable.Spec.Replicas
+	}
+	stableReplicas, canaryReplicas := splitCanaryReplicas(replicas, canaryWeight(modelSpec.Canary))
+	stable.Spec.Replicas = ptr.To(stableReplicas)
+	canary.Spec.Replicas = ptr.To(canaryReplicas)
+	return stable, canary
+}
+
+// buildVLLMCanaryService builds the service selecting the canary pods of a model, the smoke test reaches the canary through it
+func (r *LMDeploymentReconciler) buildVLLMCanaryService(deployment *llmgeeperiov1alpha1.LMDeployment, modelSpec llmgeeperiov1alpha1.VLLMModelSpec) *corev1.Service {
+	labels := vllmCanaryLabels(deployment, modelSpec)
+	service := r.buildVLLMModelService(deployment, modelSpec)
+	service.Name = deployment.GetVLLMModelCanaryName(modelSpec.Name)
+	service.Labels = labels
+	service.Spec.Type = corev1.ServiceTypeClusterIP
+	service.Spec.Selector = labels
+	return service
+}
+
+// reconcileVLLMCanary creates the canary Deployment, service and smoke test of a model, and removes them
+// once the canary is promoted, aborted or removed
+func (r *LMDeploymentReconciler) reconcileVLLMCanary(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment, modelSpec llmgeeperiov1alpha1.VLLMModelSpec, canary *appsv1.Deployment, configHash string) error {
+	name := deployment.GetVLLMModelCanaryName(modelSpec.Name)
+	if canary == nil {
+		if err := r.deleteControlled(ctx, deployment, &appsv1.Deployment{}, name); err != nil {
+			return err
+		}
+		if err := r.deleteControlled(ctx, deployment, &policyv1.PodDisruptionBudget{}, name); err != nil {
+			return err
+		}
+		if err := r.deleteControlled(ctx, deployment, &corev1.Service{}, name); err != nil {
+			return err
+		}
+
+		// Keep the smoke test of a promoted canary, its result stays in the status
+		var keep string
+		if modelSpec.Canary != nil && canaryPromoted(deployment, modelSpec) {
+			keep = vllmCanarySmokeTestName(deployment, modelSpec)
+		}
+		return r.deleteStaleSmokeTests(ctx, deployment, modelSpec.Name+"-canary", keep)
+	}
+
+	r.pinImageDigests(deployment, &canary.Spec.Template)
+	setConfigHash(canary, configHash)
+	if err := r.createOrUpdateDeployment(ctx, canary); err != nil {
+		return err
+	}
+	if err := r.reconcilePodDisruptionBudget(ctx, deployment, canary, vllmModelRollout(deployment, modelSpec)); err != nil {
+		return err
+	}
+	service := r.buildVLLMCanaryService(deployment, modelSpec)
+	if err := r.createOrUpdateService(ctx, service); err != nil {
+		return err
+	}
+
+	var keep string
+	if smokeTest := canarySmokeTest(modelSpec.Canary); smokeTest != nil {
+		keep = vllmCanarySmokeTestName(deployment, modelSpec)
+		job := r.buildSmokeTestJob(deployment, modelSpec.Name+"-canary", keep, service, modelSpec.Model, smokeTest)
+		if err := r.ensureSmokeTest(ctx, job); err != nil {
+			return err
+		}
+	}
+	return r.deleteStaleSmokeTests(ctx, deployment, modelSpec.Name+"-canary", keep)
+}
+
+// canarySmokeTest returns the smoke test of a canary, the defaults when only auto-promotion is requested
+func canarySmokeTest(canary *llmgeeperiov1alpha1.CanarySpec) *llmgeeperiov1alpha1.SmokeTestSpec {
+	if canary.SmokeTest != nil {
+		return canary.SmokeTest
+	}
+	if canary.AutoPromote {
+		return &llmgeeperiov1alpha1.SmokeTestSpec{}
+	}
+	return nil
+}
+
+// vllmCanarySmokeTestName returns the name of the smoke test Job of the current canary revision of a model
+func vllmCanarySmokeTestName(deployment *llmgeeperiov1alpha1.LMDeployment, modelSpec llmgeeperiov1alpha1.VLLMModelSpec) string {
+	return deployment.GetVLLMModelSmokeTestName(modelSpec.Name+"-canary", canaryRevision(modelSpec.Canary))
+}
+
+// setCanaryStatus reports the revisions of a model taking part in its canary rollout and how the traffic is split.
+// The phase turns Promoted once the smoke test of an auto-promoted canary succeeded, the next reconciliation promotes it.
+func (r *LMDeploymentReconciler) setCanaryStatus(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment, modelSpec llmgeeperiov1alpha1.VLLMModelSpec, stable, canary *appsv1.Deployment, statuses *[]llmgeeperiov1alpha1.CanaryStatus) {
+	spec := modelSpec.Canary
+	if spec == nil {
+		return
+	}
+
+	status := llmgeeperiov1alpha1.CanaryStatus{
+		Name:     modelSpec.Name,
+		Revision: canaryRevision(spec),
+		Stable:   revisionStatus(stable, modelSpec.Model),
+		Canary:   revisionStatus(canary, modelSpec.Model),
+	}
+	if spec.Model != "" {
+		status.Canary.Model = spec.Model
+	}
+	if status.Stable.ReadyReplicas+status.Canary.ReadyReplicas > 0 {
+		status.Weight = status.Canary.ReadyReplicas * 100 / (status.Stable.ReadyReplicas + status.Canary.ReadyReplicas)
+	}
+
+	if canarySmokeTest(spec) != nil {
+		status.SmokeTest = r.smokeTestResult(ctx, deployment, vllmCanarySmokeTestName(deployment, modelSpec))
+	}
+
+	switch {
+	case spec.Abort:
+		status.Phase = llmgeeperiov1alpha1.CanaryPhaseAborted
+	case canaryPromoted(deployment, modelSpec) || (spec.AutoPromote && status.SmokeTest == llmgeeperiov1alpha1.SmokeTestSucceeded):
+		status.Phase = llmgeeperiov1alpha1.CanaryPhasePromoted
+	case status.SmokeTest == llmgeeperiov1alpha1.SmokeTestFailed:
+		status.Phase = llmgeeperiov1alpha1.CanaryPhaseFailed
+	default:
+		status.Phase = llmgeeperiov1alpha1.CanaryPhaseProgressing
+	}
+	if status.Phase == llmgeeperiov1alpha1.CanaryPhasePromoted && spec.Model != "" {
+		status.Stable.Model = spec.Model
+	}
+	*statuses = append(*statuses, status)
+}
+
+// revisionStatus reports the model, image and replicas of a revision, only the model when its Deployment doesn't exist
+func revisionStatus(workload *appsv1.Deployment, model string) llmgeeperiov1alpha1.RevisionStatus {
+	status := llmgeeperiov1alpha1.RevisionStatus{Model: model}
+	if workload == nil {
+		return status
+	}
+	for _, container := range workload.Spec.Template.Spec.Containers {
+		if container.Name == "vllm" {
+			status.Image = container.Image
+		}
+	}
+	if workload.Spec.Replicas != nil {
+		status.Replicas = *workload.Spec.Replicas
+	}
+	status.ReadyReplicas = workload.Status.ReadyReplicas
+	return status
+}
+
+// getVLLMCanary returns the canary Deployment of a model, nil when it doesn't run a canary
+func (r *LMDeploymentReconciler) getVLLMCanary(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment, modelSpec llmgeeperiov1alpha1.VLLMModelSpec) *appsv1.Deployment {
+	if modelSpec.Canary == nil {
+		return nil
+	}
+	canary := &appsv1.Deployment{}
+	if err := r.Get(ctx, types.NamespacedName{Name: deployment.GetVLLMModelCanaryName(modelSpec.Name), Namespace: deployment.Namespace}, canary); err != nil {
+		return nil
+	}
+	return canary
+}
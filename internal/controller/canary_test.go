@@ -0,0 +1,213 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+func newCanaryTestDeployment() *llmgeeperiov1alpha1.LMDeployment {
+	return &llmgeeperiov1alpha1.LMDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "default", UID: "uid"},
+		Spec: llmgeeperiov1alpha1.LMDeploymentSpec{
+			VLLM: llmgeeperiov1alpha1.VLLMSpec{
+				Enabled: true,
+				Models: []llmgeeperiov1alpha1.VLLMModelSpec{
+					{
+						Name:     "llama",
+						Model:    "meta-llama/Llama-3.1-8B-Instruct",
+						Image:    "vllm/vllm-openai:v0.9.0",
+						Replicas: 4,
+						Persistence: &llmgeeperiov1alpha1.VLLMPersistenceSpec{
+							Enabled: true,
+							Size:    "50Gi",
+						},
+						Canary: &llmgeeperiov1alpha1.CanarySpec{
+							Model:       "meta-llama/Llama-3.3-70B-Instruct",
+							Image:       "vllm/vllm-openai:v0.10.0",
+							Args:        []string{"--max-model-len", "8192"},
+							Weight:      25,
+							AutoPromote: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestSplitCanaryReplicas(t *testing.T) {
+	tests := []struct {
+		replicas, weight, stable, canary int32
+	}{
+		{replicas: 4, weight: 25, stable: 3, canary: 1},
+		{replicas: 10, weight: 10, stable: 9, canary: 1},
+		{replicas: 3, weight: 50, stable: 2, canary: 1},
+		{replicas: 4, weight: 75, stable: 1, canary: 3},
+		{replicas: 1, weight: 10, stable: 1, canary: 0},
+		{replicas: 2, weight: 10, stable: 2, canary: 0},
+		{replicas: 2, weight: 100, stable: 0, canary: 2},
+		{replicas: 0, weight: 50, stable: 0, canary: 0},
+	}
+	for _, tt := range tests {
+		stable, canary := splitCanaryReplicas(tt.replicas, tt.weight)
+		assert.Equal(t, tt.stable, stable, "stable replicas of %d at %d%%", tt.replicas, tt.weight)
+		assert.Equal(t, tt.canary, canary, "canary replicas of %d at %d%%", tt.replicas, tt.weight)
+	}
+}
+
+func TestCanary_Build(t *testing.T) {
+	scheme := newTestScheme(t)
+	reconciler := &LMDeploymentReconciler{Scheme: scheme}
+
+	t.Run("should run the canary revision next to the stable one", func(t *testing.T) {
+		deployment := newCanaryTestDeployment()
+		stable, canary := reconciler.buildVLLMModelDeployments(deployment, deployment.Spec.VLLM.Models[0])
+		require.NotNil(t, canary)
+
+		assert.Equal(t, int32(3), *stable.Spec.Replicas)
+		assert.Equal(t, int32(1), *canary.Spec.Replicas)
+		assert.Equal(t, "test-deployment-vllm-llama-canary", canary.Name)
+		assert.Equal(t, "llama-canary", canary.Spec.Selector.MatchLabels["vllm-model"])
+		assert.Equal(t, "vllm", canary.Spec.Template.Labels["app"], "the router discovers the canary pods")
+
+		container := canary.Spec.Template.Spec.Containers[0]
+		assert.Equal(t, "vllm/vllm-openai:v0.10.0", container.Image)
+		assert.Equal(t, []string{"vllm", "serve", "meta-llama/Llama-3.3-70B-Instruct", "--served-model-name", "meta-llama/Llama-3.1-8B-Instruct"}, container.Command)
+		assert.Equal(t, []string{"--max-model-len", "8192"}, container.Args)
+		assert.NotNil(t, canary.Spec.Template.Spec.Volumes[0].EmptyDir, "the canary doesn't share the model cache")
+
+		assert.Equal(t, "vllm/vllm-openai:v0.9.0", stable.Spec.Template.Spec.Containers[0].Image)
+	})
+
+	t.Run("should run the canary revision in the stable deployment once promoted", func(t *testing.T) {
+		deployment := newCanaryTestDeployment()
+		deployment.Spec.VLLM.Models[0].Canary.Promote = true
+		stable, canary := reconciler.buildVLLMModelDeployments(deployment, deployment.Spec.VLLM.Models[0])
+		assert.Nil(t, canary)
+		assert.Equal(t, int32(4), *stable.Spec.Replicas)
+		assert.Equal(t, "vllm/vllm-openai:v0.10.0", stable.Spec.Template.Spec.Containers[0].Image)
+		assert.Equal(t, "llama", stable.Spec.Selector.MatchLabels["vllm-model"])
+	})
+
+	t.Run("should keep the task of the model with the canary args", func(t *testing.T) {
+		deployment := newCanaryTestDeployment()
+		deployment.Spec.VLLM.Models[0].Task = "embed"
+		stable, canary := reconciler.buildVLLMModelDeployments(deployment, deployment.Spec.VLLM.Models[0])
+		require.NotNil(t, canary)
+
+		assert.Equal(t, []string{"--task", "embed", "--max-model-len", "8192"}, canary.Spec.Template.Spec.Containers[0].Args)
+		assert.Equal(t, []string{"--task", "embed"}, stable.Spec.Template.Spec.Containers[0].Args)
+	})
+
+	t.Run("should remove the canary once aborted", func(t *testing.T) {
+		deployment := newCanaryTestDeployment()
+		deployment.Spec.VLLM.Models[0].Canary.Abort = true
+		stable, canary := reconciler.buildVLLMModelDeployments(deployment, deployment.Spec.VLLM.Models[0])
+		assert.Nil(t, canary)
+		assert.Equal(t, int32(4), *stable.Spec.Replicas)
+		assert.Equal(t, "vllm/vllm-openai:v0.9.0", stable.Spec.Template.Spec.Containers[0].Image)
+	})
+}
+
+func TestCanary_AutoPromote(t *testing.T) {
+	ctx := context.Background()
+	scheme := newTestScheme(t)
+	deployment := newCanaryTestDeployment()
+	modelSpec := deployment.Spec.VLLM.Models[0]
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	reconciler := &LMDeploymentReconciler{Client: c, Scheme: scheme}
+
+	reconcileCanary := func() {
+		stable, canary := reconciler.buildVLLMModelDeployments(deployment, modelSpec)
+		require.NoError(t, reconciler.createOrUpdateDeployment(ctx, stable))
+		require.NoError(t, reconciler.reconcileVLLMCanary(ctx, deployment, modelSpec, canary, "hash"))
+	}
+	reportCanary := func() llmgeeperiov1alpha1.CanaryStatus {
+		stable := &appsv1.Deployment{}
+		require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "test-deployment-vllm-llama", Namespace: "default"}, stable))
+		var statuses []llmgeeperiov1alpha1.CanaryStatus
+		reconciler.setCanaryStatus(ctx, deployment, modelSpec, stable, reconciler.getVLLMCanary(ctx, deployment, modelSpec), &statuses)
+		require.Len(t, statuses, 1)
+		deployment.Status.VLLMStatus.Canaries = statuses
+		return statuses[0]
+	}
+	jobName := vllmCanarySmokeTestName(deployment, modelSpec)
+
+	t.Run("should test the canary through its service", func(t *testing.T) {
+		reconcileCanary()
+
+		service := &corev1.Service{}
+		require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "test-deployment-vllm-llama-canary", Namespace: "default"}, service))
+		assert.Equal(t, "llama-canary", service.Spec.Selector["vllm-model"])
+
+		job := &batchv1.Job{}
+		require.NoError(t, c.Get(ctx, types.NamespacedName{Name: jobName, Namespace: "default"}, job))
+		env := job.Spec.Template.Spec.Containers[0].Env
+		assert.Equal(t, "http://test-deployment-vllm-llama-canary:8000/v1/chat/completions", env[0].Value)
+		assert.Contains(t, env[1].Value, `"model":"meta-llama/Llama-3.1-8B-Instruct"`)
+
+		status := reportCanary()
+		assert.Equal(t, llmgeeperiov1alpha1.CanaryPhaseProgressing, status.Phase)
+		assert.Equal(t, llmgeeperiov1alpha1.SmokeTestRunning, status.SmokeTest)
+		assert.Equal(t, int32(3), status.Stable.Replicas)
+		assert.Equal(t, int32(1), status.Canary.Replicas)
+		assert.Equal(t, "meta-llama/Llama-3.3-70B-Instruct", status.Canary.Model)
+	})
+
+	t.Run("should promote the canary once its smoke test succeeded", func(t *testing.T) {
+		job := &batchv1.Job{}
+		require.NoError(t, c.Get(ctx, types.NamespacedName{Name: jobName, Namespace: "default"}, job))
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+		require.NoError(t, c.Status().Update(ctx, job))
+
+		status := reportCanary()
+		assert.Equal(t, llmgeeperiov1alpha1.CanaryPhasePromoted, status.Phase)
+		assert.Equal(t, llmgeeperiov1alpha1.SmokeTestSucceeded, status.SmokeTest)
+
+		reconcileCanary()
+		err := c.Get(ctx, types.NamespacedName{Name: "test-deployment-vllm-llama-canary", Namespace: "default"}, &appsv1.Deployment{})
+		assert.True(t, errors.IsNotFound(err), "the promoted canary is removed")
+		assert.NoError(t, c.Get(ctx, types.NamespacedName{Name: jobName, Namespace: "default"}, &batchv1.Job{}), "the smoke test result is kept")
+
+		stable := &appsv1.Deployment{}
+		require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "test-deployment-vllm-llama", Namespace: "default"}, stable))
+		assert.Equal(t, int32(4), *stable.Spec.Replicas)
+		assert.Equal(t, "vllm/vllm-openai:v0.10.0", stable.Spec.Template.Spec.Containers[0].Image)
+		assert.Equal(t, llmgeeperiov1alpha1.CanaryPhasePromoted, reportCanary().Phase)
+	})
+
+	t.Run("should remove the smoke tests with the canary", func(t *testing.T) {
+		modelSpec.Canary = nil
+		reconcileCanary()
+		err := c.Get(ctx, types.NamespacedName{Name: jobName, Namespace: "default"}, &batchv1.Job{})
+		assert.True(t, errors.IsNotFound(err))
+	})
+}
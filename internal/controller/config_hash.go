@@ -0,0 +1,192 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+// configHasher builds a stable hash over the configuration consumed by a component
+type configHasher struct {
+	h hash.Hash
+}
+
+// newConfigHasher creates an empty configHasher
+func newConfigHasher() *configHasher {
+	return &configHasher{h: sha256.New()}
+}
+
+// addString adds a named plain value to the hash
+func (c *configHasher) addString(name, value string) {
+	_, _ = fmt.Fprintf(c.h, "%s=%d:%s;", name, len(value), value)
+}
+
+// addData adds the content of a secret or config map to the hash in a stable key order
+func (c *configHasher) addData(name string, data map[string][]byte) {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	c.addString("source", name)
+	for _, key := range keys {
+		c.addString(key, string(data[key]))
+	}
+}
+
+// sum returns the short hex representation of the hash
+func (c *configHasher) sum() string {
+	return hex.EncodeToString(c.h.Sum(nil))[:16]
+}
+
+// addSecrets adds the data of the named secrets in the deployment namespace to the hash.
+// Missing secrets are hashed as empty so that their later creation triggers a rollout.
+func (r *LMDeploymentReconciler) addSecrets(ctx context.Context, hasher *configHasher, namespace string, names ...string) error {
+	for _, name := range names {
+		secret := &corev1.Secret{}
+		err := r.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, secret)
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to get secret %s for config hash: %w", name, err)
+		}
+		hasher.addData(name, secret.Data)
+	}
+	return nil
+}
+
+// setConfigHash annotates the pod template of a deployment with the config hash
+func setConfigHash(deployment *appsv1.Deployment, configHash string) {
+	if deployment.Spec.Template.Annotations == nil {
+		deployment.Spec.Template.Annotations = map[string]string{}
+	}
+	deployment.Spec.Template.Annotations[llmgeeperiov1alpha1.ConfigHashAnnotation] = configHash
+}
+
+// rolledOutConfigHash returns the config hash of a deployment once all of its replicas run the current pod template,
+// otherwise the previously reported hash is kept
+func rolledOutConfigHash(deployment *appsv1.Deployment, current string) string {
+	if deployment.Status.ObservedGeneration < deployment.Generation {
+		return current
+	}
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	if deployment.Status.UpdatedReplicas != replicas || deployment.Status.Replicas != replicas {
+		return current
+	}
+	return deployment.Spec.Template.Annotations[llmgeeperiov1alpha1.ConfigHashAnnotation]
+}
+
+// rolledOutConfigHashes returns the config hash shared by deployments once all of them completed their rollout.
+// The previously reported hash is kept while one of them is missing, still rolling out or runs another hash.
+func rolledOutConfigHashes(deployments []*appsv1.Deployment, current string) string {
+	rolledOut := ""
+	for _, deployment := range deployments {
+		if deployment == nil {
+			return current
+		}
+		hash := rolledOutConfigHash(deployment, "")
+		if hash == "" || (rolledOut != "" && hash != rolledOut) {
+			return current
+		}
+		rolledOut = hash
+	}
+	if rolledOut == "" {
+		return current
+	}
+	return rolledOut
+}
+
+// referencedSecretNames returns the names of all secrets whose content is consumed by the generated workloads
+func referencedSecretNames(deployment *llmgeeperiov1alpha1.LMDeployment) []string {
+	var names []string
+	if deployment.Spec.Ollama.Enabled && deployment.Spec.Ollama.Exposed() {
+		names = append(names, deployment.GetOllamaAuthTokensSecretName())
+	}
+	if deployment.Spec.VLLM.Enabled {
+		names = append(names, deployment.GetVLLMApiKeySecretName())
+	}
+	if deployment.Spec.OpenWebUI.Enabled {
+		names = append(names, deployment.GetOpenWebUISecretName(), deployment.GetOpenWebUIConfigName())
+
+		if deployment.Spec.OpenWebUI.Pipelines != nil && deployment.Spec.OpenWebUI.Pipelines.Enabled {
+			names = append(names, deployment.GetPipelinesSecretName())
+		}
+		if langfuse := deployment.Spec.OpenWebUI.Langfuse; langfuse != nil && langfuse.Enabled && langfuse.SecretRef != nil {
+			names = append(names, langfuse.SecretRef.Name)
+		}
+		if secret := openWebUIDatabaseSecret(deployment); secret != "" {
+			names = append(names, secret)
+		}
+		for _, ref := range openWebUIVectorDBSecrets(deployment) {
+			names = append(names, ref.Name)
+		}
+		if secret := openWebUIAuthSecret(deployment); secret != "" {
+			names = append(names, secret)
+		}
+		if secret := openWebUIBootstrapSecret(deployment); secret != "" {
+			names = append(names, secret)
+		}
+		if secret := openWebUIRedisSecret(deployment); secret != "" {
+			names = append(names, secret)
+		}
+	}
+	if deployment.Spec.Tabby.Enabled {
+		names = append(names, deployment.GetTabbySecretName())
+	}
+	if deployment.Spec.OpenWebUI.Enabled || deployment.Spec.Tabby.Enabled {
+		for _, provider := range deployment.Spec.External {
+			if provider.APIKeySecretRef != nil {
+				names = append(names, provider.APIKeySecretRef.Name)
+			}
+		}
+	}
+	return names
+}
+
+// findDeploymentsForSecret maps a secret to the LMDeployments consuming it so that secret changes roll out
+func (r *LMDeploymentReconciler) findDeploymentsForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	deployments := &llmgeeperiov1alpha1.LMDeploymentList{}
+	if err := r.List(ctx, deployments, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, deployment := range deployments.Items {
+		for _, name := range referencedSecretNames(&deployment) {
+			if name == obj.GetName() {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&deployment)})
+				break
+			}
+		}
+	}
+	return requests
+}
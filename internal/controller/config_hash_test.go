@@ -0,0 +1,200 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+func TestConfigHash_Hasher(t *testing.T) {
+	t.Run("should not depend on key order", func(t *testing.T) {
+		first := newConfigHasher()
+		first.addData("config", map[string][]byte{"a": []byte("1"), "b": []byte("2")})
+
+		second := newConfigHasher()
+		second.addData("config", map[string][]byte{"b": []byte("2"), "a": []byte("1")})
+
+		assert.Equal(t, first.sum(), second.sum())
+	})
+
+	t.Run("should change when a value changes", func(t *testing.T) {
+		first := newConfigHasher()
+		first.addData("config", map[string][]byte{"config.toml": []byte("old")})
+
+		second := newConfigHasher()
+		second.addData("config", map[string][]byte{"config.toml": []byte("new")})
+
+		assert.NotEqual(t, first.sum(), second.sum())
+	})
+
+	t.Run("should not collide when values are shifted between keys", func(t *testing.T) {
+		first := newConfigHasher()
+		first.addString("a", "bc")
+
+		second := newConfigHasher()
+		second.addString("ab", "c")
+
+		assert.NotEqual(t, first.sum(), second.sum())
+	})
+}
+
+func TestConfigHash_RolledOutConfigHash(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Generation: 2},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(int32(2)),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{llmgeeperiov1alpha1.ConfigHashAnnotation: "new"},
+				},
+			},
+		},
+		Status: appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 1},
+	}
+
+	assert.Equal(t, "old", rolledOutConfigHash(deployment, "old"), "rollout in progress keeps the previous revision")
+
+	deployment.Status.Replicas = 2
+	deployment.Status.UpdatedReplicas = 2
+	assert.Equal(t, "new", rolledOutConfigHash(deployment, "old"), "completed rollout reports the new revision")
+}
+
+func TestConfigHash_VLLMRolledOut(t *testing.T) {
+	scheme := newTestScheme(t)
+	deployment := &llmgeeperiov1alpha1.LMDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "default", UID: "uid"},
+		Spec: llmgeeperiov1alpha1.LMDeploymentSpec{
+			VLLM: llmgeeperiov1alpha1.VLLMSpec{
+				Enabled: true,
+				Models: []llmgeeperiov1alpha1.VLLMModelSpec{
+					{Name: "llama", Model: "meta-llama/Llama-3.1-8B-Instruct", Replicas: 1},
+					{Name: "qwen", Model: "Qwen/Qwen2.5-7B-Instruct", Replicas: 1},
+				},
+			},
+		},
+		Status: llmgeeperiov1alpha1.LMDeploymentStatus{
+			VLLMStatus: llmgeeperiov1alpha1.LMDeploymentComponentStatus{ConfigHash: "old"},
+		},
+	}
+	workload := func(name string, updated int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: ptr.To(int32(1)),
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{llmgeeperiov1alpha1.ConfigHashAnnotation: "new"}},
+				},
+			},
+			Status: appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: updated},
+		}
+	}
+	qwen := workload(deployment.GetVLLMModelDeploymentName("qwen"), 0)
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithStatusSubresource(&llmgeeperiov1alpha1.LMDeployment{}, &appsv1.Deployment{}).
+		WithObjects(deployment.DeepCopy(),
+			workload(deployment.GetVLLMModelDeploymentName("llama"), 1),
+			qwen,
+			workload(deployment.GetVLLMRouterDeploymentName(), 1)).
+		Build()
+	reconciler := &LMDeploymentReconciler{Client: c, Scheme: scheme}
+
+	require.NoError(t, c.Get(t.Context(), types.NamespacedName{Name: "test-deployment", Namespace: "default"}, deployment))
+	require.NoError(t, reconciler.updateStatus(t.Context(), deployment))
+	assert.Equal(t, "old", deployment.Status.VLLMStatus.ConfigHash, "one model is still rolling out")
+
+	qwen.Status.UpdatedReplicas = 1
+	require.NoError(t, c.Status().Update(t.Context(), qwen))
+	require.NoError(t, reconciler.updateStatus(t.Context(), deployment))
+	assert.Equal(t, "new", deployment.Status.VLLMStatus.ConfigHash, "every model and the router rolled out")
+}
+
+func TestConfigHash_FindDeploymentsForSecret(t *testing.T) {
+	scheme := newTestScheme(t)
+	deployment := &llmgeeperiov1alpha1.LMDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "default"},
+		Spec: llmgeeperiov1alpha1.LMDeploymentSpec{
+			OpenWebUI: llmgeeperiov1alpha1.OpenWebUISpec{
+				Enabled: true,
+				Langfuse: &llmgeeperiov1alpha1.LangfuseSpec{
+					Enabled:   true,
+					SecretRef: &corev1.SecretReference{Name: "langfuse-credentials"},
+				},
+			},
+		},
+	}
+	reconciler := &LMDeploymentReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment).Build(),
+		Scheme: scheme,
+	}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "langfuse-credentials", Namespace: "default"}}
+	requests := reconciler.findDeploymentsForSecret(t.Context(), secret)
+	require.Len(t, requests, 1)
+	assert.Equal(t, types.NamespacedName{Name: "test-deployment", Namespace: "default"}, requests[0].NamespacedName)
+
+	unrelated := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "default"}}
+	assert.Empty(t, reconciler.findDeploymentsForSecret(t.Context(), unrelated))
+}
+
+func TestConfigHash_TabbyRollsOutConfigChanges(t *testing.T) {
+	scheme := newTestScheme(t)
+	deployment := &llmgeeperiov1alpha1.LMDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "default"},
+		Spec: llmgeeperiov1alpha1.LMDeploymentSpec{
+			Ollama: llmgeeperiov1alpha1.OllamaSpec{
+				Enabled: true,
+				Models:  []string{"llama2:7b", "codellama:7b"},
+				Service: llmgeeperiov1alpha1.ServiceSpec{Port: 11434},
+			},
+			Tabby: llmgeeperiov1alpha1.TabbySpec{
+				Enabled:         true,
+				ChatModel:       "llama2:7b",
+				CompletionModel: "llama2:7b",
+			},
+		},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment).Build()
+	reconciler := &LMDeploymentReconciler{Client: k8sClient, Scheme: scheme}
+
+	templateHash := func() string {
+		tabby := &appsv1.Deployment{}
+		require.NoError(t, k8sClient.Get(t.Context(), types.NamespacedName{Name: deployment.GetTabbyDeploymentName(), Namespace: "default"}, tabby))
+		return tabby.Spec.Template.Annotations[llmgeeperiov1alpha1.ConfigHashAnnotation]
+	}
+
+	require.NoError(t, reconciler.reconcileTabby(t.Context(), deployment))
+	initial := templateHash()
+	assert.NotEmpty(t, initial)
+
+	require.NoError(t, reconciler.reconcileTabby(t.Context(), deployment))
+	assert.Equal(t, initial, templateHash(), "unchanged config must not restart pods")
+
+	deployment.Spec.Tabby.CompletionModel = "codellama:7b"
+	require.NoError(t, reconciler.reconcileTabby(t.Context(), deployment))
+	assert.NotEqual(t, initial, templateHash(), "changed config must restart pods")
+}
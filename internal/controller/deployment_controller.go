@@ -20,21 +20,35 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"reflect"
+	"sync"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/cluster-api/util/patch"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+	operatorconfig "github.com/geeper-io/llm-operator/internal/config"
+	"github.com/geeper-io/llm-operator/internal/registry"
+	"github.com/geeper-io/llm-operator/internal/sentinel"
 )
 
 const (
@@ -46,6 +60,21 @@ const (
 type LMDeploymentReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// Config provides the operator-level defaults, the built-in defaults are used when nil
+	Config *operatorconfig.Store
+
+	// Registry resolves image tags to digests when digest pinning is enabled
+	Registry registry.Client
+
+	// Sentinel reports the health of Redis in sentinel mode
+	Sentinel sentinel.Client
+
+	// OperatorNamespace is the namespace the operator runs in, its pods are allowed to query Sentinel
+	OperatorNamespace string
+
+	// redisProbes caches the last Sentinel view of Redis per deployment
+	redisProbes sync.Map
 }
 
 // +kubebuilder:rbac:groups=llm.geeper.io,resources=lmdeployments,verbs=get;list;watch;create;update;patch;delete
@@ -85,6 +114,15 @@ func (r *LMDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{}, nil
 	}
 
+	// Leave owned resources untouched while reconciliation is paused, only refresh status
+	if deployment.IsReconcilePaused() {
+		logger.Info("Reconciliation is paused", "annotation", llmgeeperiov1alpha1.ReconcilePausedAnnotation)
+		if err := r.updateStatus(ctx, deployment); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update deployment status: %w", err)
+		}
+		return ctrl.Result{}, nil
+	}
+
 	// Add finalizer if it doesn't exist
 	if !containsFinalizer(deployment.Finalizers, FinalizerName) {
 		deployment.Finalizers = append(deployment.Finalizers, FinalizerName)
@@ -94,12 +132,28 @@ func (r *LMDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{}, nil
 	}
 
+	// Resolve image digests before building the workloads so they can be pinned in the pod templates
+	requeueAfter, err := r.reconcileImageDigests(ctx, deployment)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to resolve image digests: %w", err)
+	}
+
 	// Reconcile model serving deployment (Ollama or vLLM)
 	if deployment.Spec.VLLM.Enabled {
 		// Reconcile vLLM deployment
-		if err := r.reconcileVLLM(ctx, deployment); err != nil {
+		idleAfter, err := r.reconcileVLLM(ctx, deployment)
+		if err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to reconcile vLLM: %w", err)
 		}
+		// Scale models to zero once their idle timeout expires
+		if idleAfter > 0 && (requeueAfter == 0 || idleAfter < requeueAfter) {
+			requeueAfter = idleAfter
+		}
+	}
+
+	// Rescale components when their next schedule fires
+	if scheduleAfter := scheduleRequeueAfter(deployment); scheduleAfter > 0 && (requeueAfter == 0 || scheduleAfter < requeueAfter) {
+		requeueAfter = scheduleAfter
 	}
 
 	if deployment.Spec.Ollama.Enabled {
@@ -109,6 +163,11 @@ func (r *LMDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		}
 	}
 
+	// Reconcile the embedding model server, removing it when another backend serves the embeddings
+	if err := r.reconcileEmbeddings(ctx, deployment); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to reconcile embeddings: %w", err)
+	}
+
 	if deployment.Spec.OpenWebUI.Enabled {
 		if err := r.reconcileOpenWebUI(ctx, deployment); err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to reconcile OpenWebUI: %w", err)
@@ -121,14 +180,23 @@ func (r *LMDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		}
 	}
 
+	if err := r.reconcileNetworkPolicies(ctx, deployment); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to reconcile network policies: %w", err)
+	}
+
 	// Update status
 	if err := r.updateStatus(ctx, deployment); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to update deployment status: %w", err)
 	}
 
+	// Query Sentinel again until Redis is ready
+	if redisAfter := redisStatusRequeueAfter(deployment); redisAfter > 0 && (requeueAfter == 0 || redisAfter < requeueAfter) {
+		requeueAfter = redisAfter
+	}
+
 	// Only requeue if there are actual changes that need monitoring
 	// If everything is stable, don't requeue unnecessarily
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 // containsFinalizer checks if a slice contains a specific finalizer
@@ -156,6 +224,7 @@ func removeFinalizer(finalizers []string, finalizer string) []string {
 func (r *LMDeploymentReconciler) finalizeDeployment(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment) error {
 	logger := log.FromContext(ctx)
 	logger.Info("Finalizing deployment", "name", deployment.Name)
+	r.redisProbes.Delete(client.ObjectKeyFromObject(deployment))
 
 	// Remove finalizer
 	deployment.Finalizers = removeFinalizer(deployment.Finalizers, FinalizerName)
@@ -175,6 +244,31 @@ func (r *LMDeploymentReconciler) buildResourceRequirements(resources llmgeeperio
 	}
 }
 
+// imageOrDefault returns the given image, or the operator default image prefixed with the registry mirror
+func (r *LMDeploymentReconciler) imageOrDefault(image, defaultImage string) string {
+	if image != "" {
+		return image
+	}
+	return r.operatorConfig().Image(defaultImage)
+}
+
+// ollamaImage returns the Ollama image, falling back to the operator default for the configured flavor
+func (r *LMDeploymentReconciler) ollamaImage(deployment *llmgeeperiov1alpha1.LMDeployment) string {
+	defaultImage := r.operatorConfig().Images.Ollama
+	if deployment.Spec.Ollama.Flavor == "amd" {
+		defaultImage = r.operatorConfig().Images.OllamaROCm
+	}
+	return r.imageOrDefault(deployment.Spec.Ollama.Image, defaultImage)
+}
+
+// desiredReplicas returns the replica count for a generated workload, scaling it to zero while the deployment is suspended
+func (r *LMDeploymentReconciler) desiredReplicas(deployment *llmgeeperiov1alpha1.LMDeployment, replicas int32) *int32 {
+	if deployment.Spec.Suspend {
+		return ptr.To(int32(0))
+	}
+	return ptr.To(replicas)
+}
+
 // createOrUpdateDeployment creates or updates a deployment using patch helper to avoid unnecessary reconciliations
 func (r *LMDeploymentReconciler) createOrUpdateDeployment(ctx context.Context, deployment *appsv1.Deployment) error {
 	existing := &appsv1.Deployment{}
@@ -185,6 +279,12 @@ func (r *LMDeploymentReconciler) createOrUpdateDeployment(ctx context.Context, d
 			return err
 		}
 	} else if err == nil {
+		// Autoscaled deployments keep the replicas set by the autoscaler, unless scaled to zero
+		// as an HPA doesn't scale a deployment up from zero
+		if deployment.Spec.Replicas == nil && existing.Spec.Replicas != nil && *existing.Spec.Replicas > 0 {
+			deployment.Spec.Replicas = existing.Spec.Replicas
+		}
+
 		// Update existing deployment using patch helper
 		if !reflect.DeepEqual(existing.Spec, deployment.Spec) {
 			patchHelper, err := patch.NewHelper(existing, r.Client)
@@ -203,6 +303,37 @@ func (r *LMDeploymentReconciler) createOrUpdateDeployment(ctx context.Context, d
 	return nil
 }
 
+// createOrUpdateStatefulSet creates or updates a StatefulSet using patch helper to avoid unnecessary reconciliations.
+// The volume claim templates can't be changed once the StatefulSet exists and are kept.
+func (r *LMDeploymentReconciler) createOrUpdateStatefulSet(ctx context.Context, statefulSet *appsv1.StatefulSet) error {
+	existing := &appsv1.StatefulSet{}
+	err := r.Get(ctx, types.NamespacedName{Name: statefulSet.Name, Namespace: statefulSet.Namespace}, existing)
+	if err != nil && errors.IsNotFound(err) {
+		// Create new StatefulSet
+		if err := r.Create(ctx, statefulSet); err != nil {
+			return err
+		}
+	} else if err == nil {
+		statefulSet.Spec.VolumeClaimTemplates = existing.Spec.VolumeClaimTemplates
+
+		// Update existing StatefulSet using patch helper
+		if !reflect.DeepEqual(existing.Spec, statefulSet.Spec) {
+			patchHelper, err := patch.NewHelper(existing, r.Client)
+			if err != nil {
+				return fmt.Errorf("failed to create patch helper for statefulset %s: %w", statefulSet.Name, err)
+			}
+
+			existing.Spec = statefulSet.Spec
+			if err := patchHelper.Patch(ctx, existing); err != nil {
+				return fmt.Errorf("failed to patch statefulset %s: %w", statefulSet.Name, err)
+			}
+		}
+	} else {
+		return err
+	}
+	return nil
+}
+
 // createOrUpdateService creates or updates a service using patch helper to avoid unnecessary reconciliations
 func (r *LMDeploymentReconciler) createOrUpdateService(ctx context.Context, service *corev1.Service) error {
 	existing := &corev1.Service{}
@@ -237,18 +368,21 @@ func (r *LMDeploymentReconciler) createOrUpdateIngress(ctx context.Context, ingr
 	err := r.Get(ctx, types.NamespacedName{Name: ingress.Name, Namespace: ingress.Namespace}, existing)
 	if err != nil && errors.IsNotFound(err) {
 		// Create new ingress
+		ingress.Annotations = applyManagedAnnotations(nil, ingress.Annotations)
 		if err := r.Create(ctx, ingress); err != nil {
 			return err
 		}
 	} else if err == nil {
-		// Update existing ingress using patch helper
-		if !reflect.DeepEqual(existing.Spec, ingress.Spec) {
+		// Update existing ingress using patch helper, annotations added by others are kept
+		annotations := applyManagedAnnotations(existing.Annotations, ingress.Annotations)
+		if !reflect.DeepEqual(existing.Spec, ingress.Spec) || !reflect.DeepEqual(existing.Annotations, annotations) {
 			patchHelper, err := patch.NewHelper(existing, r.Client)
 			if err != nil {
 				return fmt.Errorf("failed to create patch helper for ingress %s: %w", ingress.Name, err)
 			}
 
 			existing.Spec = ingress.Spec
+			existing.Annotations = annotations
 			if err := patchHelper.Patch(ctx, existing); err != nil {
 				return fmt.Errorf("failed to patch ingress %s: %w", ingress.Name, err)
 			}
@@ -274,13 +408,22 @@ func (r *LMDeploymentReconciler) updateStatus(ctx context.Context, deployment *l
 		var totalVLLMReadyReplicas int32
 		var totalVLLMAvailableReplicas int32
 		var totalVLLMUpdatedReplicas int32
+		deployment.Status.VLLMStatus.Autoscaling = nil
+		deployment.Status.VLLMStatus.ScaleToZero = nil
+		deployment.Status.VLLMStatus.Schedules = nil
+		// Promotions are decided on the reported canaries, they are replaced once all models are reported
+		var canaryStatuses []llmgeeperiov1alpha1.CanaryStatus
+		deployment.Status.VLLMStatus.SmokeTests = nil
+		// The config hash is rolled out once every model, canary and the router run it, a missing one is nil
+		var hashedDeployments []*appsv1.Deployment
 
 		for _, modelSpec := range deployment.Spec.VLLM.Models {
 			replicas := modelSpec.Replicas
 			if replicas == 0 {
 				replicas = 1
 			}
-			totalVLLMReplicas += replicas
+			replicas = scheduledReplicas(modelSpec.Schedules, replicas)
+			setScheduleStatus(modelSpec.Name, modelSpec.Schedules, &deployment.Status.VLLMStatus.Schedules)
 
 			// Get individual model deployment status
 			vllmDeployment := &appsv1.Deployment{}
@@ -289,12 +432,57 @@ func (r *LMDeploymentReconciler) updateStatus(ctx context.Context, deployment *l
 				Namespace: deployment.Namespace,
 			}, vllmDeployment)
 
+			stableDeployment := vllmDeployment
 			if err == nil {
 				totalVLLMReadyReplicas += vllmDeployment.Status.ReadyReplicas
 				totalVLLMAvailableReplicas += vllmDeployment.Status.AvailableReplicas
 				totalVLLMUpdatedReplicas += vllmDeployment.Status.UpdatedReplicas
+				// Autoscaled models run as many replicas as the autoscaler asked for
+				if modelSpec.Autoscaling != nil && vllmDeployment.Spec.Replicas != nil {
+					replicas = *vllmDeployment.Spec.Replicas
+				}
+				// Idle models are scaled to zero
+				if modelSpec.IdleTimeout != nil && vllmDeployment.Spec.Replicas != nil && *vllmDeployment.Spec.Replicas == 0 {
+					replicas = 0
+				}
+				setScaleToZeroStatus(deployment, modelSpec, vllmDeployment, &deployment.Status.VLLMStatus.ScaleToZero)
+			} else {
+				stableDeployment = nil
+				setScaleToZeroStatus(deployment, modelSpec, nil, &deployment.Status.VLLMStatus.ScaleToZero)
+			}
+
+			// A running canary serves part of the replicas of its model
+			canaryDeployment := r.getVLLMCanary(ctx, deployment, modelSpec)
+			if canaryDeployment != nil {
+				totalVLLMReadyReplicas += canaryDeployment.Status.ReadyReplicas
+				totalVLLMAvailableReplicas += canaryDeployment.Status.AvailableReplicas
+				totalVLLMUpdatedReplicas += canaryDeployment.Status.UpdatedReplicas
+				if stableDeployment != nil && stableDeployment.Spec.Replicas != nil && canaryDeployment.Spec.Replicas != nil {
+					replicas = *stableDeployment.Spec.Replicas + *canaryDeployment.Spec.Replicas
+				}
 			}
+			hashedDeployments = append(hashedDeployments, stableDeployment)
+			if canaryDeployment != nil {
+				hashedDeployments = append(hashedDeployments, canaryDeployment)
+			}
+			r.setCanaryStatus(ctx, deployment, modelSpec, stableDeployment, canaryDeployment, &canaryStatuses)
+			r.setSmokeTestStatus(ctx, deployment, modelSpec, stableDeployment, &deployment.Status.VLLMStatus.SmokeTests)
+			totalVLLMReplicas += replicas
+
+			r.setAutoscalingStatus(ctx, deployment, deployment.GetVLLMModelDeploymentName(modelSpec.Name), modelSpec.Autoscaling, &deployment.Status.VLLMStatus.Autoscaling)
+		}
+
+		deployment.Status.VLLMStatus.Canaries = canaryStatuses
+
+		routerDeployment := &appsv1.Deployment{}
+		if err := r.Get(ctx, types.NamespacedName{Name: deployment.GetVLLMRouterDeploymentName(), Namespace: deployment.Namespace}, routerDeployment); err != nil {
+			routerDeployment = nil
 		}
+		hashedDeployments = append(hashedDeployments, routerDeployment)
+		deployment.Status.VLLMStatus.ConfigHash = rolledOutConfigHashes(hashedDeployments, deployment.Status.VLLMStatus.ConfigHash)
+		setModelVerifiedCondition(deployment, deployment.Status.VLLMStatus.SmokeTests, &deployment.Status.VLLMStatus.Conditions)
+
+		r.setRouteConditions(ctx, deployment, deployment.Spec.VLLM.Router.Gateway, deployment.GetVLLMRouterHTTPRouteName(), &deployment.Status.VLLMStatus.Conditions)
 
 		// Update vLLM status
 		deployment.Status.VLLMStatus.ReadyReplicas = totalVLLMReadyReplicas
@@ -321,9 +509,20 @@ func (r *LMDeploymentReconciler) updateStatus(ctx context.Context, deployment *l
 			deployment.Status.OllamaStatus.AvailableReplicas = ollamaDeployment.Status.AvailableReplicas
 			deployment.Status.OllamaStatus.ReadyReplicas = ollamaDeployment.Status.ReadyReplicas
 			deployment.Status.OllamaStatus.UpdatedReplicas = ollamaDeployment.Status.UpdatedReplicas
+			deployment.Status.OllamaStatus.ConfigHash = rolledOutConfigHash(ollamaDeployment, deployment.Status.OllamaStatus.ConfigHash)
 		}
+		r.setRouteConditions(ctx, deployment, deployment.Spec.Ollama.Gateway, deployment.GetOllamaHTTPRouteName(), &deployment.Status.OllamaStatus.Conditions)
+
+		deployment.Status.OllamaStatus.Autoscaling = nil
+		r.setAutoscalingStatus(ctx, deployment, deployment.GetOllamaDeploymentName(), deployment.Spec.Ollama.Autoscaling, &deployment.Status.OllamaStatus.Autoscaling)
+
+		deployment.Status.OllamaStatus.Schedules = nil
+		setScheduleStatus("ollama", deployment.Spec.Ollama.Schedules, &deployment.Status.OllamaStatus.Schedules)
 
-		deployment.Status.TotalReplicas = deployment.Spec.Ollama.Replicas
+		deployment.Status.TotalReplicas = scheduledReplicas(deployment.Spec.Ollama.Schedules, deployment.Spec.Ollama.Replicas)
+		if deployment.Spec.Ollama.Autoscaling != nil && err == nil && ollamaDeployment.Spec.Replicas != nil {
+			deployment.Status.TotalReplicas = *ollamaDeployment.Spec.Replicas
+		}
 		deployment.Status.ReadyReplicas = deployment.Status.OllamaStatus.ReadyReplicas
 	}
 
@@ -339,9 +538,18 @@ func (r *LMDeploymentReconciler) updateStatus(ctx context.Context, deployment *l
 			deployment.Status.OpenWebUIStatus.AvailableReplicas = openwebuiDeployment.Status.AvailableReplicas
 			deployment.Status.OpenWebUIStatus.ReadyReplicas = openwebuiDeployment.Status.ReadyReplicas
 			deployment.Status.OpenWebUIStatus.UpdatedReplicas = openwebuiDeployment.Status.UpdatedReplicas
+			deployment.Status.OpenWebUIStatus.ConfigHash = rolledOutConfigHash(openwebuiDeployment, deployment.Status.OpenWebUIStatus.ConfigHash)
 		}
 
-		deployment.Status.TotalReplicas += deployment.Spec.OpenWebUI.Replicas
+		r.setRouteConditions(ctx, deployment, deployment.Spec.OpenWebUI.Gateway, deployment.GetOpenWebUIHTTPRouteName(), &deployment.Status.OpenWebUIStatus.Conditions)
+		r.setVectorDBCondition(ctx, deployment)
+		r.setBootstrappedCondition(ctx, deployment)
+		r.setRedisStatus(ctx, deployment)
+
+		deployment.Status.OpenWebUIStatus.Schedules = nil
+		setScheduleStatus("openwebui", deployment.Spec.OpenWebUI.Schedules, &deployment.Status.OpenWebUIStatus.Schedules)
+
+		deployment.Status.TotalReplicas += scheduledReplicas(deployment.Spec.OpenWebUI.Schedules, deployment.Spec.OpenWebUI.Replicas)
 		deployment.Status.ReadyReplicas += deployment.Status.OpenWebUIStatus.ReadyReplicas
 	}
 
@@ -357,36 +565,123 @@ func (r *LMDeploymentReconciler) updateStatus(ctx context.Context, deployment *l
 			deployment.Status.TabbyStatus.AvailableReplicas = tabbyDeployment.Status.AvailableReplicas
 			deployment.Status.TabbyStatus.ReadyReplicas = tabbyDeployment.Status.ReadyReplicas
 			deployment.Status.TabbyStatus.UpdatedReplicas = tabbyDeployment.Status.UpdatedReplicas
+			deployment.Status.TabbyStatus.ConfigHash = rolledOutConfigHash(tabbyDeployment, deployment.Status.TabbyStatus.ConfigHash)
 		}
 
-		deployment.Status.TotalReplicas += deployment.Spec.Tabby.Replicas
+		r.setRouteConditions(ctx, deployment, deployment.Spec.Tabby.Gateway, deployment.GetTabbyHTTPRouteName(), &deployment.Status.TabbyStatus.Conditions)
+
+		deployment.Status.TabbyStatus.Schedules = nil
+		setScheduleStatus("tabby", deployment.Spec.Tabby.Schedules, &deployment.Status.TabbyStatus.Schedules)
+
+		deployment.Status.TotalReplicas += scheduledReplicas(deployment.Spec.Tabby.Schedules, deployment.Spec.Tabby.Replicas)
 		deployment.Status.ReadyReplicas += deployment.Status.TabbyStatus.ReadyReplicas
 	}
 
+	// Suspended deployments don't expect any running replicas
+	if deployment.Spec.Suspend {
+		deployment.Status.TotalReplicas = 0
+	}
+
 	// Set phase
-	// nolint:staticcheck
-	if deployment.Status.ReadyReplicas == 0 {
-		deployment.Status.Phase = "Pending"
-	} else if deployment.Status.ReadyReplicas == deployment.Status.TotalReplicas {
-		deployment.Status.Phase = "Ready"
-	} else {
-		deployment.Status.Phase = "Progressing"
+	switch {
+	case deployment.IsReconcilePaused():
+		deployment.Status.Phase = llmgeeperiov1alpha1.PhasePaused
+	case deployment.Spec.Suspend:
+		deployment.Status.Phase = llmgeeperiov1alpha1.PhaseSuspended
+	case deployment.Status.ReadyReplicas == 0:
+		deployment.Status.Phase = llmgeeperiov1alpha1.PhasePending
+	case deployment.Status.ReadyReplicas == deployment.Status.TotalReplicas:
+		deployment.Status.Phase = llmgeeperiov1alpha1.PhaseReady
+	default:
+		deployment.Status.Phase = llmgeeperiov1alpha1.PhaseProgressing
 	}
 
+	r.setSuspendConditions(deployment)
+
 	// Use patch helper to update status - this only updates fields that actually changed
 	return patchHelper.Patch(ctx, deployment)
 }
 
+// setSuspendConditions reflects spec.suspend and the reconcile-paused annotation in the status conditions
+func (r *LMDeploymentReconciler) setSuspendConditions(deployment *llmgeeperiov1alpha1.LMDeployment) {
+	suspended := metav1.Condition{
+		Type:               llmgeeperiov1alpha1.ConditionSuspended,
+		Status:             metav1.ConditionFalse,
+		Reason:             "Running",
+		Message:            "Workloads are running with their configured replicas",
+		ObservedGeneration: deployment.Generation,
+	}
+	if deployment.Spec.Suspend {
+		suspended.Status = metav1.ConditionTrue
+		suspended.Reason = "SuspendRequested"
+		suspended.Message = "All workloads are scaled to zero because spec.suspend is set"
+	}
+	meta.SetStatusCondition(&deployment.Status.Conditions, suspended)
+
+	paused := metav1.Condition{
+		Type:               llmgeeperiov1alpha1.ConditionReconcilePaused,
+		Status:             metav1.ConditionFalse,
+		Reason:             "Reconciling",
+		Message:            "Owned resources are reconciled",
+		ObservedGeneration: deployment.Generation,
+	}
+	if deployment.IsReconcilePaused() {
+		paused.Status = metav1.ConditionTrue
+		paused.Reason = "PauseAnnotationSet"
+		paused.Message = fmt.Sprintf("Reconciliation is paused by the %s annotation", llmgeeperiov1alpha1.ReconcilePausedAnnotation)
+	}
+	meta.SetStatusCondition(&deployment.Status.Conditions, paused)
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *LMDeploymentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	// Initialize specialized controllers
-	return ctrl.NewControllerManagedBy(mgr).
+	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&llmgeeperiov1alpha1.LMDeployment{}).
 		Owns(&appsv1.Deployment{}).
-		// PVCs are managed manually via ensurePVC to avoid immutable field issues
-		// Services, ConfigMaps, Secrets, and Ingresses are managed by individual controllers
-		Named("lmdeployment").
-		Complete(r)
+		Owns(&appsv1.StatefulSet{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
+		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
+		Owns(&batchv1.Job{}).
+		// Secrets consumed by the workloads are hashed into their pod templates, roll out when they change
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.findDeploymentsForSecret))
+	// PVCs are managed manually via ensurePVC to avoid immutable field issues
+	// Services, ConfigMaps, Secrets, and Ingresses are managed by individual controllers
+
+	// HTTPRoutes are only watched when the Gateway API CRDs are installed at startup
+	if _, err := mgr.GetRESTMapper().RESTMapping(httpRouteGVK.GroupKind(), httpRouteGVK.Version); err == nil {
+		builder = builder.Owns(newHTTPRoute())
+	}
+	// ScaledObjects are only watched when the KEDA CRDs are installed at startup
+	if _, err := mgr.GetRESTMapper().RESTMapping(scaledObjectGVK.GroupKind(), scaledObjectGVK.Version); err == nil {
+		builder = builder.Owns(newScaledObject())
+	}
+
+	if r.Config != nil {
+		// Reconcile every deployment when the operator config is reloaded
+		builder = builder.WatchesRawSource(source.Channel(r.Config.Changes(), handler.EnqueueRequestsFromMapFunc(r.findAllDeployments)))
+	}
+
+	return builder.Named("lmdeployment").Complete(r)
+}
+
+// findAllDeployments maps an event to every LMDeployment in the cluster
+func (r *LMDeploymentReconciler) findAllDeployments(ctx context.Context, _ client.Object) []reconcile.Request {
+	deployments := &llmgeeperiov1alpha1.LMDeploymentList{}
+	if err := r.List(ctx, deployments); err != nil {
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(deployments.Items))
+	for _, deployment := range deployments.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&deployment)})
+	}
+	return requests
+}
+
+// operatorConfig returns the current operator-level configuration
+func (r *LMDeploymentReconciler) operatorConfig() *operatorconfig.OperatorConfig {
+	return r.Config.Get()
 }
 
 // ensurePVC creates a PersistentVolumeClaim only if it doesn't exist
@@ -430,6 +725,30 @@ func (r *LMDeploymentReconciler) createSecretIfNotExists(ctx context.Context, se
 	return nil
 }
 
+// buildVolumeSecret builds a secret holding a newly generated hex credential a data volume is initialized with,
+// such as a database password. It gets no controller reference: like the PVC it must outlive the LMDeployment,
+// a recreated deployment reuses the volume and needs the credential its data was written with.
+func buildVolumeSecret(deployment *llmgeeperiov1alpha1.LMDeployment, name, app, key string) (*corev1.Secret, error) {
+	bytes := make([]byte, 24)
+	if _, err := rand.Read(bytes); err != nil {
+		return nil, err
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: deployment.Namespace,
+			Labels: map[string]string{
+				"app":            app,
+				"llm-deployment": deployment.Name,
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			key: []byte(hex.EncodeToString(bytes)),
+		},
+	}, nil
+}
+
 // generateSecureSecret generates a cryptographically secure random secret
 func generateSecureSecret(length int) (string, error) {
 	bytes := make([]byte, length)
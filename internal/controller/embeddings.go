@@ -0,0 +1,265 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+	operatorconfig "github.com/geeper-io/llm-operator/internal/config"
+)
+
+// teiPort is the port text-embeddings-inference listens on
+const teiPort = 8080
+
+// embeddingsDeployed reports whether the operator runs text-embeddings-inference for the embedding model
+func embeddingsDeployed(deployment *llmgeeperiov1alpha1.LMDeployment) bool {
+	return deployment.Spec.EmbeddingsBackend() == llmgeeperiov1alpha1.EmbeddingsBackendTEI
+}
+
+// reconcileEmbeddings reconciles the text-embeddings-inference deployment, and removes it once another backend serves the embeddings
+func (r *LMDeploymentReconciler) reconcileEmbeddings(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment) error {
+	if !embeddingsDeployed(deployment) {
+		if err := r.deleteControlled(ctx, deployment, &appsv1.Deployment{}, deployment.GetEmbeddingsDeploymentName()); err != nil {
+			return err
+		}
+		return r.deleteControlled(ctx, deployment, &corev1.Service{}, deployment.GetEmbeddingsServiceName())
+	}
+
+	embeddingsDeployment := r.buildEmbeddingsDeployment(deployment)
+	r.pinImageDigests(deployment, &embeddingsDeployment.Spec.Template)
+	if err := r.createOrUpdateDeployment(ctx, embeddingsDeployment); err != nil {
+		return err
+	}
+
+	return r.createOrUpdateService(ctx, r.buildEmbeddingsService(deployment))
+}
+
+// servedVLLMModel returns the model identifier vLLM serves for a vllm.models entry, empty if there is none
+func servedVLLMModel(deployment *llmgeeperiov1alpha1.LMDeployment, name string) string {
+	for _, model := range deployment.Spec.VLLM.Models {
+		if model.Name == name {
+			return model.Model
+		}
+	}
+	return ""
+}
+
+// vllmAPIKeyRef references the API key of the vLLM router
+func vllmAPIKeyRef(deployment *llmgeeperiov1alpha1.LMDeployment) *corev1.SecretKeySelector {
+	return &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: deployment.GetVLLMApiKeySecretName()},
+		Key:                  llmgeeperiov1alpha1.VLLMApiKeySecretKey,
+	}
+}
+
+// openWebUIEmbeddingsEnv returns the env vars pointing OpenWebUI RAG at the embedding model and the reranker,
+// none when OpenWebUI embeds documents itself
+func openWebUIEmbeddingsEnv(deployment *llmgeeperiov1alpha1.LMDeployment) []corev1.EnvVar {
+	embeddings := deployment.Spec.Embeddings
+	if embeddings == nil {
+		return nil
+	}
+
+	var envVars []corev1.EnvVar
+	switch deployment.Spec.EmbeddingsBackend() {
+	case llmgeeperiov1alpha1.EmbeddingsBackendOllama:
+		envVars = append(envVars,
+			corev1.EnvVar{Name: "RAG_EMBEDDING_ENGINE", Value: "ollama"},
+			corev1.EnvVar{Name: "RAG_EMBEDDING_MODEL", Value: embeddings.Model},
+			corev1.EnvVar{Name: "RAG_OLLAMA_BASE_URL", Value: fmt.Sprintf("http://%s:%d", deployment.GetOllamaServiceName(), deployment.GetOllamaServicePort())},
+		)
+	case llmgeeperiov1alpha1.EmbeddingsBackendVLLM:
+		envVars = append(envVars,
+			corev1.EnvVar{Name: "RAG_EMBEDDING_ENGINE", Value: "openai"},
+			corev1.EnvVar{Name: "RAG_EMBEDDING_MODEL", Value: servedVLLMModel(deployment, embeddings.Model)},
+			corev1.EnvVar{Name: "RAG_OPENAI_API_BASE_URL", Value: fmt.Sprintf("http://%s:%d/v1", deployment.GetVLLMRouterServiceName(), vllmRouterPort(deployment))},
+			corev1.EnvVar{Name: "RAG_OPENAI_API_KEY", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: vllmAPIKeyRef(deployment)}},
+		)
+	case llmgeeperiov1alpha1.EmbeddingsBackendTEI:
+		// text-embeddings-inference serves the OpenAI embeddings API without authentication
+		envVars = append(envVars,
+			corev1.EnvVar{Name: "RAG_EMBEDDING_ENGINE", Value: "openai"},
+			corev1.EnvVar{Name: "RAG_EMBEDDING_MODEL", Value: embeddings.Model},
+			corev1.EnvVar{Name: "RAG_OPENAI_API_BASE_URL", Value: fmt.Sprintf("http://%s:%d/v1", deployment.GetEmbeddingsServiceName(), teiPort)},
+		)
+	}
+
+	// The reranker is a vLLM score model behind the router, OpenWebUI calls its rerank API
+	if embeddings.Reranker != nil {
+		envVars = append(envVars,
+			corev1.EnvVar{Name: "ENABLE_RAG_HYBRID_SEARCH", Value: "True"},
+			corev1.EnvVar{Name: "RAG_RERANKING_ENGINE", Value: "external"},
+			corev1.EnvVar{Name: "RAG_RERANKING_MODEL", Value: servedVLLMModel(deployment, embeddings.Reranker.Model)},
+			corev1.EnvVar{Name: "RAG_EXTERNAL_RERANKER_URL", Value: fmt.Sprintf("http://%s:%d/v1/rerank", deployment.GetVLLMRouterServiceName(), vllmRouterPort(deployment))},
+			corev1.EnvVar{Name: "RAG_EXTERNAL_RERANKER_API_KEY", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: vllmAPIKeyRef(deployment)}},
+		)
+	}
+	return envVars
+}
+
+// tabbyEmbeddingConfig returns the embedding model of Tabby, the local Nomic model when the deployment serves none
+func tabbyEmbeddingConfig(deployment *llmgeeperiov1alpha1.LMDeployment, vllmAPIKey string) TabbyEmbeddingConfig {
+	embeddings := deployment.Spec.Embeddings
+	switch deployment.Spec.EmbeddingsBackend() {
+	case llmgeeperiov1alpha1.EmbeddingsBackendOllama:
+		return TabbyEmbeddingConfig{HTTP: &TabbyHTTPConfig{
+			Kind:        "ollama/embedding",
+			ModelName:   embeddings.Model,
+			APIEndpoint: fmt.Sprintf("http://%s.%s:%d", deployment.GetOllamaServiceName(), deployment.Namespace, deployment.GetOllamaServicePort()),
+		}}
+	case llmgeeperiov1alpha1.EmbeddingsBackendVLLM:
+		return TabbyEmbeddingConfig{HTTP: &TabbyHTTPConfig{
+			Kind:        "openai/embedding",
+			ModelName:   servedVLLMModel(deployment, embeddings.Model),
+			APIEndpoint: fmt.Sprintf("http://%s.%s:%d/v1", deployment.GetVLLMRouterServiceName(), deployment.Namespace, vllmRouterPort(deployment)),
+			APIKey:      vllmAPIKey,
+		}}
+	case llmgeeperiov1alpha1.EmbeddingsBackendTEI:
+		return TabbyEmbeddingConfig{HTTP: &TabbyHTTPConfig{
+			Kind:        "openai/embedding",
+			ModelName:   embeddings.Model,
+			APIEndpoint: fmt.Sprintf("http://%s.%s:%d/v1", deployment.GetEmbeddingsServiceName(), deployment.Namespace, teiPort),
+		}}
+	default:
+		return TabbyEmbeddingConfig{Local: &TabbyLocalConfig{ModelID: "Nomic-Embed-Text"}}
+	}
+}
+
+// buildEmbeddingsDeployment builds the text-embeddings-inference deployment object
+func (r *LMDeploymentReconciler) buildEmbeddingsDeployment(deployment *llmgeeperiov1alpha1.LMDeployment) *appsv1.Deployment {
+	labels := map[string]string{
+		"app":            "embeddings",
+		"llm-deployment": deployment.Name,
+	}
+	spec := deployment.Spec.Embeddings.TEI
+
+	replicas := spec.Replicas
+	if replicas == 0 {
+		replicas = 1
+	}
+
+	embeddingsDeployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deployment.GetEmbeddingsDeploymentName(),
+			Namespace: deployment.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: r.desiredReplicas(deployment, replicas),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "tei",
+							Image: r.imageOrDefault(spec.Image, r.operatorConfig().Images.TEI),
+							Args: []string{
+								"--model-id", deployment.Spec.Embeddings.Model,
+								"--port", fmt.Sprintf("%d", teiPort),
+							},
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "http",
+									ContainerPort: teiPort,
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
+							Env:       spec.EnvVars,
+							Resources: r.buildResourceRequirements(operatorconfig.ResourcesOrDefault(spec.Resources, r.operatorConfig().Resources.TEI)),
+							// The model is downloaded at start, the pod is ready once it is loaded
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									HTTPGet: &corev1.HTTPGetAction{
+										Path: "/health",
+										Port: intstr.FromString("http"),
+									},
+								},
+								PeriodSeconds: 10,
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "model-cache",
+									MountPath: "/data",
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "model-cache",
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Apply pod template overrides
+	r.applyPodTemplateOverrides(&embeddingsDeployment.Spec.Template, spec.PodTemplateOverrides)
+
+	// Set owner reference
+	_ = controllerutil.SetControllerReference(deployment, embeddingsDeployment, r.Scheme)
+	return embeddingsDeployment
+}
+
+// buildEmbeddingsService builds the text-embeddings-inference service object
+func (r *LMDeploymentReconciler) buildEmbeddingsService(deployment *llmgeeperiov1alpha1.LMDeployment) *corev1.Service {
+	labels := map[string]string{
+		"app":            "embeddings",
+		"llm-deployment": deployment.Name,
+	}
+
+	embeddingsService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deployment.GetEmbeddingsServiceName(),
+			Namespace: deployment.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "http",
+					Port:       teiPort,
+					TargetPort: intstr.FromInt32(teiPort),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+			Selector: labels,
+		},
+	}
+
+	// Set owner reference
+	_ = controllerutil.SetControllerReference(deployment, embeddingsService, r.Scheme)
+	return embeddingsService
+}
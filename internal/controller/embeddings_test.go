@@ -0,0 +1,156 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+func TestOpenWebUIEmbeddingsEnv(t *testing.T) {
+	deployment := &llmgeeperiov1alpha1.LMDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "default"},
+		Spec: llmgeeperiov1alpha1.LMDeploymentSpec{
+			VLLM: llmgeeperiov1alpha1.VLLMSpec{
+				Enabled: true,
+				Models: []llmgeeperiov1alpha1.VLLMModelSpec{
+					{Name: "bge", Model: "BAAI/bge-m3", Task: "embed"},
+					{Name: "reranker", Model: "BAAI/bge-reranker-v2-m3", Task: "score"},
+				},
+			},
+			Ollama: llmgeeperiov1alpha1.OllamaSpec{Enabled: true, Models: []string{"nomic-embed-text"}},
+		},
+	}
+	env := func() map[string]corev1.EnvVar {
+		vars := map[string]corev1.EnvVar{}
+		for _, envVar := range openWebUIEmbeddingsEnv(deployment) {
+			vars[envVar.Name] = envVar
+		}
+		return vars
+	}
+
+	t.Run("should let OpenWebUI embed documents itself by default", func(t *testing.T) {
+		assert.Empty(t, openWebUIEmbeddingsEnv(deployment))
+	})
+
+	t.Run("should use a vLLM embedding model and reranker through the router", func(t *testing.T) {
+		deployment.Spec.Embeddings = &llmgeeperiov1alpha1.EmbeddingsSpec{
+			Backend:  llmgeeperiov1alpha1.EmbeddingsBackendVLLM,
+			Model:    "bge",
+			Reranker: &llmgeeperiov1alpha1.RerankerSpec{Model: "reranker"},
+		}
+
+		vars := env()
+		assert.Equal(t, "openai", vars["RAG_EMBEDDING_ENGINE"].Value)
+		assert.Equal(t, "BAAI/bge-m3", vars["RAG_EMBEDDING_MODEL"].Value)
+		assert.Equal(t, "http://test-deployment-vllm-router:8000/v1", vars["RAG_OPENAI_API_BASE_URL"].Value)
+		assert.Equal(t, "test-deployment-vllm-api-key", vars["RAG_OPENAI_API_KEY"].ValueFrom.SecretKeyRef.Name)
+		assert.Equal(t, "True", vars["ENABLE_RAG_HYBRID_SEARCH"].Value)
+		assert.Equal(t, "BAAI/bge-reranker-v2-m3", vars["RAG_RERANKING_MODEL"].Value)
+		assert.Equal(t, "http://test-deployment-vllm-router:8000/v1/rerank", vars["RAG_EXTERNAL_RERANKER_URL"].Value)
+	})
+
+	t.Run("should use an Ollama embedding model", func(t *testing.T) {
+		deployment.Spec.Embeddings = &llmgeeperiov1alpha1.EmbeddingsSpec{Backend: llmgeeperiov1alpha1.EmbeddingsBackendOllama, Model: "nomic-embed-text"}
+
+		vars := env()
+		assert.Equal(t, "ollama", vars["RAG_EMBEDDING_ENGINE"].Value)
+		assert.Equal(t, "http://test-deployment-ollama:11434", vars["RAG_OLLAMA_BASE_URL"].Value)
+		assert.NotContains(t, vars, "RAG_RERANKING_MODEL")
+	})
+}
+
+func TestTabbyEmbeddingConfig(t *testing.T) {
+	deployment := &llmgeeperiov1alpha1.LMDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "default"},
+		Spec: llmgeeperiov1alpha1.LMDeploymentSpec{
+			Ollama: llmgeeperiov1alpha1.OllamaSpec{Enabled: true, Models: []string{"codellama:7b"}},
+			Tabby:  llmgeeperiov1alpha1.TabbySpec{Enabled: true, ChatModel: "codellama:7b", CompletionModel: "codellama:7b"},
+			Embeddings: &llmgeeperiov1alpha1.EmbeddingsSpec{
+				Model: "BAAI/bge-small-en-v1.5",
+			},
+		},
+	}
+
+	config, err := (&LMDeploymentReconciler{}).generateTabbyConfig(t.Context(), deployment)
+	require.NoError(t, err)
+	assert.Contains(t, config, `  [model.embedding]
+    [model.embedding.http]
+      kind = "openai/embedding"
+      model_name = "BAAI/bge-small-en-v1.5"
+      api_endpoint = "http://test-deployment-embeddings.default:8080/v1"
+`)
+	assert.NotContains(t, config, "Nomic-Embed-Text")
+}
+
+func TestReconcileEmbeddings(t *testing.T) {
+	ctx := context.Background()
+	scheme := newTestScheme(t)
+	deployment := &llmgeeperiov1alpha1.LMDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "default", UID: "uid"},
+		Spec: llmgeeperiov1alpha1.LMDeploymentSpec{
+			Embeddings: &llmgeeperiov1alpha1.EmbeddingsSpec{
+				Model: "BAAI/bge-small-en-v1.5",
+				TEI: llmgeeperiov1alpha1.TEISpec{
+					Replicas: 2,
+					EnvVars:  []corev1.EnvVar{{Name: "HF_TOKEN", Value: "token"}},
+				},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	reconciler := &LMDeploymentReconciler{Client: c, Scheme: scheme}
+	key := types.NamespacedName{Name: "test-deployment-embeddings", Namespace: "default"}
+
+	t.Run("should serve the model with text-embeddings-inference", func(t *testing.T) {
+		require.NoError(t, reconciler.reconcileEmbeddings(ctx, deployment))
+
+		workload := &appsv1.Deployment{}
+		require.NoError(t, c.Get(ctx, key, workload))
+		assert.Equal(t, int32(2), *workload.Spec.Replicas)
+		container := workload.Spec.Template.Spec.Containers[0]
+		assert.Equal(t, "ghcr.io/huggingface/text-embeddings-inference:cpu-1.7", container.Image)
+		assert.Equal(t, []string{"--model-id", "BAAI/bge-small-en-v1.5", "--port", "8080"}, container.Args)
+		assert.Equal(t, deployment.Spec.Embeddings.TEI.EnvVars, container.Env)
+		require.NoError(t, c.Get(ctx, key, &corev1.Service{}))
+	})
+
+	t.Run("should remove text-embeddings-inference once another backend serves the embeddings", func(t *testing.T) {
+		deployment.Spec.Embeddings.Backend = llmgeeperiov1alpha1.EmbeddingsBackendOllama
+		require.NoError(t, reconciler.reconcileEmbeddings(ctx, deployment))
+
+		assert.True(t, errors.IsNotFound(c.Get(ctx, key, &appsv1.Deployment{})))
+		assert.True(t, errors.IsNotFound(c.Get(ctx, key, &corev1.Service{})))
+	})
+}
+
+func TestVLLMArgs(t *testing.T) {
+	assert.Equal(t, []string{"--max-model-len", "8192"}, vllmArgs(llmgeeperiov1alpha1.VLLMModelSpec{Args: []string{"--max-model-len", "8192"}}))
+	assert.Equal(t, []string{"--task", "embed", "--max-model-len", "8192"}, vllmArgs(llmgeeperiov1alpha1.VLLMModelSpec{Task: "embed", Args: []string{"--max-model-len", "8192"}}))
+	assert.Equal(t, []string{"codellama"}, getVLLMModelNames([]llmgeeperiov1alpha1.VLLMModelSpec{{Model: "codellama"}, {Model: "bge", Task: "embed"}}))
+}
@@ -0,0 +1,313 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+// The Gateway API is an optional CRD installation, HTTPRoutes are handled as unstructured objects
+// so the operator neither depends on its Go types nor fails when the CRDs are missing.
+var httpRouteGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"}
+
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
+
+// newHTTPRoute returns an empty HTTPRoute object
+func newHTTPRoute() *unstructured.Unstructured {
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(httpRouteGVK)
+	return route
+}
+
+// gatewayAPIAvailable reports whether the HTTPRoute CRD is installed in the cluster
+func (r *LMDeploymentReconciler) gatewayAPIAvailable() (bool, error) {
+	_, err := r.RESTMapper().RESTMapping(httpRouteGVK.GroupKind(), httpRouteGVK.Version)
+	if meta.IsNoMatchError(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// buildHTTPRoute builds an HTTPRoute routing the hostnames and paths of a GatewaySpec to a component service
+func (r *LMDeploymentReconciler) buildHTTPRoute(deployment *llmgeeperiov1alpha1.LMDeployment, name string, labels map[string]string, spec llmgeeperiov1alpha1.GatewaySpec, serviceName string, servicePort int32) *unstructured.Unstructured {
+	// Defaults the API server would add are set explicitly, so the route doesn't look changed on every reconcile
+	parentRefs := make([]interface{}, 0, len(spec.ParentRefs))
+	for _, ref := range spec.ParentRefs {
+		parentRef := map[string]interface{}{
+			"group": httpRouteGVK.Group,
+			"kind":  "Gateway",
+			"name":  ref.Name,
+		}
+		if ref.Namespace != "" {
+			parentRef["namespace"] = ref.Namespace
+		}
+		if ref.SectionName != "" {
+			parentRef["sectionName"] = ref.SectionName
+		}
+		if ref.Port != nil {
+			parentRef["port"] = int64(*ref.Port)
+		}
+		parentRefs = append(parentRefs, parentRef)
+	}
+
+	pathType := spec.PathType
+	if pathType == "" {
+		pathType = "PathPrefix"
+	}
+	paths := spec.Paths
+	if len(paths) == 0 {
+		paths = []string{"/"}
+	}
+	matches := make([]interface{}, 0, len(paths))
+	for _, path := range paths {
+		matches = append(matches, map[string]interface{}{
+			"path": map[string]interface{}{
+				"type":  pathType,
+				"value": path,
+			},
+		})
+	}
+
+	rule := map[string]interface{}{
+		"matches": matches,
+		"backendRefs": []interface{}{
+			map[string]interface{}{
+				"group":  "",
+				"kind":   "Service",
+				"name":   serviceName,
+				"port":   int64(servicePort),
+				"weight": int64(1),
+			},
+		},
+	}
+	if timeouts := spec.Timeouts; timeouts != nil {
+		routeTimeouts := map[string]interface{}{}
+		if timeouts.Request != nil {
+			routeTimeouts["request"] = gatewayDuration(timeouts.Request.Duration)
+		}
+		if timeouts.BackendRequest != nil {
+			routeTimeouts["backendRequest"] = gatewayDuration(timeouts.BackendRequest.Duration)
+		}
+		if len(routeTimeouts) > 0 {
+			rule["timeouts"] = routeTimeouts
+		}
+	}
+
+	routeSpec := map[string]interface{}{
+		"parentRefs": parentRefs,
+		"rules":      []interface{}{rule},
+	}
+	if len(spec.Hostnames) > 0 {
+		hostnames := make([]interface{}, 0, len(spec.Hostnames))
+		for _, hostname := range spec.Hostnames {
+			hostnames = append(hostnames, hostname)
+		}
+		routeSpec["hostnames"] = hostnames
+	}
+
+	route := newHTTPRoute()
+	route.SetName(name)
+	route.SetNamespace(deployment.Namespace)
+	route.SetLabels(labels)
+	if len(spec.Annotations) > 0 {
+		route.SetAnnotations(mergeStringMaps(spec.Annotations))
+	}
+	route.Object["spec"] = routeSpec
+
+	// Set owner reference
+	_ = controllerutil.SetControllerReference(deployment, route, r.Scheme)
+	return route
+}
+
+// createOrUpdateHTTPRoute creates or updates an HTTPRoute, it is skipped when the Gateway API CRDs are not installed
+func (r *LMDeploymentReconciler) createOrUpdateHTTPRoute(ctx context.Context, route *unstructured.Unstructured) error {
+	available, err := r.gatewayAPIAvailable()
+	if err != nil {
+		return fmt.Errorf("failed to discover the Gateway API: %w", err)
+	}
+	if !available {
+		log.FromContext(ctx).Info("Gateway API CRDs are not installed, skipping HTTPRoute", "name", route.GetName())
+		return nil
+	}
+
+	existing := newHTTPRoute()
+	err = r.Get(ctx, types.NamespacedName{Name: route.GetName(), Namespace: route.GetNamespace()}, existing)
+	if err != nil && errors.IsNotFound(err) {
+		// Create new HTTPRoute
+		route.SetAnnotations(applyManagedAnnotations(nil, route.GetAnnotations()))
+		if err := r.Create(ctx, route); err != nil {
+			return err
+		}
+	} else if err == nil {
+		// Update existing HTTPRoute using patch helper, annotations added by others are kept
+		annotations := applyManagedAnnotations(existing.GetAnnotations(), route.GetAnnotations())
+		if !reflect.DeepEqual(existing.Object["spec"], route.Object["spec"]) || !reflect.DeepEqual(existing.GetAnnotations(), annotations) {
+			patchHelper, err := patch.NewHelper(existing, r.Client)
+			if err != nil {
+				return fmt.Errorf("failed to create patch helper for HTTPRoute %s: %w", route.GetName(), err)
+			}
+
+			existing.Object["spec"] = route.Object["spec"]
+			existing.SetAnnotations(annotations)
+			if err := patchHelper.Patch(ctx, existing); err != nil {
+				return fmt.Errorf("failed to patch HTTPRoute %s: %w", route.GetName(), err)
+			}
+		}
+	} else {
+		return err
+	}
+	return nil
+}
+
+// deleteHTTPRoute removes the HTTPRoute of a component that is no longer exposed through a Gateway
+func (r *LMDeploymentReconciler) deleteHTTPRoute(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment, name string) error {
+	available, err := r.gatewayAPIAvailable()
+	if err != nil {
+		return fmt.Errorf("failed to discover the Gateway API: %w", err)
+	}
+	if !available {
+		return nil
+	}
+	return r.deleteControlled(ctx, deployment, newHTTPRoute(), name)
+}
+
+// gatewayDuration formats a duration in the whole units of a Gateway API duration, e.g. 1m30s or 1s500ms.
+// Precision below a millisecond is rejected by the webhook.
+func gatewayDuration(d time.Duration) string {
+	var b strings.Builder
+	for _, unit := range []struct {
+		duration time.Duration
+		suffix   string
+	}{{time.Hour, "h"}, {time.Minute, "m"}, {time.Second, "s"}, {time.Millisecond, "ms"}} {
+		if n := d / unit.duration; n > 0 {
+			fmt.Fprintf(&b, "%d%s", n, unit.suffix)
+			d -= n * unit.duration
+		}
+	}
+	if b.Len() == 0 {
+		return "0s"
+	}
+	return b.String()
+}
+
+// setRouteConditions mirrors the Accepted and ResolvedRefs conditions of a component's HTTPRoute into its status
+func (r *LMDeploymentReconciler) setRouteConditions(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment, spec *llmgeeperiov1alpha1.GatewaySpec, name string, conditions *[]metav1.Condition) {
+	if spec == nil {
+		meta.RemoveStatusCondition(conditions, llmgeeperiov1alpha1.ConditionRouteAccepted)
+		meta.RemoveStatusCondition(conditions, llmgeeperiov1alpha1.ConditionRouteResolvedRefs)
+		return
+	}
+
+	setBoth := func(status metav1.ConditionStatus, reason, message string) {
+		for _, conditionType := range []string{llmgeeperiov1alpha1.ConditionRouteAccepted, llmgeeperiov1alpha1.ConditionRouteResolvedRefs} {
+			meta.SetStatusCondition(conditions, metav1.Condition{
+				Type:               conditionType,
+				Status:             status,
+				Reason:             reason,
+				Message:            message,
+				ObservedGeneration: deployment.Generation,
+			})
+		}
+	}
+
+	available, err := r.gatewayAPIAvailable()
+	if err != nil || !available {
+		setBoth(metav1.ConditionFalse, "GatewayAPINotInstalled", "The Gateway API CRDs are not installed in the cluster")
+		return
+	}
+
+	route := newHTTPRoute()
+	if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: deployment.Namespace}, route); err != nil {
+		setBoth(metav1.ConditionUnknown, "RouteNotFound", fmt.Sprintf("HTTPRoute %s has not been created yet", name))
+		return
+	}
+
+	parents, _, _ := unstructured.NestedSlice(route.Object, "status", "parents")
+	for conditionType, routeConditionType := range map[string]string{
+		llmgeeperiov1alpha1.ConditionRouteAccepted:     "Accepted",
+		llmgeeperiov1alpha1.ConditionRouteResolvedRefs: "ResolvedRefs",
+	} {
+		meta.SetStatusCondition(conditions, aggregateParentCondition(parents, len(spec.ParentRefs), conditionType, routeConditionType, deployment.Generation))
+	}
+}
+
+// aggregateParentCondition combines a condition reported by the parent Gateways, it is only True when every parent reports True
+func aggregateParentCondition(parents []interface{}, expected int, conditionType, routeConditionType string, generation int64) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               conditionType,
+		Status:             metav1.ConditionUnknown,
+		Reason:             "Pending",
+		ObservedGeneration: generation,
+	}
+
+	reported := 0
+	for _, parent := range parents {
+		parentMap, ok := parent.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		gateway, _, _ := unstructured.NestedString(parentMap, "parentRef", "name")
+		routeConditions, _, _ := unstructured.NestedSlice(parentMap, "conditions")
+		for _, routeCondition := range routeConditions {
+			conditionMap, ok := routeCondition.(map[string]interface{})
+			if !ok || conditionMap["type"] != routeConditionType {
+				continue
+			}
+			status, _ := conditionMap["status"].(string)
+			if status == string(metav1.ConditionFalse) {
+				// A single rejecting parent fails the condition
+				reason, _ := conditionMap["reason"].(string)
+				message, _ := conditionMap["message"].(string)
+				if reason == "" {
+					reason = routeConditionType
+				}
+				condition.Status = metav1.ConditionFalse
+				condition.Reason = reason
+				condition.Message = fmt.Sprintf("Gateway %s: %s", gateway, message)
+				return condition
+			}
+			if status == string(metav1.ConditionTrue) {
+				reported++
+			}
+		}
+	}
+
+	if reported < expected {
+		condition.Message = fmt.Sprintf("%d of %d Gateways reported %s", reported, expected, routeConditionType)
+		return condition
+	}
+	condition.Status = metav1.ConditionTrue
+	condition.Reason = routeConditionType
+	condition.Message = fmt.Sprintf("All %d Gateways reported %s", expected, routeConditionType)
+	return condition
+}
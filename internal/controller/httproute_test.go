@@ -0,0 +1,194 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+func newHTTPRouteTestDeployment() *llmgeeperiov1alpha1.LMDeployment {
+	return &llmgeeperiov1alpha1.LMDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "default", Generation: 2},
+		Spec: llmgeeperiov1alpha1.LMDeploymentSpec{
+			OpenWebUI: llmgeeperiov1alpha1.OpenWebUISpec{
+				Enabled: true,
+				Service: llmgeeperiov1alpha1.ServiceSpec{Port: 8080},
+				Gateway: &llmgeeperiov1alpha1.GatewaySpec{
+					ParentRefs: []llmgeeperiov1alpha1.GatewayParentRef{
+						{Name: "public", Namespace: "gateways", SectionName: "https"},
+						{Name: "internal", Port: ptr.To(int32(80))},
+					},
+					Hostnames: []string{"chat.example.com"},
+					Timeouts: &llmgeeperiov1alpha1.GatewayTimeouts{
+						Request: &metav1.Duration{Duration: 10 * time.Minute},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestHTTPRoute_Build(t *testing.T) {
+	reconciler := &LMDeploymentReconciler{Scheme: newTestScheme(t)}
+	deployment := newHTTPRouteTestDeployment()
+
+	route := reconciler.buildOpenWebUIHTTPRoute(deployment)
+	assert.Equal(t, httpRouteGVK, route.GroupVersionKind())
+	assert.Equal(t, "test-deployment-openwebui-route", route.GetName())
+	require.Len(t, route.GetOwnerReferences(), 1)
+
+	hostnames, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hostnames")
+	assert.Equal(t, []string{"chat.example.com"}, hostnames)
+
+	parentRefs, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"group": "gateway.networking.k8s.io", "kind": "Gateway", "name": "public", "namespace": "gateways", "sectionName": "https"},
+		map[string]interface{}{"group": "gateway.networking.k8s.io", "kind": "Gateway", "name": "internal", "port": int64(80)},
+	}, parentRefs)
+
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	require.Len(t, rules, 1)
+	rule := rules[0].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"request": "10m"}, rule["timeouts"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"path": map[string]interface{}{"type": "PathPrefix", "value": "/"}},
+	}, rule["matches"])
+	backendRefs := rule["backendRefs"].([]interface{})
+	assert.Equal(t, "test-deployment-openwebui", backendRefs[0].(map[string]interface{})["name"])
+	assert.Equal(t, int64(8080), backendRefs[0].(map[string]interface{})["port"])
+}
+
+func TestHTTPRoute_Reconcile(t *testing.T) {
+	scheme := newTestScheme(t)
+
+	t.Run("should skip the route when the Gateway API is not installed", func(t *testing.T) {
+		deployment := newHTTPRouteTestDeployment()
+		reconciler := &LMDeploymentReconciler{
+			Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+			Scheme: scheme,
+		}
+
+		require.NoError(t, reconciler.createOrUpdateHTTPRoute(t.Context(), reconciler.buildOpenWebUIHTTPRoute(deployment)))
+
+		reconciler.setRouteConditions(t.Context(), deployment, deployment.Spec.OpenWebUI.Gateway, deployment.GetOpenWebUIHTTPRouteName(), &deployment.Status.OpenWebUIStatus.Conditions)
+		accepted := meta.FindStatusCondition(deployment.Status.OpenWebUIStatus.Conditions, llmgeeperiov1alpha1.ConditionRouteAccepted)
+		require.NotNil(t, accepted)
+		assert.Equal(t, metav1.ConditionFalse, accepted.Status)
+		assert.Equal(t, "GatewayAPINotInstalled", accepted.Reason)
+	})
+
+	t.Run("should create the route and mirror its conditions", func(t *testing.T) {
+		deployment := newHTTPRouteTestDeployment()
+		mapper := meta.NewDefaultRESTMapper(nil)
+		mapper.Add(httpRouteGVK, meta.RESTScopeNamespace)
+		reconciler := &LMDeploymentReconciler{
+			Client: fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).Build(),
+			Scheme: scheme,
+		}
+
+		require.NoError(t, reconciler.createOrUpdateHTTPRoute(t.Context(), reconciler.buildOpenWebUIHTTPRoute(deployment)))
+		route := newHTTPRoute()
+		key := types.NamespacedName{Name: deployment.GetOpenWebUIHTTPRouteName(), Namespace: "default"}
+		require.NoError(t, reconciler.Get(t.Context(), key, route))
+
+		conditions := &deployment.Status.OpenWebUIStatus.Conditions
+		reconciler.setRouteConditions(t.Context(), deployment, deployment.Spec.OpenWebUI.Gateway, key.Name, conditions)
+		assert.Equal(t, metav1.ConditionUnknown, meta.FindStatusCondition(*conditions, llmgeeperiov1alpha1.ConditionRouteAccepted).Status)
+
+		// The first Gateway accepts the route, the second one can't resolve the backend
+		parents := []interface{}{
+			map[string]interface{}{
+				"parentRef": map[string]interface{}{"name": "public"},
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Accepted", "status": "True", "reason": "Accepted"},
+					map[string]interface{}{"type": "ResolvedRefs", "status": "True", "reason": "ResolvedRefs"},
+				},
+			},
+			map[string]interface{}{
+				"parentRef": map[string]interface{}{"name": "internal"},
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Accepted", "status": "True", "reason": "Accepted"},
+					map[string]interface{}{"type": "ResolvedRefs", "status": "False", "reason": "BackendNotFound", "message": "service not found"},
+				},
+			},
+		}
+		require.NoError(t, unstructured.SetNestedSlice(route.Object, parents, "status", "parents"))
+		require.NoError(t, reconciler.Update(t.Context(), route))
+
+		reconciler.setRouteConditions(t.Context(), deployment, deployment.Spec.OpenWebUI.Gateway, key.Name, conditions)
+		assert.True(t, meta.IsStatusConditionTrue(*conditions, llmgeeperiov1alpha1.ConditionRouteAccepted))
+		resolvedRefs := meta.FindStatusCondition(*conditions, llmgeeperiov1alpha1.ConditionRouteResolvedRefs)
+		assert.Equal(t, metav1.ConditionFalse, resolvedRefs.Status)
+		assert.Equal(t, "BackendNotFound", resolvedRefs.Reason)
+		assert.Equal(t, "Gateway internal: service not found", resolvedRefs.Message)
+
+		// Changing the spec patches the existing route
+		deployment.Spec.OpenWebUI.Gateway.Paths = []string{"/chat"}
+		require.NoError(t, reconciler.createOrUpdateHTTPRoute(t.Context(), reconciler.buildOpenWebUIHTTPRoute(deployment)))
+		require.NoError(t, reconciler.Get(t.Context(), key, route))
+		rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+		path, _, _ := unstructured.NestedString(rules[0].(map[string]interface{})["matches"].([]interface{})[0].(map[string]interface{}), "path", "value")
+		assert.Equal(t, "/chat", path)
+
+		// Removing the gateway removes the conditions
+		reconciler.setRouteConditions(t.Context(), deployment, nil, key.Name, conditions)
+		assert.Empty(t, *conditions)
+	})
+
+	t.Run("should replace its annotations and remove the route with the gateway", func(t *testing.T) {
+		deployment := newHTTPRouteTestDeployment()
+		deployment.Spec.OpenWebUI.Gateway.Annotations = map[string]string{"example.com/tier": "gold"}
+		mapper := meta.NewDefaultRESTMapper(nil)
+		mapper.Add(httpRouteGVK, meta.RESTScopeNamespace)
+		reconciler := &LMDeploymentReconciler{
+			Client: fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).Build(),
+			Scheme: scheme,
+		}
+		key := types.NamespacedName{Name: deployment.GetOpenWebUIHTTPRouteName(), Namespace: "default"}
+		require.NoError(t, reconciler.createOrUpdateHTTPRoute(t.Context(), reconciler.buildOpenWebUIHTTPRoute(deployment)))
+
+		deployment.Spec.OpenWebUI.Gateway.Annotations = map[string]string{"example.com/team": "chat"}
+		require.NoError(t, reconciler.createOrUpdateHTTPRoute(t.Context(), reconciler.buildOpenWebUIHTTPRoute(deployment)))
+		route := newHTTPRoute()
+		require.NoError(t, reconciler.Get(t.Context(), key, route))
+		assert.NotContains(t, route.GetAnnotations(), "example.com/tier")
+		assert.Equal(t, "chat", route.GetAnnotations()["example.com/team"])
+
+		require.NoError(t, reconciler.deleteHTTPRoute(t.Context(), deployment, key.Name))
+		assert.True(t, errors.IsNotFound(reconciler.Get(t.Context(), key, newHTTPRoute())))
+	})
+}
+
+func TestGatewayDuration(t *testing.T) {
+	assert.Equal(t, "0s", gatewayDuration(0))
+	assert.Equal(t, "1m30s", gatewayDuration(90*time.Second))
+	assert.Equal(t, "1s500ms", gatewayDuration(1500*time.Millisecond))
+	assert.Equal(t, "2h5m", gatewayDuration(2*time.Hour+5*time.Minute))
+}
@@ -0,0 +1,265 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+	"github.com/geeper-io/llm-operator/internal/registry"
+)
+
+// imageResolveRetryInterval is how long to wait before retrying images that could not be resolved
+const imageResolveRetryInterval = time.Minute
+
+// defaultRegistryClient resolves digests when the reconciler is not configured with a registry client
+var defaultRegistryClient = registry.NewClient(registry.Options{})
+
+// registryClient returns the registry client used to resolve image digests
+func (r *LMDeploymentReconciler) registryClient() registry.Client {
+	if r.Registry == nil {
+		return defaultRegistryClient
+	}
+	return r.Registry
+}
+
+// buildWorkloadDeployments builds the deployments of all enabled components without applying them
+func (r *LMDeploymentReconciler) buildWorkloadDeployments(deployment *llmgeeperiov1alpha1.LMDeployment) []*appsv1.Deployment {
+	var deployments []*appsv1.Deployment
+	if deployment.Spec.VLLM.Enabled {
+		for _, modelSpec := range deployment.Spec.VLLM.Models {
+			stable, canary := r.buildVLLMModelDeployments(deployment, modelSpec)
+			deployments = append(deployments, stable)
+			if canary != nil {
+				deployments = append(deployments, canary)
+			}
+			if modelSpec.IdleTimeout != nil {
+				deployments = append(deployments, r.buildVLLMActivatorDeployment(deployment, modelSpec))
+			}
+		}
+		deployments = append(deployments, r.buildVLLMRouterDeployment(deployment))
+	}
+	if deployment.Spec.Ollama.Enabled {
+		deployments = append(deployments, r.buildOllamaDeployment(deployment))
+	}
+	if deployment.Spec.OpenWebUI.Enabled {
+		deployments = append(deployments, r.buildOpenWebUIDeployment(deployment))
+		if redisDeployed(deployment) {
+			// The StatefulSet of sentinel mode runs the same image
+			deployments = append(deployments, r.buildRedisDeployment(deployment))
+		}
+		if postgresDeployed(deployment) {
+			deployments = append(deployments, r.buildPostgresDeployment(deployment))
+		}
+		if qdrantDeployed(deployment) {
+			deployments = append(deployments, r.buildQdrantDeployment(deployment))
+		}
+		if deployment.Spec.OpenWebUI.Pipelines != nil && deployment.Spec.OpenWebUI.Pipelines.Enabled {
+			deployments = append(deployments, r.buildPipelinesDeployment(deployment))
+		}
+	}
+	if deployment.Spec.Tabby.Enabled {
+		deployments = append(deployments, r.buildTabbyDeployment(deployment))
+	}
+	if embeddingsDeployed(deployment) {
+		deployments = append(deployments, r.buildEmbeddingsDeployment(deployment))
+	}
+	return deployments
+}
+
+// workloadImages returns the sorted images used by the containers of all enabled components, with the image pull
+// secrets of the pods running them
+func (r *LMDeploymentReconciler) workloadImages(deployment *llmgeeperiov1alpha1.LMDeployment) ([]string, map[string][]string) {
+	pullSecrets := map[string][]string{}
+	var images []string
+	for _, d := range r.buildWorkloadDeployments(deployment) {
+		podSpec := d.Spec.Template.Spec
+		for _, container := range append(podSpec.InitContainers, podSpec.Containers...) {
+			if container.Image == "" {
+				continue
+			}
+			if _, seen := pullSecrets[container.Image]; !seen {
+				pullSecrets[container.Image] = []string{}
+				images = append(images, container.Image)
+			}
+			for _, secret := range podSpec.ImagePullSecrets {
+				if !slices.Contains(pullSecrets[container.Image], secret.Name) {
+					pullSecrets[container.Image] = append(pullSecrets[container.Image], secret.Name)
+				}
+			}
+		}
+	}
+	sort.Strings(images)
+	return images, pullSecrets
+}
+
+// imageKeychain returns the registry credentials of the image pull secrets, like the kubelet missing secrets are skipped
+func (r *LMDeploymentReconciler) imageKeychain(ctx context.Context, namespace string, pullSecrets []string) (registry.Keychain, error) {
+	var dockerConfigs [][]byte
+	for _, name := range pullSecrets {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, secret); err != nil {
+			if errors.IsNotFound(err) {
+				log.FromContext(ctx).Info("Image pull secret not found", "secret", name)
+				continue
+			}
+			return nil, fmt.Errorf("failed to get image pull secret %s: %w", name, err)
+		}
+		for _, key := range []string{corev1.DockerConfigJsonKey, corev1.DockerConfigKey} {
+			if data, ok := secret.Data[key]; ok {
+				dockerConfigs = append(dockerConfigs, data)
+			}
+		}
+	}
+	keychain, err := registry.NewKeychain(dockerConfigs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image pull secrets: %w", err)
+	}
+	return keychain, nil
+}
+
+// reconcileImageDigests resolves the images of all components to digests when digest pinning is enabled and records
+// them in the status. Digests are kept until they are refreshed through refreshImages or the refresh interval.
+// It returns when the digests should be resolved again.
+func (r *LMDeploymentReconciler) reconcileImageDigests(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment) (time.Duration, error) {
+	logger := log.FromContext(ctx)
+
+	patchHelper, err := patch.NewHelper(deployment, r.Client)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create patch helper: %w", err)
+	}
+
+	policy := deployment.Spec.ImagePolicy
+	if policy == nil || !policy.PinDigests {
+		deployment.Status.Images = nil
+		deployment.Status.ObservedRefreshImages = ""
+		meta.RemoveStatusCondition(&deployment.Status.Conditions, llmgeeperiov1alpha1.ConditionImagesResolved)
+		return 0, patchHelper.Patch(ctx, deployment)
+	}
+
+	resolved := map[string]llmgeeperiov1alpha1.ImageStatus{}
+	for _, image := range deployment.Status.Images {
+		resolved[image.Image] = image
+	}
+	refreshAll := policy.RefreshImages != deployment.Status.ObservedRefreshImages
+	now := time.Now()
+
+	var images []llmgeeperiov1alpha1.ImageStatus
+	var failed []string
+	var requeueAfter time.Duration
+	workloadImages, pullSecrets := r.workloadImages(deployment)
+	for _, image := range workloadImages {
+		// Images configured with a digest are already pinned
+		if strings.Contains(image, "@") {
+			continue
+		}
+
+		current, exists := resolved[image]
+		due := !exists || refreshAll
+		if exists && policy.RefreshInterval != nil {
+			remaining := current.ResolvedAt.Add(policy.RefreshInterval.Duration).Sub(now)
+			if remaining <= 0 {
+				due = true
+			} else if !due && (requeueAfter == 0 || remaining < requeueAfter) {
+				requeueAfter = remaining
+			}
+		}
+
+		if due {
+			keychain, err := r.imageKeychain(ctx, deployment.Namespace, pullSecrets[image])
+			var digest string
+			if err == nil {
+				digest, err = r.registryClient().Digest(ctx, image, keychain)
+			}
+			if err != nil {
+				logger.Error(err, "Failed to resolve image digest", "image", image)
+				failed = append(failed, image)
+			} else {
+				if exists && current.Digest != digest {
+					logger.Info("Image digest changed", "image", image, "from", current.Digest, "to", digest)
+				}
+				current = llmgeeperiov1alpha1.ImageStatus{Image: image, Digest: digest, ResolvedAt: metav1.NewTime(now)}
+				exists = true
+				if policy.RefreshInterval != nil && (requeueAfter == 0 || policy.RefreshInterval.Duration < requeueAfter) {
+					requeueAfter = policy.RefreshInterval.Duration
+				}
+			}
+		}
+
+		// Keep the previous digest when resolving fails so the running build is not replaced
+		if exists {
+			images = append(images, current)
+		}
+	}
+	deployment.Status.Images = images
+
+	condition := metav1.Condition{
+		Type:               llmgeeperiov1alpha1.ConditionImagesResolved,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Resolved",
+		Message:            "All images are pinned to digests",
+		ObservedGeneration: deployment.Generation,
+	}
+	if len(failed) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "ResolveFailed"
+		condition.Message = fmt.Sprintf("Failed to resolve digests of %s", strings.Join(failed, ", "))
+		if requeueAfter == 0 || imageResolveRetryInterval < requeueAfter {
+			requeueAfter = imageResolveRetryInterval
+		}
+	} else {
+		deployment.Status.ObservedRefreshImages = policy.RefreshImages
+	}
+	meta.SetStatusCondition(&deployment.Status.Conditions, condition)
+
+	return requeueAfter, patchHelper.Patch(ctx, deployment)
+}
+
+// pinImageDigests replaces the image tags in the pod template of a generated workload with the digests recorded in the status
+func (r *LMDeploymentReconciler) pinImageDigests(deployment *llmgeeperiov1alpha1.LMDeployment, template *corev1.PodTemplateSpec) {
+	if deployment.Spec.ImagePolicy == nil || !deployment.Spec.ImagePolicy.PinDigests {
+		return
+	}
+
+	digests := map[string]string{}
+	for _, image := range deployment.Status.Images {
+		digests[image.Image] = image.Digest
+	}
+
+	podSpec := &template.Spec
+	for _, containers := range [][]corev1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for i := range containers {
+			if digest := digests[containers[i].Image]; digest != "" {
+				containers[i].Image = containers[i].Image + "@" + digest
+			}
+		}
+	}
+}
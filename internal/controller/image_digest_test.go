@@ -0,0 +1,184 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+	"github.com/geeper-io/llm-operator/internal/registry"
+)
+
+// fakeRegistry resolves images from a static map
+type fakeRegistry struct {
+	digests   map[string]string
+	calls     int
+	keychains map[string]registry.Keychain
+}
+
+func (f *fakeRegistry) Digest(_ context.Context, image string, keychain registry.Keychain) (string, error) {
+	f.calls++
+	if f.keychains == nil {
+		f.keychains = map[string]registry.Keychain{}
+	}
+	f.keychains[image] = keychain
+	digest, ok := f.digests[image]
+	if !ok {
+		return "", fmt.Errorf("manifest unknown: %s", image)
+	}
+	return digest, nil
+}
+
+func TestImageDigests(t *testing.T) {
+	scheme := newTestScheme(t)
+	deployment := &llmgeeperiov1alpha1.LMDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "default"},
+		Spec: llmgeeperiov1alpha1.LMDeploymentSpec{
+			Tabby: llmgeeperiov1alpha1.TabbySpec{
+				Enabled: true,
+				Image:   "tabbyml/tabby:latest",
+			},
+			ImagePolicy: &llmgeeperiov1alpha1.ImagePolicySpec{PinDigests: true},
+		},
+	}
+	registry := &fakeRegistry{digests: map[string]string{
+		"tabbyml/tabby:latest": "sha256:aaa",
+		"busybox:1.35":         "sha256:bbb",
+	}}
+	reconciler := &LMDeploymentReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment).WithStatusSubresource(deployment).Build(),
+		Scheme:   scheme,
+		Registry: registry,
+	}
+
+	t.Run("should resolve every image and pin the digests", func(t *testing.T) {
+		requeueAfter, err := reconciler.reconcileImageDigests(t.Context(), deployment)
+		require.NoError(t, err)
+		assert.Zero(t, requeueAfter)
+		assert.Equal(t, 2, registry.calls)
+
+		require.Len(t, deployment.Status.Images, 2)
+		assert.Equal(t, "busybox:1.35", deployment.Status.Images[0].Image)
+		assert.Equal(t, "sha256:bbb", deployment.Status.Images[0].Digest)
+		assert.True(t, meta.IsStatusConditionTrue(deployment.Status.Conditions, llmgeeperiov1alpha1.ConditionImagesResolved))
+
+		tabby := reconciler.buildTabbyDeployment(deployment)
+		reconciler.pinImageDigests(deployment, &tabby.Spec.Template)
+		assert.Equal(t, "tabbyml/tabby:latest@sha256:aaa", tabby.Spec.Template.Spec.Containers[0].Image)
+		assert.Equal(t, "busybox:1.35@sha256:bbb", tabby.Spec.Template.Spec.InitContainers[0].Image)
+	})
+
+	t.Run("should keep digests until a refresh is requested", func(t *testing.T) {
+		registry.digests["tabbyml/tabby:latest"] = "sha256:ccc"
+
+		_, err := reconciler.reconcileImageDigests(t.Context(), deployment)
+		require.NoError(t, err)
+		assert.Equal(t, 2, registry.calls, "digests are not resolved again")
+		assert.Equal(t, "sha256:aaa", deployment.Status.Images[1].Digest)
+
+		deployment.Spec.ImagePolicy.RefreshImages = "2025-01-01"
+		_, err = reconciler.reconcileImageDigests(t.Context(), deployment)
+		require.NoError(t, err)
+		assert.Equal(t, "sha256:ccc", deployment.Status.Images[1].Digest)
+		assert.Equal(t, "2025-01-01", deployment.Status.ObservedRefreshImages)
+	})
+
+	t.Run("should refresh digests after the refresh interval", func(t *testing.T) {
+		deployment.Spec.ImagePolicy.RefreshInterval = &metav1.Duration{Duration: time.Hour}
+		requeueAfter, err := reconciler.reconcileImageDigests(t.Context(), deployment)
+		require.NoError(t, err)
+		assert.Greater(t, requeueAfter, 59*time.Minute)
+
+		registry.digests["tabbyml/tabby:latest"] = "sha256:ddd"
+		deployment.Status.Images[1].ResolvedAt = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+		_, err = reconciler.reconcileImageDigests(t.Context(), deployment)
+		require.NoError(t, err)
+		assert.Equal(t, "sha256:ddd", deployment.Status.Images[1].Digest)
+	})
+
+	t.Run("should keep the previous digest when resolving fails", func(t *testing.T) {
+		delete(registry.digests, "tabbyml/tabby:latest")
+		deployment.Spec.ImagePolicy.RefreshImages = "2025-01-02"
+
+		requeueAfter, err := reconciler.reconcileImageDigests(t.Context(), deployment)
+		require.NoError(t, err)
+		assert.Equal(t, imageResolveRetryInterval, requeueAfter)
+		assert.Equal(t, "sha256:ddd", deployment.Status.Images[1].Digest)
+		assert.True(t, meta.IsStatusConditionFalse(deployment.Status.Conditions, llmgeeperiov1alpha1.ConditionImagesResolved))
+		assert.Equal(t, "2025-01-01", deployment.Status.ObservedRefreshImages, "refresh is retried")
+	})
+
+	t.Run("should clear the status when pinning is disabled", func(t *testing.T) {
+		deployment.Spec.ImagePolicy = nil
+		_, err := reconciler.reconcileImageDigests(t.Context(), deployment)
+		require.NoError(t, err)
+		assert.Empty(t, deployment.Status.Images)
+		assert.Nil(t, meta.FindStatusCondition(deployment.Status.Conditions, llmgeeperiov1alpha1.ConditionImagesResolved))
+
+		tabby := reconciler.buildTabbyDeployment(deployment)
+		reconciler.pinImageDigests(deployment, &tabby.Spec.Template)
+		assert.Equal(t, "tabbyml/tabby:latest", tabby.Spec.Template.Spec.Containers[0].Image)
+	})
+}
+
+func TestImageDigests_PullSecrets(t *testing.T) {
+	scheme := newTestScheme(t)
+	deployment := &llmgeeperiov1alpha1.LMDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "default"},
+		Spec: llmgeeperiov1alpha1.LMDeploymentSpec{
+			Tabby: llmgeeperiov1alpha1.TabbySpec{
+				Enabled: true,
+				Image:   "registry.example.com/ml/tabby:latest",
+				PodTemplateOverrides: llmgeeperiov1alpha1.PodTemplateOverrides{
+					ImagePullSecrets: []corev1.LocalObjectReference{{Name: "missing"}, {Name: "registry"}},
+				},
+			},
+			ImagePolicy: &llmgeeperiov1alpha1.ImagePolicySpec{PinDigests: true},
+		},
+	}
+	pullSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "default"},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: []byte(`{"auths":{"registry.example.com":{"username":"robot","password":"secret"}}}`),
+		},
+	}
+	resolver := &fakeRegistry{digests: map[string]string{
+		"registry.example.com/ml/tabby:latest": "sha256:aaa",
+		"busybox:1.35":                         "sha256:bbb",
+	}}
+	reconciler := &LMDeploymentReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment, pullSecret).WithStatusSubresource(deployment).Build(),
+		Scheme:   scheme,
+		Registry: resolver,
+	}
+
+	_, err := reconciler.reconcileImageDigests(t.Context(), deployment)
+	require.NoError(t, err)
+	assert.True(t, meta.IsStatusConditionTrue(deployment.Status.Conditions, llmgeeperiov1alpha1.ConditionImagesResolved))
+	assert.Equal(t, registry.Keychain{"registry.example.com": {Username: "robot", Password: "secret"}}, resolver.keychains["registry.example.com/ml/tabby:latest"])
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sort"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+const (
+	// certManagerIssuerAnnotation requests a certificate from a namespaced cert-manager Issuer
+	certManagerIssuerAnnotation = "cert-manager.io/issuer"
+
+	// certManagerClusterIssuerAnnotation requests a certificate from a cert-manager ClusterIssuer
+	certManagerClusterIssuerAnnotation = "cert-manager.io/cluster-issuer"
+)
+
+// buildIngress builds an ingress routing the host and paths of an IngressSpec to a component service
+func (r *LMDeploymentReconciler) buildIngress(deployment *llmgeeperiov1alpha1.LMDeployment, name string, labels map[string]string, spec llmgeeperiov1alpha1.IngressSpec, host, serviceName string, servicePort int32) *networkingv1.Ingress {
+	pathType := networkingv1.PathTypePrefix
+	if spec.PathType != "" {
+		pathType = networkingv1.PathType(spec.PathType)
+	}
+	paths := spec.Paths
+	if len(paths) == 0 {
+		paths = []string{"/"}
+	}
+
+	httpPaths := make([]networkingv1.HTTPIngressPath, 0, len(paths))
+	for _, path := range paths {
+		httpPaths = append(httpPaths, networkingv1.HTTPIngressPath{
+			Path:     path,
+			PathType: &pathType,
+			Backend: networkingv1.IngressBackend{
+				Service: &networkingv1.IngressServiceBackend{
+					Name: serviceName,
+					Port: networkingv1.ServiceBackendPort{
+						Number: servicePort,
+					},
+				},
+			},
+		})
+	}
+
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   deployment.Namespace,
+			Labels:      labels,
+			Annotations: mergeStringMaps(spec.Annotations),
+		},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: spec.IngressClassName,
+			Rules: []networkingv1.IngressRule{
+				{
+					Host: host,
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: httpPaths,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if tls := spec.TLS; tls != nil {
+		secretName := tls.SecretName
+		if tls.Issuer != nil {
+			// cert-manager's ingress-shim creates the Certificate for annotated ingresses
+			annotation := certManagerIssuerAnnotation
+			if tls.Issuer.Kind == "ClusterIssuer" {
+				annotation = certManagerClusterIssuerAnnotation
+			}
+			ingress.Annotations = mergeStringMaps(ingress.Annotations, map[string]string{annotation: tls.Issuer.Name})
+			if secretName == "" {
+				secretName = name + "-tls"
+			}
+		}
+		ingress.Spec.TLS = []networkingv1.IngressTLS{
+			{
+				Hosts:      []string{host},
+				SecretName: secretName,
+			},
+		}
+	}
+
+	// Set owner reference
+	_ = controllerutil.SetControllerReference(deployment, ingress, r.Scheme)
+	return ingress
+}
+
+// applyManagedAnnotations returns the annotations of an existing object updated to the desired ones.
+// Annotations set by others are kept, the ones the operator set before and no longer desires are removed.
+func applyManagedAnnotations(existing, desired map[string]string) map[string]string {
+	annotations := map[string]string{}
+	for key, value := range existing {
+		annotations[key] = value
+	}
+	for _, key := range strings.Split(existing[llmgeeperiov1alpha1.ManagedAnnotationsAnnotation], ",") {
+		delete(annotations, key)
+	}
+	delete(annotations, llmgeeperiov1alpha1.ManagedAnnotationsAnnotation)
+
+	keys := make([]string, 0, len(desired))
+	for key, value := range desired {
+		annotations[key] = value
+		keys = append(keys, key)
+	}
+	if len(keys) > 0 {
+		sort.Strings(keys)
+		annotations[llmgeeperiov1alpha1.ManagedAnnotationsAnnotation] = strings.Join(keys, ",")
+	}
+	if len(annotations) == 0 {
+		return nil
+	}
+	return annotations
+}
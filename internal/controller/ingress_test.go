@@ -0,0 +1,164 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+func newIngressTestDeployment() *llmgeeperiov1alpha1.LMDeployment {
+	return &llmgeeperiov1alpha1.LMDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "default"},
+		Spec: llmgeeperiov1alpha1.LMDeploymentSpec{
+			OpenWebUI: llmgeeperiov1alpha1.OpenWebUISpec{
+				Enabled: true,
+				Service: llmgeeperiov1alpha1.ServiceSpec{Port: 8080},
+				Ingress: llmgeeperiov1alpha1.IngressSpec{
+					Host:        "chat.example.com",
+					Annotations: map[string]string{"nginx.ingress.kubernetes.io/proxy-body-size": "50m"},
+				},
+			},
+		},
+	}
+}
+
+func openWebUIEnv(t *testing.T, reconciler *LMDeploymentReconciler, deployment *llmgeeperiov1alpha1.LMDeployment) map[string]string {
+	openwebui := reconciler.buildOpenWebUIDeployment(deployment)
+	require.NotEmpty(t, openwebui.Spec.Template.Spec.Containers)
+
+	env := map[string]string{}
+	for _, envVar := range openwebui.Spec.Template.Spec.Containers[0].Env {
+		env[envVar.Name] = envVar.Value
+	}
+	return env
+}
+
+func TestIngress_Defaults(t *testing.T) {
+	reconciler := &LMDeploymentReconciler{Scheme: newTestScheme(t)}
+	deployment := newIngressTestDeployment()
+
+	ingress := reconciler.buildOpenWebUIIngress(deployment)
+	assert.Nil(t, ingress.Spec.IngressClassName)
+	assert.Empty(t, ingress.Spec.TLS)
+	assert.Equal(t, "50m", ingress.Annotations["nginx.ingress.kubernetes.io/proxy-body-size"])
+
+	require.Len(t, ingress.Spec.Rules, 1)
+	paths := ingress.Spec.Rules[0].HTTP.Paths
+	require.Len(t, paths, 1)
+	assert.Equal(t, "/", paths[0].Path)
+	assert.Equal(t, networkingv1.PathTypePrefix, *paths[0].PathType)
+
+	env := openWebUIEnv(t, reconciler, deployment)
+	assert.Equal(t, "http://chat.example.com", env["WEBUI_HOST"])
+	assert.Equal(t, "http://chat.example.com", env["CORS_ALLOW_ORIGIN"])
+}
+
+func TestIngress_TLSAndPaths(t *testing.T) {
+	reconciler := &LMDeploymentReconciler{Scheme: newTestScheme(t)}
+
+	t.Run("should use the given secret and class", func(t *testing.T) {
+		deployment := newIngressTestDeployment()
+		deployment.Spec.OpenWebUI.Ingress.IngressClassName = ptr.To("nginx")
+		deployment.Spec.OpenWebUI.Ingress.TLS = &llmgeeperiov1alpha1.IngressTLSSpec{SecretName: "chat-tls"}
+		deployment.Spec.OpenWebUI.Ingress.Paths = []string{"/chat", "/api"}
+		deployment.Spec.OpenWebUI.Ingress.PathType = "Exact"
+
+		ingress := reconciler.buildOpenWebUIIngress(deployment)
+		assert.Equal(t, "nginx", *ingress.Spec.IngressClassName)
+		assert.Equal(t, []networkingv1.IngressTLS{{Hosts: []string{"chat.example.com"}, SecretName: "chat-tls"}}, ingress.Spec.TLS)
+
+		paths := ingress.Spec.Rules[0].HTTP.Paths
+		require.Len(t, paths, 2)
+		assert.Equal(t, "/api", paths[1].Path)
+		assert.Equal(t, networkingv1.PathTypeExact, *paths[1].PathType)
+
+		env := openWebUIEnv(t, reconciler, deployment)
+		assert.Equal(t, "https://chat.example.com", env["WEBUI_HOST"])
+	})
+
+	t.Run("should request the certificate from cert-manager", func(t *testing.T) {
+		deployment := newIngressTestDeployment()
+		deployment.Spec.OpenWebUI.Ingress.TLS = &llmgeeperiov1alpha1.IngressTLSSpec{
+			Issuer: &llmgeeperiov1alpha1.CertManagerIssuerRef{Name: "letsencrypt", Kind: "ClusterIssuer"},
+		}
+
+		ingress := reconciler.buildOpenWebUIIngress(deployment)
+		assert.Equal(t, "letsencrypt", ingress.Annotations[certManagerClusterIssuerAnnotation])
+		assert.Equal(t, "50m", ingress.Annotations["nginx.ingress.kubernetes.io/proxy-body-size"])
+		assert.Equal(t, deployment.GetOpenWebUIIngressName()+"-tls", ingress.Spec.TLS[0].SecretName)
+		assert.NotContains(t, deployment.Spec.OpenWebUI.Ingress.Annotations, certManagerClusterIssuerAnnotation, "spec annotations are not mutated")
+	})
+
+	t.Run("should apply to the Tabby ingress", func(t *testing.T) {
+		deployment := newIngressTestDeployment()
+		deployment.Spec.Tabby = llmgeeperiov1alpha1.TabbySpec{
+			Enabled: true,
+			Service: llmgeeperiov1alpha1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, Port: 8080},
+			Ingress: llmgeeperiov1alpha1.IngressSpec{
+				Host: "tabby.example.com",
+				TLS: &llmgeeperiov1alpha1.IngressTLSSpec{
+					Issuer: &llmgeeperiov1alpha1.CertManagerIssuerRef{Name: "internal-ca", Kind: "Issuer"},
+				},
+			},
+		}
+
+		ingress := reconciler.buildTabbyIngress(deployment)
+		assert.Equal(t, "internal-ca", ingress.Annotations[certManagerIssuerAnnotation])
+		assert.Equal(t, []string{"tabby.example.com"}, ingress.Spec.TLS[0].Hosts)
+	})
+}
+
+func TestIngress_ManagedAnnotations(t *testing.T) {
+	ctx := context.Background()
+	scheme := newTestScheme(t)
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	reconciler := &LMDeploymentReconciler{Client: c, Scheme: scheme}
+	deployment := newIngressTestDeployment()
+	deployment.Spec.OpenWebUI.Ingress.TLS = &llmgeeperiov1alpha1.IngressTLSSpec{
+		Issuer: &llmgeeperiov1alpha1.CertManagerIssuerRef{Name: "letsencrypt", Kind: "Issuer"},
+	}
+	key := types.NamespacedName{Name: deployment.GetOpenWebUIIngressName(), Namespace: "default"}
+	require.NoError(t, reconciler.createOrUpdateIngress(ctx, reconciler.buildOpenWebUIIngress(deployment)))
+
+	// Annotations of others, e.g. added by cert-manager or kubectl, are kept
+	ingress := &networkingv1.Ingress{}
+	require.NoError(t, c.Get(ctx, key, ingress))
+	ingress.Annotations["example.com/owner"] = "team-a"
+	require.NoError(t, c.Update(ctx, ingress))
+
+	deployment.Spec.OpenWebUI.Ingress.TLS.Issuer.Kind = "ClusterIssuer"
+	deployment.Spec.OpenWebUI.Ingress.Annotations = nil
+	require.NoError(t, reconciler.createOrUpdateIngress(ctx, reconciler.buildOpenWebUIIngress(deployment)))
+
+	require.NoError(t, c.Get(ctx, key, ingress))
+	assert.NotContains(t, ingress.Annotations, certManagerIssuerAnnotation, "the previous issuer is removed")
+	assert.NotContains(t, ingress.Annotations, "nginx.ingress.kubernetes.io/proxy-body-size", "removed spec annotations are removed")
+	assert.Equal(t, "letsencrypt", ingress.Annotations[certManagerClusterIssuerAnnotation])
+	assert.Equal(t, "team-a", ingress.Annotations["example.com/owner"])
+}
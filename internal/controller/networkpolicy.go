@@ -0,0 +1,375 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+// defaultIngressNamespace is the ingress controller namespace allowed to reach exposed components by default
+const defaultIngressNamespace = "ingress-nginx"
+
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
+
+// reconcileNetworkPolicies creates a NetworkPolicy for every enabled component and removes the ones no longer needed
+func (r *LMDeploymentReconciler) reconcileNetworkPolicies(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment) error {
+	var policies []*networkingv1.NetworkPolicy
+	if spec := deployment.Spec.NetworkPolicy; spec != nil && spec.Enabled {
+		policies = r.buildNetworkPolicies(deployment)
+	}
+
+	desired := map[string]bool{}
+	for _, policy := range policies {
+		desired[policy.Name] = true
+		if err := r.createOrUpdateNetworkPolicy(ctx, policy); err != nil {
+			return err
+		}
+	}
+
+	existing := &networkingv1.NetworkPolicyList{}
+	if err := r.List(ctx, existing, client.InNamespace(deployment.Namespace), client.MatchingLabels{"llm-deployment": deployment.Name}); err != nil {
+		return fmt.Errorf("failed to list network policies: %w", err)
+	}
+	for i := range existing.Items {
+		policy := &existing.Items[i]
+		if desired[policy.Name] || !metav1.IsControlledBy(policy, deployment) {
+			continue
+		}
+		if err := r.Delete(ctx, policy); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete network policy %s: %w", policy.Name, err)
+		}
+	}
+	return nil
+}
+
+// buildNetworkPolicies builds the NetworkPolicies of all enabled components.
+// Only ingress is restricted, the components still need egress to registries and model hubs.
+func (r *LMDeploymentReconciler) buildNetworkPolicies(deployment *llmgeeperiov1alpha1.LMDeployment) []*networkingv1.NetworkPolicy {
+	spec := deployment.Spec.NetworkPolicy
+	openwebui := r.componentPeer(deployment, "openwebui")
+	tabby := r.componentPeer(deployment, "tabby")
+	router := r.componentPeer(deployment, "vllm-router")
+	// Benchmarks of this deployment send their requests to the Ollama API or the vLLM router
+	benchmark := r.componentPeer(deployment, "benchmark")
+	ingressPeers := ingressNamespacePeers(spec.IngressNamespaces)
+	// Prometheus scrapes the vLLM metrics from the serving port
+	monitoringPeers := monitoringPeers(spec.Monitoring)
+
+	var policies []*networkingv1.NetworkPolicy
+	if deployment.Spec.Ollama.Enabled {
+		service := r.buildOllamaService(deployment)
+		rules := []networkingv1.NetworkPolicyIngressRule{
+			newIngressRule(servicePorts(service, "http"), openwebui, tabby, benchmark),
+		}
+		if deployment.Spec.Ollama.Ingress.Host != "" || deployment.Spec.Ollama.Gateway != nil {
+			// External clients only reach the authenticating proxy
+			rules = append(rules, newIngressRule(servicePorts(service, "auth"), ingressPeers...))
+		}
+		if deployment.Spec.Ollama.ExternalService() {
+			// The NodePort or LoadBalancer service publishes the authenticating proxy to anyone
+			rules = append(rules, networkingv1.NetworkPolicyIngressRule{Ports: servicePorts(service, "auth")})
+		}
+		policies = append(policies, r.buildNetworkPolicy(deployment, "ollama", service, rules, spec.ExtraPeers.Ollama))
+	}
+
+	if deployment.Spec.VLLM.Enabled {
+		// Every model server gets its own policy, the router and the front-ends talk to them
+		for _, modelSpec := range deployment.Spec.VLLM.Models {
+			// Smoke tests reach the model pods directly, behind the activator through the backend service
+			var smokeTest []networkingv1.NetworkPolicyPeer
+			if modelSpec.SmokeTest != nil {
+				smokeTest = append(smokeTest, networkingv1.NetworkPolicyPeer{
+					PodSelector: &metav1.LabelSelector{MatchLabels: smokeTestLabels(deployment, modelSpec.Name)},
+				})
+			}
+
+			service := r.buildVLLMModelService(deployment, modelSpec)
+			peers := []networkingv1.NetworkPolicyPeer{router, openwebui, tabby}
+			if modelSpec.IdleTimeout == nil {
+				peers = append(peers, smokeTest...)
+			}
+			rules := []networkingv1.NetworkPolicyIngressRule{newIngressRule(servicePorts(service), peers...)}
+			if modelSpec.IdleTimeout == nil && len(monitoringPeers) > 0 {
+				rules = append(rules, newIngressRule(servicePorts(service), monitoringPeers...))
+			}
+			policies = append(policies, r.buildNetworkPolicy(deployment, "vllm-"+modelSpec.Name, service, rules, spec.ExtraPeers.VLLM))
+
+			// The model service selects the activator of a model with an idle timeout, only the activator reaches the model pods
+			if modelSpec.IdleTimeout != nil {
+				activator := networkingv1.NetworkPolicyPeer{
+					PodSelector: &metav1.LabelSelector{MatchLabels: vllmActivatorLabels(deployment, modelSpec)},
+				}
+				backend := r.buildVLLMModelBackendService(deployment, modelSpec)
+				rules := []networkingv1.NetworkPolicyIngressRule{newIngressRule(servicePorts(backend), append([]networkingv1.NetworkPolicyPeer{activator}, smokeTest...)...)}
+				if len(monitoringPeers) > 0 {
+					rules = append(rules, newIngressRule(servicePorts(backend), monitoringPeers...))
+				}
+				policies = append(policies, r.buildNetworkPolicy(deployment, "vllm-"+modelSpec.Name+"-backend", backend, rules, spec.ExtraPeers.VLLM))
+			}
+
+			// The canary pods serve the same clients as the stable ones, and its smoke test
+			if canaryRunning(deployment, modelSpec) {
+				smokeTest := networkingv1.NetworkPolicyPeer{
+					PodSelector: &metav1.LabelSelector{MatchLabels: smokeTestLabels(deployment, modelSpec.Name+"-canary")},
+				}
+				canary := r.buildVLLMCanaryService(deployment, modelSpec)
+				rules := []networkingv1.NetworkPolicyIngressRule{newIngressRule(servicePorts(canary), router, openwebui, tabby, smokeTest)}
+				if len(monitoringPeers) > 0 {
+					rules = append(rules, newIngressRule(servicePorts(canary), monitoringPeers...))
+				}
+				policies = append(policies, r.buildNetworkPolicy(deployment, "vllm-"+modelSpec.Name+"-canary", canary, rules, spec.ExtraPeers.VLLM))
+			}
+		}
+
+		service := r.buildVLLMRouterService(deployment)
+		rules := []networkingv1.NetworkPolicyIngressRule{newIngressRule(servicePorts(service), openwebui, tabby, benchmark)}
+		if deployment.Spec.VLLM.Router.Ingress.Host != "" || deployment.Spec.VLLM.Router.Gateway != nil {
+			rules = append(rules, newIngressRule(servicePorts(service), ingressPeers...))
+		}
+		if len(monitoringPeers) > 0 {
+			rules = append(rules, newIngressRule(servicePorts(service), monitoringPeers...))
+		}
+		policies = append(policies, r.buildNetworkPolicy(deployment, "vllm-router", service, rules, spec.ExtraPeers.VLLMRouter))
+	}
+
+	if deployment.Spec.OpenWebUI.Enabled {
+		service := r.buildOpenWebUIService(deployment)
+		var rules []networkingv1.NetworkPolicyIngressRule
+		if deployment.Spec.OpenWebUI.Ingress.Host != "" || deployment.Spec.OpenWebUI.Gateway != nil {
+			rules = append(rules, newIngressRule(servicePorts(service), ingressPeers...))
+		}
+		policies = append(policies, r.buildNetworkPolicy(deployment, "openwebui", service, rules, spec.ExtraPeers.OpenWebUI))
+
+		if deployment.Spec.OpenWebUI.Pipelines != nil && deployment.Spec.OpenWebUI.Pipelines.Enabled {
+			service := r.buildPipelinesService(deployment)
+			rules := []networkingv1.NetworkPolicyIngressRule{newIngressRule(servicePorts(service), openwebui)}
+			policies = append(policies, r.buildNetworkPolicy(deployment, "pipelines", service, rules, spec.ExtraPeers.Pipelines))
+		}
+
+		if redisDeployed(deployment) {
+			service := r.buildRedisService(deployment)
+			peers := []networkingv1.NetworkPolicyPeer{openwebui}
+			if redisSentinelMode(deployment) {
+				// The pods replicate from the master and the Sentinels talk to each other, clients reach the master
+				// through the headless service publishing every port
+				service = r.buildRedisHeadlessService(deployment)
+				peers = append(peers, r.componentPeer(deployment, "redis"))
+			}
+			rules := []networkingv1.NetworkPolicyIngressRule{newIngressRule(servicePorts(service), peers...)}
+			if redisSentinelMode(deployment) && r.OperatorNamespace != "" {
+				// The operator queries Sentinel for the RedisReady condition
+				rules = append(rules, newIngressRule(servicePorts(service, "sentinel"), r.operatorPeer()))
+			}
+			policies = append(policies, r.buildNetworkPolicy(deployment, "redis", service, rules, spec.ExtraPeers.Redis))
+		}
+
+		if postgresDeployed(deployment) {
+			service := r.buildPostgresService(deployment)
+			rules := []networkingv1.NetworkPolicyIngressRule{newIngressRule(servicePorts(service), openwebui)}
+			policies = append(policies, r.buildNetworkPolicy(deployment, "postgres", service, rules, spec.ExtraPeers.Postgres))
+		}
+
+		if qdrantDeployed(deployment) {
+			service := r.buildQdrantService(deployment)
+			rules := []networkingv1.NetworkPolicyIngressRule{newIngressRule(servicePorts(service), openwebui)}
+			policies = append(policies, r.buildNetworkPolicy(deployment, "qdrant", service, rules, spec.ExtraPeers.Qdrant))
+		}
+	}
+
+	if deployment.Spec.Tabby.Enabled {
+		service := r.buildTabbyService(deployment)
+		var rules []networkingv1.NetworkPolicyIngressRule
+		if deployment.Spec.Tabby.Ingress.Host != "" || deployment.Spec.Tabby.Gateway != nil {
+			rules = append(rules, newIngressRule(servicePorts(service), ingressPeers...))
+		}
+		policies = append(policies, r.buildNetworkPolicy(deployment, "tabby", service, rules, spec.ExtraPeers.Tabby))
+	}
+
+	if embeddingsDeployed(deployment) {
+		service := r.buildEmbeddingsService(deployment)
+		rules := []networkingv1.NetworkPolicyIngressRule{newIngressRule(servicePorts(service), openwebui, tabby)}
+		policies = append(policies, r.buildNetworkPolicy(deployment, "embeddings", service, rules, spec.ExtraPeers.Embeddings))
+	}
+
+	return policies
+}
+
+// buildNetworkPolicy builds the NetworkPolicy of a component selecting the pods behind its service.
+// Services of type NodePort or LoadBalancer stay reachable from anywhere, extra peers may reach every service port.
+// A component without rules only accepts traffic from kubectl port-forward.
+func (r *LMDeploymentReconciler) buildNetworkPolicy(deployment *llmgeeperiov1alpha1.LMDeployment, component string, service *corev1.Service, rules []networkingv1.NetworkPolicyIngressRule, extraPeers []networkingv1.NetworkPolicyPeer) *networkingv1.NetworkPolicy {
+	ports := servicePorts(service)
+	if service.Spec.Type == corev1.ServiceTypeNodePort || service.Spec.Type == corev1.ServiceTypeLoadBalancer {
+		rules = append(rules, networkingv1.NetworkPolicyIngressRule{Ports: ports})
+	}
+	if len(extraPeers) > 0 {
+		rules = append(rules, newIngressRule(ports, extraPeers...))
+	}
+
+	policy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deployment.GetNetworkPolicyName(component),
+			Namespace: deployment.Namespace,
+			Labels: map[string]string{
+				"app":            component,
+				"llm-deployment": deployment.Name,
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: service.Spec.Selector,
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress:     rules,
+		},
+	}
+
+	// Set owner reference
+	_ = controllerutil.SetControllerReference(deployment, policy, r.Scheme)
+	return policy
+}
+
+// componentPeer selects the pods of a component of the deployment
+func (r *LMDeploymentReconciler) componentPeer(deployment *llmgeeperiov1alpha1.LMDeployment, component string) networkingv1.NetworkPolicyPeer {
+	return networkingv1.NetworkPolicyPeer{
+		PodSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"app":            component,
+				"llm-deployment": deployment.Name,
+			},
+		},
+	}
+}
+
+// operatorPeer selects the pods of the operator
+func (r *LMDeploymentReconciler) operatorPeer() networkingv1.NetworkPolicyPeer {
+	return networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{corev1.LabelMetadataName: r.OperatorNamespace},
+		},
+		PodSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"control-plane": "controller-manager"},
+		},
+	}
+}
+
+// ingressNamespacePeers selects the namespaces of the ingress controllers
+func ingressNamespacePeers(namespaces []string) []networkingv1.NetworkPolicyPeer {
+	if len(namespaces) == 0 {
+		namespaces = []string{defaultIngressNamespace}
+	}
+	return []networkingv1.NetworkPolicyPeer{
+		{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{
+						Key:      corev1.LabelMetadataName,
+						Operator: metav1.LabelSelectorOpIn,
+						Values:   namespaces,
+					},
+				},
+			},
+		},
+	}
+}
+
+// monitoringPeers selects the Prometheus pods, none when monitoring is not configured
+func monitoringPeers(monitoring *llmgeeperiov1alpha1.NetworkPolicyMonitoringSpec) []networkingv1.NetworkPolicyPeer {
+	if monitoring == nil || len(monitoring.Namespaces) == 0 {
+		return nil
+	}
+	peer := ingressNamespacePeers(monitoring.Namespaces)[0]
+	peer.PodSelector = monitoring.PodSelector
+	return []networkingv1.NetworkPolicyPeer{peer}
+}
+
+// newIngressRule allows the peers to reach the ports
+func newIngressRule(ports []networkingv1.NetworkPolicyPort, peers ...networkingv1.NetworkPolicyPeer) networkingv1.NetworkPolicyIngressRule {
+	return networkingv1.NetworkPolicyIngressRule{From: peers, Ports: ports}
+}
+
+// servicePorts returns the pod ports behind the named service ports, or behind all ports when no names are given
+func servicePorts(service *corev1.Service, names ...string) []networkingv1.NetworkPolicyPort {
+	var ports []networkingv1.NetworkPolicyPort
+	for _, servicePort := range service.Spec.Ports {
+		if len(names) > 0 && !containsString(names, servicePort.Name) {
+			continue
+		}
+		targetPort := servicePort.TargetPort
+		if targetPort.IntValue() == 0 && targetPort.Type == intstr.Int {
+			targetPort = intstr.FromInt32(servicePort.Port)
+		}
+		protocol := servicePort.Protocol
+		if protocol == "" {
+			protocol = corev1.ProtocolTCP
+		}
+		ports = append(ports, networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &targetPort})
+	}
+	return ports
+}
+
+// containsString checks if a slice contains a string
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// createOrUpdateNetworkPolicy creates or updates a network policy using patch helper to avoid unnecessary reconciliations
+func (r *LMDeploymentReconciler) createOrUpdateNetworkPolicy(ctx context.Context, policy *networkingv1.NetworkPolicy) error {
+	existing := &networkingv1.NetworkPolicy{}
+	err := r.Get(ctx, types.NamespacedName{Name: policy.Name, Namespace: policy.Namespace}, existing)
+	if err != nil && errors.IsNotFound(err) {
+		// Create new network policy
+		if err := r.Create(ctx, policy); err != nil {
+			return err
+		}
+	} else if err == nil {
+		// Update existing network policy using patch helper
+		if !reflect.DeepEqual(existing.Spec, policy.Spec) {
+			patchHelper, err := patch.NewHelper(existing, r.Client)
+			if err != nil {
+				return fmt.Errorf("failed to create patch helper for network policy %s: %w", policy.Name, err)
+			}
+
+			existing.Spec = policy.Spec
+			if err := patchHelper.Patch(ctx, existing); err != nil {
+				return fmt.Errorf("failed to patch network policy %s: %w", policy.Name, err)
+			}
+		}
+	} else {
+		return err
+	}
+	return nil
+}
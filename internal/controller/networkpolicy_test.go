@@ -0,0 +1,201 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+func newNetworkPolicyTestDeployment() *llmgeeperiov1alpha1.LMDeployment {
+	return &llmgeeperiov1alpha1.LMDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "default", UID: "uid"},
+		Spec: llmgeeperiov1alpha1.LMDeploymentSpec{
+			Ollama: llmgeeperiov1alpha1.OllamaSpec{
+				Enabled: true,
+				Service: llmgeeperiov1alpha1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, Port: 11434},
+				Ingress: llmgeeperiov1alpha1.IngressSpec{Host: "ollama.example.com"},
+			},
+			OpenWebUI: llmgeeperiov1alpha1.OpenWebUISpec{
+				Enabled:  true,
+				Replicas: 2,
+				Service:  llmgeeperiov1alpha1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, Port: 8080},
+				Ingress:  llmgeeperiov1alpha1.IngressSpec{Host: "chat.example.com"},
+				Redis: llmgeeperiov1alpha1.RedisSpec{
+					Service: llmgeeperiov1alpha1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, Port: 6379},
+				},
+			},
+			Tabby: llmgeeperiov1alpha1.TabbySpec{
+				Enabled: true,
+				Service: llmgeeperiov1alpha1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, Port: 8080},
+			},
+			NetworkPolicy: &llmgeeperiov1alpha1.NetworkPolicySpec{
+				Enabled:           true,
+				IngressNamespaces: []string{"traefik"},
+				ExtraPeers: llmgeeperiov1alpha1.NetworkPolicyPeers{
+					Redis: []networkingv1.NetworkPolicyPeer{
+						{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "sre"}}},
+					},
+				},
+			},
+		},
+	}
+}
+
+// policiesByName indexes the policies by name
+func policiesByName(policies []*networkingv1.NetworkPolicy) map[string]*networkingv1.NetworkPolicy {
+	byName := map[string]*networkingv1.NetworkPolicy{}
+	for _, policy := range policies {
+		byName[policy.Name] = policy
+	}
+	return byName
+}
+
+func TestNetworkPolicies_Build(t *testing.T) {
+	reconciler := &LMDeploymentReconciler{Scheme: newTestScheme(t)}
+	deployment := newNetworkPolicyTestDeployment()
+
+	policies := policiesByName(reconciler.buildNetworkPolicies(deployment))
+	assert.Len(t, policies, 4)
+	openwebui := reconciler.componentPeer(deployment, "openwebui")
+	traefik := ingressNamespacePeers([]string{"traefik"})[0]
+
+	t.Run("should only let OpenWebUI and the extra peers reach Redis", func(t *testing.T) {
+		redis := policies["test-deployment-redis"]
+		require.NotNil(t, redis)
+		assert.Equal(t, map[string]string{"app": "redis", "llm-deployment": "test-deployment"}, redis.Spec.PodSelector.MatchLabels)
+		assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}, redis.Spec.PolicyTypes)
+		require.Len(t, redis.Spec.Ingress, 2)
+		assert.Equal(t, []networkingv1.NetworkPolicyPeer{openwebui}, redis.Spec.Ingress[0].From)
+		assert.Equal(t, intstr.FromInt32(6379), *redis.Spec.Ingress[0].Ports[0].Port)
+		assert.Equal(t, "sre", redis.Spec.Ingress[1].From[0].NamespaceSelector.MatchLabels["team"])
+	})
+
+	t.Run("should let the operator query Sentinel", func(t *testing.T) {
+		deployment := newNetworkPolicyTestDeployment()
+		deployment.Spec.OpenWebUI.Redis.Mode = llmgeeperiov1alpha1.RedisModeSentinel
+		reconciler := &LMDeploymentReconciler{Scheme: newTestScheme(t), OperatorNamespace: "llm-operator-system"}
+
+		redis := policiesByName(reconciler.buildNetworkPolicies(deployment))["test-deployment-redis"]
+		require.Len(t, redis.Spec.Ingress, 3)
+		assert.Equal(t, []networkingv1.NetworkPolicyPeer{reconciler.operatorPeer()}, redis.Spec.Ingress[1].From)
+		require.Len(t, redis.Spec.Ingress[1].Ports, 1)
+		assert.Equal(t, intstr.FromInt32(redisSentinelPort), *redis.Spec.Ingress[1].Ports[0].Port)
+		assert.Equal(t, "llm-operator-system", redis.Spec.Ingress[1].From[0].NamespaceSelector.MatchLabels[corev1.LabelMetadataName])
+	})
+
+	t.Run("should let Prometheus scrape the vLLM metrics", func(t *testing.T) {
+		deployment := newNetworkPolicyTestDeployment()
+		deployment.Spec.VLLM = llmgeeperiov1alpha1.VLLMSpec{
+			Enabled: true,
+			Models:  []llmgeeperiov1alpha1.VLLMModelSpec{{Name: "llama", Model: "meta-llama/Llama-3.1-8B-Instruct"}},
+		}
+		prometheus := &metav1.LabelSelector{MatchLabels: map[string]string{"app.kubernetes.io/name": "prometheus"}}
+		deployment.Spec.NetworkPolicy.Monitoring = &llmgeeperiov1alpha1.NetworkPolicyMonitoringSpec{
+			Namespaces:  []string{"monitoring"},
+			PodSelector: prometheus,
+		}
+
+		policies := policiesByName(reconciler.buildNetworkPolicies(deployment))
+		for _, name := range []string{"test-deployment-vllm-llama", "test-deployment-vllm-router"} {
+			policy := policies[name]
+			require.NotNil(t, policy, name)
+			scrape := policy.Spec.Ingress[len(policy.Spec.Ingress)-1]
+			require.Len(t, scrape.From, 1, name)
+			assert.Equal(t, []string{"monitoring"}, scrape.From[0].NamespaceSelector.MatchExpressions[0].Values, name)
+			assert.Equal(t, prometheus, scrape.From[0].PodSelector, name)
+			assert.Equal(t, policy.Spec.Ingress[0].Ports, scrape.Ports, "%s is scraped on its serving port", name)
+		}
+		assert.Len(t, policies["test-deployment-ollama"].Spec.Ingress, 2, "Ollama exposes no metrics")
+	})
+
+	t.Run("should route the ingress controller to the Ollama proxy only", func(t *testing.T) {
+		ollama := policies["test-deployment-ollama"]
+		require.Len(t, ollama.Spec.Ingress, 2)
+		assert.Equal(t, []networkingv1.NetworkPolicyPeer{openwebui, reconciler.componentPeer(deployment, "tabby"), reconciler.componentPeer(deployment, "benchmark")}, ollama.Spec.Ingress[0].From)
+		assert.Equal(t, intstr.FromInt32(11434), *ollama.Spec.Ingress[0].Ports[0].Port)
+		assert.Equal(t, []networkingv1.NetworkPolicyPeer{traefik}, ollama.Spec.Ingress[1].From)
+		require.Len(t, ollama.Spec.Ingress[1].Ports, 1)
+		assert.Equal(t, intstr.FromInt32(ollamaAuthProxyPort), *ollama.Spec.Ingress[1].Ports[0].Port)
+	})
+
+	t.Run("should only publish the Ollama proxy through a LoadBalancer service", func(t *testing.T) {
+		deployment := newNetworkPolicyTestDeployment()
+		deployment.Spec.Ollama.Ingress = llmgeeperiov1alpha1.IngressSpec{}
+		deployment.Spec.Ollama.Service.Type = corev1.ServiceTypeLoadBalancer
+
+		ollama := policiesByName(reconciler.buildNetworkPolicies(deployment))["test-deployment-ollama"]
+		require.Len(t, ollama.Spec.Ingress, 2)
+		assert.Empty(t, ollama.Spec.Ingress[1].From)
+		require.Len(t, ollama.Spec.Ingress[1].Ports, 1)
+		assert.Equal(t, intstr.FromInt32(ollamaAuthProxyPort), *ollama.Spec.Ingress[1].Ports[0].Port)
+	})
+
+	t.Run("should allow the ingress controller to reach exposed front-ends", func(t *testing.T) {
+		ui := policies["test-deployment-openwebui"]
+		require.Len(t, ui.Spec.Ingress, 1)
+		assert.Equal(t, []networkingv1.NetworkPolicyPeer{traefik}, ui.Spec.Ingress[0].From)
+	})
+
+	t.Run("should keep LoadBalancer services reachable", func(t *testing.T) {
+		tabby := policies["test-deployment-tabby"]
+		require.Len(t, tabby.Spec.Ingress, 1)
+		assert.Empty(t, tabby.Spec.Ingress[0].From)
+		assert.Equal(t, intstr.FromInt32(8080), *tabby.Spec.Ingress[0].Ports[0].Port)
+	})
+}
+
+func TestNetworkPolicies_Reconcile(t *testing.T) {
+	scheme := newTestScheme(t)
+	deployment := newNetworkPolicyTestDeployment()
+	reconciler := &LMDeploymentReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Scheme: scheme,
+	}
+
+	listPolicies := func() []string {
+		policies := &networkingv1.NetworkPolicyList{}
+		require.NoError(t, reconciler.List(t.Context(), policies, client.InNamespace("default")))
+		var names []string
+		for _, policy := range policies.Items {
+			names = append(names, policy.Name)
+		}
+		return names
+	}
+
+	require.NoError(t, reconciler.reconcileNetworkPolicies(t.Context(), deployment))
+	assert.ElementsMatch(t, []string{"test-deployment-ollama", "test-deployment-openwebui", "test-deployment-redis", "test-deployment-tabby"}, listPolicies())
+
+	// Policies of removed components are deleted
+	deployment.Spec.Tabby.Enabled = false
+	require.NoError(t, reconciler.reconcileNetworkPolicies(t.Context(), deployment))
+	assert.NotContains(t, listPolicies(), "test-deployment-tabby")
+
+	deployment.Spec.NetworkPolicy.Enabled = false
+	require.NoError(t, reconciler.reconcileNetworkPolicies(t.Context(), deployment))
+	assert.Empty(t, listPolicies())
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+const (
+	// ollamaAuthProxyPort is the port the authenticating proxy listens on, the exposed Ollama API is routed to it
+	ollamaAuthProxyPort int32 = 11435
+
+	// ollamaAuthTokenKey is the key of the token in the generated Ollama auth secret
+	ollamaAuthTokenKey = "token"
+)
+
+// validOllamaToken restricts tokens to characters that are safe inside the quoted nginx map keys
+var validOllamaToken = regexp.MustCompile(`^[A-Za-z0-9._~+/=-]+$`)
+
+// ollamaAuthProxyTemplate is the nginx configuration of the proxy, tokens are matched against the Authorization header
+var ollamaAuthProxyTemplate = template.Must(template.New("ollama-auth-proxy").Parse(`map $http_authorization $ollama_authorized {
+    default 0;
+{{- range .Tokens }}
+    "Bearer {{ . }}" 1;
+{{- end }}
+}
+
+server {
+    listen {{ .ListenPort }};
+    client_max_body_size 0;
+
+    location = /healthz {
+        access_log off;
+        return 200;
+    }
+
+    location / {
+        if ($ollama_authorized = 0) {
+            add_header WWW-Authenticate 'Bearer realm="ollama"' always;
+            return 401;
+        }
+
+        proxy_pass http://127.0.0.1:{{ .OllamaPort }};
+        proxy_set_header Authorization "";
+        proxy_http_version 1.1;
+        # Stream generated tokens as they arrive
+        proxy_buffering off;
+        proxy_read_timeout 1h;
+        proxy_send_timeout 1h;
+    }
+}
+`))
+
+// ensureOllamaAuthSecret creates the secret with a generated token unless the tokens come from a user provided secret
+func (r *LMDeploymentReconciler) ensureOllamaAuthSecret(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment) error {
+	if auth := deployment.Spec.Ollama.Auth; auth != nil && auth.TokensSecretRef != nil {
+		return nil
+	}
+
+	existingSecret := &corev1.Secret{}
+	err := r.Get(ctx, client.ObjectKey{Name: deployment.GetOllamaAuthSecretName(), Namespace: deployment.Namespace}, existingSecret)
+	if err == nil {
+		if len(existingSecret.Data[ollamaAuthTokenKey]) > 0 {
+			return nil
+		}
+	} else if !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to get Ollama auth secret: %w", err)
+	}
+
+	token, err := generateSecureSecret(32)
+	if err != nil {
+		return fmt.Errorf("failed to generate Ollama API token: %w", err)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deployment.GetOllamaAuthSecretName(),
+			Namespace: deployment.Namespace,
+			Labels: map[string]string{
+				"app":            "ollama",
+				"llm-deployment": deployment.Name,
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			ollamaAuthTokenKey: []byte(token),
+		},
+	}
+
+	_ = controllerutil.SetControllerReference(deployment, secret, r.Scheme)
+
+	if err := r.createOrUpdateSecret(ctx, secret); err != nil {
+		return fmt.Errorf("failed to create Ollama auth secret: %w", err)
+	}
+	return nil
+}
+
+// ollamaAuthTokens returns the sorted bearer tokens accepted by the proxy, every value of the tokens secret is a token
+func (r *LMDeploymentReconciler) ollamaAuthTokens(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment) ([]string, error) {
+	secretName := deployment.GetOllamaAuthTokensSecretName()
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Name: secretName, Namespace: deployment.Namespace}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get Ollama auth tokens secret %s: %w", secretName, err)
+	}
+
+	tokens := make([]string, 0, len(secret.Data))
+	for key, value := range secret.Data {
+		token := string(bytes.TrimSpace(value))
+		if !validOllamaToken.MatchString(token) {
+			return nil, fmt.Errorf("auth token %s in secret %s contains unsupported characters", key, secretName)
+		}
+		tokens = append(tokens, token)
+	}
+	sort.Strings(tokens)
+	return tokens, nil
+}
+
+// buildOllamaAuthProxyConfig builds the Secret holding the nginx configuration of the Ollama auth proxy
+func (r *LMDeploymentReconciler) buildOllamaAuthProxyConfig(deployment *llmgeeperiov1alpha1.LMDeployment, tokens []string) (*corev1.Secret, error) {
+	var config bytes.Buffer
+	err := ollamaAuthProxyTemplate.Execute(&config, map[string]interface{}{
+		"Tokens":     tokens,
+		"ListenPort": ollamaAuthProxyPort,
+		"OllamaPort": ollamaPort(deployment),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render Ollama auth proxy config: %w", err)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deployment.GetOllamaAuthProxyConfigName(),
+			Namespace: deployment.Namespace,
+			Labels: map[string]string{
+				"app":            "ollama",
+				"llm-deployment": deployment.Name,
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			"default.conf": config.Bytes(),
+		},
+	}
+
+	_ = controllerutil.SetControllerReference(deployment, secret, r.Scheme)
+	return secret, nil
+}
+
+// buildOllamaAuthProxyContainer builds the sidecar authenticating requests to the exposed Ollama API
+func (r *LMDeploymentReconciler) buildOllamaAuthProxyContainer(deployment *llmgeeperiov1alpha1.LMDeployment) corev1.Container {
+	auth := deployment.Spec.Ollama.Auth
+	if auth == nil {
+		auth = &llmgeeperiov1alpha1.OllamaAuthSpec{}
+	}
+
+	return corev1.Container{
+		Name:  "auth-proxy",
+		Image: r.imageOrDefault(auth.Image, r.operatorConfig().Images.AuthProxy),
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          "auth",
+				ContainerPort: ollamaAuthProxyPort,
+				Protocol:      corev1.ProtocolTCP,
+			},
+		},
+		Resources: r.buildResourceRequirements(auth.Resources),
+		ReadinessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path: "/healthz",
+					Port: intstr.FromInt32(ollamaAuthProxyPort),
+				},
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      "auth-proxy-config",
+				MountPath: "/etc/nginx/conf.d",
+				ReadOnly:  true,
+			},
+		},
+	}
+}
+
+// ollamaPort returns the port the Ollama API listens on
+func ollamaPort(deployment *llmgeeperiov1alpha1.LMDeployment) int32 {
+	if deployment.Spec.Ollama.Service.Port == 0 {
+		return 11434
+	}
+	return deployment.Spec.Ollama.Service.Port
+}
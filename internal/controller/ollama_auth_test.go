@@ -0,0 +1,228 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+func newOllamaAuthTestDeployment() *llmgeeperiov1alpha1.LMDeployment {
+	return &llmgeeperiov1alpha1.LMDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "default"},
+		Spec: llmgeeperiov1alpha1.LMDeploymentSpec{
+			Ollama: llmgeeperiov1alpha1.OllamaSpec{
+				Enabled:  true,
+				Replicas: 1,
+				Models:   []string{"llama3.2"},
+				Service:  llmgeeperiov1alpha1.ServiceSpec{Port: 11434},
+				Ingress:  llmgeeperiov1alpha1.IngressSpec{Host: "ollama.example.com"},
+			},
+		},
+	}
+}
+
+func TestOllamaAuth_Unexposed(t *testing.T) {
+	reconciler := &LMDeploymentReconciler{Scheme: newTestScheme(t)}
+	deployment := newOllamaAuthTestDeployment()
+	deployment.Spec.Ollama.Ingress = llmgeeperiov1alpha1.IngressSpec{}
+
+	ollama := reconciler.buildOllamaDeployment(deployment)
+	assert.Len(t, ollama.Spec.Template.Spec.Containers, 1)
+	assert.Len(t, reconciler.buildOllamaService(deployment).Spec.Ports, 1)
+}
+
+func TestOllamaAuth_Reconcile(t *testing.T) {
+	scheme := newTestScheme(t)
+	deployment := newOllamaAuthTestDeployment()
+	reconciler := &LMDeploymentReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment).Build(),
+		Scheme: scheme,
+	}
+
+	t.Run("should generate a token and route the ingress through the proxy", func(t *testing.T) {
+		require.NoError(t, reconciler.reconcileOllama(t.Context(), deployment))
+
+		token := &corev1.Secret{}
+		require.NoError(t, reconciler.Get(t.Context(), types.NamespacedName{Name: deployment.GetOllamaAuthSecretName(), Namespace: "default"}, token))
+		require.NotEmpty(t, token.Data[ollamaAuthTokenKey])
+
+		proxyConfig := &corev1.Secret{}
+		require.NoError(t, reconciler.Get(t.Context(), types.NamespacedName{Name: deployment.GetOllamaAuthProxyConfigName(), Namespace: "default"}, proxyConfig))
+		config := string(proxyConfig.Data["default.conf"])
+		assert.Contains(t, config, `"Bearer `+string(token.Data[ollamaAuthTokenKey])+`" 1;`)
+		assert.Contains(t, config, "listen 11435;")
+		assert.Contains(t, config, "proxy_pass http://127.0.0.1:11434;")
+
+		ollama := &appsv1.Deployment{}
+		require.NoError(t, reconciler.Get(t.Context(), types.NamespacedName{Name: deployment.GetOllamaDeploymentName(), Namespace: "default"}, ollama))
+		containers := ollama.Spec.Template.Spec.Containers
+		require.Len(t, containers, 2)
+		assert.Equal(t, "auth-proxy", containers[1].Name)
+		assert.Equal(t, "nginxinc/nginx-unprivileged:1.27-alpine", containers[1].Image)
+		assert.NotEmpty(t, ollama.Spec.Template.Annotations[llmgeeperiov1alpha1.ConfigHashAnnotation])
+
+		service := &corev1.Service{}
+		require.NoError(t, reconciler.Get(t.Context(), types.NamespacedName{Name: deployment.GetOllamaServiceName(), Namespace: "default"}, service))
+		require.Len(t, service.Spec.Ports, 2)
+		assert.Equal(t, int32(11434), service.Spec.Ports[0].Port, "in-cluster clients are not authenticated")
+		assert.Equal(t, ollamaAuthProxyPort, service.Spec.Ports[1].Port)
+
+		ingress := &networkingv1.Ingress{}
+		require.NoError(t, reconciler.Get(t.Context(), types.NamespacedName{Name: deployment.GetOllamaIngressName(), Namespace: "default"}, ingress))
+		assert.Equal(t, ollamaAuthProxyPort, ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Port.Number)
+	})
+
+	t.Run("should accept every token of a referenced secret", func(t *testing.T) {
+		tokens := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "ide-tokens", Namespace: "default"},
+			Data: map[string][]byte{
+				"alice": []byte("token-a\n"),
+				"bob":   []byte("token-b"),
+			},
+		}
+		require.NoError(t, reconciler.Create(t.Context(), tokens))
+		deployment.Spec.Ollama.Auth = &llmgeeperiov1alpha1.OllamaAuthSpec{
+			TokensSecretRef: &corev1.LocalObjectReference{Name: "ide-tokens"},
+		}
+		assert.Contains(t, referencedSecretNames(deployment), "ide-tokens")
+
+		previous := &appsv1.Deployment{}
+		require.NoError(t, reconciler.Get(t.Context(), types.NamespacedName{Name: deployment.GetOllamaDeploymentName(), Namespace: "default"}, previous))
+
+		require.NoError(t, reconciler.reconcileOllama(t.Context(), deployment))
+		proxyConfig := &corev1.Secret{}
+		require.NoError(t, reconciler.Get(t.Context(), types.NamespacedName{Name: deployment.GetOllamaAuthProxyConfigName(), Namespace: "default"}, proxyConfig))
+		config := string(proxyConfig.Data["default.conf"])
+		assert.Contains(t, config, `"Bearer token-a" 1;`)
+		assert.Contains(t, config, `"Bearer token-b" 1;`)
+
+		ollama := &appsv1.Deployment{}
+		require.NoError(t, reconciler.Get(t.Context(), types.NamespacedName{Name: deployment.GetOllamaDeploymentName(), Namespace: "default"}, ollama))
+		assert.NotEqual(t, previous.Spec.Template.Annotations[llmgeeperiov1alpha1.ConfigHashAnnotation],
+			ollama.Spec.Template.Annotations[llmgeeperiov1alpha1.ConfigHashAnnotation], "the proxy restarts with the new tokens")
+	})
+
+	t.Run("should reject tokens that would break the proxy config", func(t *testing.T) {
+		tokens := &corev1.Secret{}
+		require.NoError(t, reconciler.Get(t.Context(), types.NamespacedName{Name: "ide-tokens", Namespace: "default"}, tokens))
+		tokens.Data["mallory"] = []byte(`x" 1; default 1; "`)
+		require.NoError(t, reconciler.Update(t.Context(), tokens))
+
+		assert.ErrorContains(t, reconciler.reconcileOllama(t.Context(), deployment), "unsupported characters")
+	})
+}
+
+func TestOllamaAuth_Unexpose(t *testing.T) {
+	scheme := newTestScheme(t)
+	deployment := newOllamaAuthTestDeployment()
+	deployment.Spec.Ollama.Gateway = &llmgeeperiov1alpha1.GatewaySpec{
+		ParentRefs: []llmgeeperiov1alpha1.GatewayParentRef{{Name: "public"}},
+	}
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(httpRouteGVK, meta.RESTScopeNamespace)
+	reconciler := &LMDeploymentReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).WithObjects(deployment).Build(),
+		Scheme: scheme,
+	}
+	ingressKey := types.NamespacedName{Name: deployment.GetOllamaIngressName(), Namespace: "default"}
+	routeKey := types.NamespacedName{Name: deployment.GetOllamaHTTPRouteName(), Namespace: "default"}
+
+	require.NoError(t, reconciler.reconcileOllama(t.Context(), deployment))
+	require.NoError(t, reconciler.Get(t.Context(), ingressKey, &networkingv1.Ingress{}))
+	require.NoError(t, reconciler.Get(t.Context(), routeKey, newHTTPRoute()))
+
+	// Without exposure the proxy checking the tokens is removed, so must be the routes reaching the API
+	deployment.Spec.Ollama.Ingress = llmgeeperiov1alpha1.IngressSpec{}
+	deployment.Spec.Ollama.Gateway = nil
+	require.NoError(t, reconciler.reconcileOllama(t.Context(), deployment))
+	assert.True(t, errors.IsNotFound(reconciler.Get(t.Context(), ingressKey, &networkingv1.Ingress{})))
+	assert.True(t, errors.IsNotFound(reconciler.Get(t.Context(), routeKey, newHTTPRoute())))
+	for _, name := range []string{deployment.GetOllamaAuthSecretName(), deployment.GetOllamaAuthProxyConfigName()} {
+		err := reconciler.Get(t.Context(), types.NamespacedName{Name: name, Namespace: "default"}, &corev1.Secret{})
+		assert.True(t, errors.IsNotFound(err), "secret %s is removed", name)
+	}
+}
+
+func TestOllamaAuth_ExternalService(t *testing.T) {
+	scheme := newTestScheme(t)
+	deployment := newOllamaAuthTestDeployment()
+	deployment.Spec.Ollama.Ingress = llmgeeperiov1alpha1.IngressSpec{}
+	deployment.Spec.Ollama.Service.Type = corev1.ServiceTypeLoadBalancer
+	reconciler := &LMDeploymentReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment).Build(),
+		Scheme: scheme,
+	}
+	serviceKey := types.NamespacedName{Name: deployment.GetOllamaServiceName(), Namespace: "default"}
+	externalKey := types.NamespacedName{Name: deployment.GetOllamaExternalServiceName(), Namespace: "default"}
+
+	assert.True(t, deployment.Spec.Ollama.Exposed())
+	require.NoError(t, reconciler.reconcileOllama(t.Context(), deployment))
+
+	service := &corev1.Service{}
+	require.NoError(t, reconciler.Get(t.Context(), serviceKey, service))
+	assert.Equal(t, corev1.ServiceTypeClusterIP, service.Spec.Type, "the http port is not authenticated")
+
+	external := &corev1.Service{}
+	require.NoError(t, reconciler.Get(t.Context(), externalKey, external))
+	assert.Equal(t, corev1.ServiceTypeLoadBalancer, external.Spec.Type)
+	require.Len(t, external.Spec.Ports, 1)
+	assert.Equal(t, ollamaAuthProxyPort, external.Spec.Ports[0].Port)
+	require.NoError(t, reconciler.Get(t.Context(), types.NamespacedName{Name: deployment.GetOllamaAuthProxyConfigName(), Namespace: "default"}, &corev1.Secret{}))
+
+	deployment.Spec.Ollama.Service.Type = corev1.ServiceTypeClusterIP
+	require.NoError(t, reconciler.reconcileOllama(t.Context(), deployment))
+	assert.True(t, errors.IsNotFound(reconciler.Get(t.Context(), externalKey, &corev1.Service{})))
+}
+
+func TestVLLMRouter_Exposure(t *testing.T) {
+	reconciler := &LMDeploymentReconciler{Scheme: newTestScheme(t)}
+	deployment := &llmgeeperiov1alpha1.LMDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "default"},
+		Spec: llmgeeperiov1alpha1.LMDeploymentSpec{
+			VLLM: llmgeeperiov1alpha1.VLLMSpec{
+				Enabled: true,
+				Router: llmgeeperiov1alpha1.VLLMRouterSpec{
+					Ingress: llmgeeperiov1alpha1.IngressSpec{Host: "api.example.com"},
+					Gateway: &llmgeeperiov1alpha1.GatewaySpec{
+						ParentRefs: []llmgeeperiov1alpha1.GatewayParentRef{{Name: "public"}},
+					},
+				},
+			},
+		},
+	}
+
+	ingress := reconciler.buildVLLMRouterIngress(deployment)
+	assert.Equal(t, "test-deployment-vllm-router-ingress", ingress.Name)
+	assert.Equal(t, "test-deployment-vllm-router", ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name)
+	assert.Equal(t, int32(8000), ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Port.Number)
+
+	route := reconciler.buildVLLMRouterHTTPRoute(deployment)
+	assert.Equal(t, "test-deployment-vllm-router-route", route.GetName())
+}
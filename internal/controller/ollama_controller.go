@@ -23,20 +23,63 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+	operatorconfig "github.com/geeper-io/llm-operator/internal/config"
 )
 
 // reconcileOllama reconciles the Ollama deployment
 func (r *LMDeploymentReconciler) reconcileOllama(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment) error {
+	// Render the auth proxy config from the accepted tokens when the API is exposed
+	var proxyConfig *corev1.Secret
+	if deployment.Spec.Ollama.Exposed() {
+		if err := r.ensureOllamaAuthSecret(ctx, deployment); err != nil {
+			return err
+		}
+		tokens, err := r.ollamaAuthTokens(ctx, deployment)
+		if err != nil {
+			return err
+		}
+		proxyConfig, err = r.buildOllamaAuthProxyConfig(deployment, tokens)
+		if err != nil {
+			return err
+		}
+		if err := r.createOrUpdateSecret(ctx, proxyConfig); err != nil {
+			return err
+		}
+	} else {
+		// Remove the generated token and the proxy config once the API is no longer exposed
+		if err := r.deleteControlled(ctx, deployment, &corev1.Secret{}, deployment.GetOllamaAuthSecretName()); err != nil {
+			return err
+		}
+		if err := r.deleteControlled(ctx, deployment, &corev1.Secret{}, deployment.GetOllamaAuthProxyConfigName()); err != nil {
+			return err
+		}
+	}
+
 	// Create or update Ollama deployment
 	ollamaDeployment := r.buildOllamaDeployment(deployment)
+	r.pinImageDigests(deployment, &ollamaDeployment.Spec.Template)
+	if proxyConfig != nil {
+		// nginx only reads its config at start, restart the proxy when the tokens change
+		hasher := newConfigHasher()
+		hasher.addData(proxyConfig.Name, proxyConfig.Data)
+		setConfigHash(ollamaDeployment, hasher.sum())
+	}
 	if err := r.createOrUpdateDeployment(ctx, ollamaDeployment); err != nil {
 		return err
 	}
+	if err := r.reconcilePodDisruptionBudget(ctx, deployment, ollamaDeployment, deployment.Spec.Ollama.Rollout); err != nil {
+		return err
+	}
+	if err := r.reconcileAutoscaler(ctx, deployment, ollamaDeployment, deployment.Spec.Ollama.Autoscaling); err != nil {
+		return err
+	}
 
 	// Create or update Ollama service
 	ollamaService := r.buildOllamaService(deployment)
@@ -44,6 +87,36 @@ func (r *LMDeploymentReconciler) reconcileOllama(ctx context.Context, deployment
 		return err
 	}
 
+	// Create or update the service exposing the proxy outside the cluster, remove it otherwise
+	if deployment.Spec.Ollama.ExternalService() {
+		externalService := r.buildOllamaExternalService(deployment)
+		if err := r.createOrUpdateService(ctx, externalService); err != nil {
+			return err
+		}
+	} else if err := r.deleteControlled(ctx, deployment, &corev1.Service{}, deployment.GetOllamaExternalServiceName()); err != nil {
+		return err
+	}
+
+	// Create or update Ollama ingress if enabled, remove it otherwise as the proxy checking the tokens is gone too
+	if deployment.Spec.Ollama.Ingress.Host != "" {
+		ollamaIngress := r.buildOllamaIngress(deployment)
+		if err := r.createOrUpdateIngress(ctx, ollamaIngress); err != nil {
+			return err
+		}
+	} else if err := r.deleteControlled(ctx, deployment, &networkingv1.Ingress{}, deployment.GetOllamaIngressName()); err != nil {
+		return err
+	}
+
+	// Create or update Ollama HTTPRoute if a Gateway is configured, remove it otherwise
+	if deployment.Spec.Ollama.Gateway != nil {
+		ollamaRoute := r.buildOllamaHTTPRoute(deployment)
+		if err := r.createOrUpdateHTTPRoute(ctx, ollamaRoute); err != nil {
+			return err
+		}
+	} else if err := r.deleteHTTPRoute(ctx, deployment, deployment.GetOllamaHTTPRouteName()); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -82,7 +155,7 @@ func (r *LMDeploymentReconciler) buildOllamaDeployment(deployment *llmgeeperiov1
 			Labels:    labels,
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: &deployment.Spec.Ollama.Replicas,
+			Replicas: r.scaledReplicas(deployment, scheduledReplicas(deployment.Spec.Ollama.Schedules, deployment.Spec.Ollama.Replicas), deployment.Spec.Ollama.Autoscaling),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: labels,
 			},
@@ -94,7 +167,7 @@ func (r *LMDeploymentReconciler) buildOllamaDeployment(deployment *llmgeeperiov1
 					Containers: []corev1.Container{
 						{
 							Name:  "ollama",
-							Image: deployment.Spec.Ollama.Image,
+							Image: r.ollamaImage(deployment),
 							Ports: []corev1.ContainerPort{
 								{
 									Name:          "http",
@@ -102,7 +175,7 @@ func (r *LMDeploymentReconciler) buildOllamaDeployment(deployment *llmgeeperiov1
 									Protocol:      corev1.ProtocolTCP,
 								},
 							},
-							Resources: r.buildResourceRequirements(deployment.Spec.Ollama.Resources),
+							Resources: r.buildResourceRequirements(operatorconfig.ResourcesOrDefault(deployment.Spec.Ollama.Resources, r.operatorConfig().Resources.Ollama)),
 							VolumeMounts: []corev1.VolumeMount{
 								{
 									Name:      "ollama-data",
@@ -132,18 +205,41 @@ func (r *LMDeploymentReconciler) buildOllamaDeployment(deployment *llmgeeperiov1
 		},
 	}
 
+	// Put the authenticating proxy in front of the exposed API
+	if deployment.Spec.Ollama.Exposed() {
+		podSpec := &ollamaDeployment.Spec.Template.Spec
+		podSpec.Containers = append(podSpec.Containers, r.buildOllamaAuthProxyContainer(deployment))
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: "auth-proxy-config",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: deployment.GetOllamaAuthProxyConfigName(),
+				},
+			},
+		})
+	}
+
+	// Apply pod template overrides
+	r.applyPodTemplateOverrides(&ollamaDeployment.Spec.Template, deployment.Spec.Ollama.PodTemplateOverrides)
+	r.applyRolloutStrategy(ollamaDeployment, deployment.Spec.Ollama.Rollout)
+
 	// Set owner reference
 	_ = controllerutil.SetControllerReference(deployment, ollamaDeployment, r.Scheme)
 	return ollamaDeployment
 }
 
-// buildOllamaService builds the Ollama service object
+// buildOllamaService builds the Ollama service object, it stays inside the cluster as the http port is not authenticated
 func (r *LMDeploymentReconciler) buildOllamaService(deployment *llmgeeperiov1alpha1.LMDeployment) *corev1.Service {
 	labels := map[string]string{
 		"app":            "ollama",
 		"llm-deployment": deployment.Name,
 	}
 
+	serviceType := deployment.Spec.Ollama.Service.Type
+	if deployment.Spec.Ollama.ExternalService() {
+		serviceType = corev1.ServiceTypeClusterIP
+	}
+
 	ollamaService := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      deployment.GetOllamaServiceName(),
@@ -151,7 +247,7 @@ func (r *LMDeploymentReconciler) buildOllamaService(deployment *llmgeeperiov1alp
 			Labels:    labels,
 		},
 		Spec: corev1.ServiceSpec{
-			Type: deployment.Spec.Ollama.Service.Type,
+			Type: serviceType,
 			Ports: []corev1.ServicePort{
 				{
 					Name:       "http",
@@ -164,7 +260,72 @@ func (r *LMDeploymentReconciler) buildOllamaService(deployment *llmgeeperiov1alp
 		},
 	}
 
+	// The exposed API is served through the authenticating proxy, in-cluster clients keep using the http port
+	if deployment.Spec.Ollama.Exposed() {
+		ollamaService.Spec.Ports = append(ollamaService.Spec.Ports, corev1.ServicePort{
+			Name:       "auth",
+			Port:       ollamaAuthProxyPort,
+			TargetPort: intstr.FromInt32(ollamaAuthProxyPort),
+			Protocol:   corev1.ProtocolTCP,
+		})
+	}
+
 	// Set owner reference
 	_ = controllerutil.SetControllerReference(deployment, ollamaService, r.Scheme)
 	return ollamaService
 }
+
+// buildOllamaExternalService builds the NodePort or LoadBalancer service exposing only the authenticating proxy
+func (r *LMDeploymentReconciler) buildOllamaExternalService(deployment *llmgeeperiov1alpha1.LMDeployment) *corev1.Service {
+	labels := map[string]string{
+		"app":            "ollama",
+		"llm-deployment": deployment.Name,
+	}
+
+	externalService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deployment.GetOllamaExternalServiceName(),
+			Namespace: deployment.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Type: deployment.Spec.Ollama.Service.Type,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "auth",
+					Port:       ollamaAuthProxyPort,
+					TargetPort: intstr.FromInt32(ollamaAuthProxyPort),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+			Selector: labels,
+		},
+	}
+
+	// Set owner reference
+	_ = controllerutil.SetControllerReference(deployment, externalService, r.Scheme)
+	return externalService
+}
+
+// buildOllamaIngress builds the Ollama ingress object, it routes to the authenticating proxy
+func (r *LMDeploymentReconciler) buildOllamaIngress(deployment *llmgeeperiov1alpha1.LMDeployment) *networkingv1.Ingress {
+	labels := map[string]string{
+		"app":            "ollama",
+		"llm-deployment": deployment.Name,
+	}
+
+	ingressSpec := deployment.Spec.Ollama.Ingress
+	return r.buildIngress(deployment, deployment.GetOllamaIngressName(), labels, ingressSpec, ingressSpec.Host,
+		deployment.GetOllamaServiceName(), ollamaAuthProxyPort)
+}
+
+// buildOllamaHTTPRoute builds the Ollama HTTPRoute object, it routes to the authenticating proxy
+func (r *LMDeploymentReconciler) buildOllamaHTTPRoute(deployment *llmgeeperiov1alpha1.LMDeployment) *unstructured.Unstructured {
+	labels := map[string]string{
+		"app":            "ollama",
+		"llm-deployment": deployment.Name,
+	}
+
+	return r.buildHTTPRoute(deployment, deployment.GetOllamaHTTPRouteName(), labels, *deployment.Spec.Ollama.Gateway,
+		deployment.GetOllamaServiceName(), ollamaAuthProxyPort)
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+// defaultOIDCScopes are requested when the OIDC scopes are left empty
+var defaultOIDCScopes = []string{"openid", "email", "profile"}
+
+// openWebUIUIConfig renders the ui section of the OpenWebUI config.json with the bootstrap settings.
+// Signup stays open without an auth section as it always was.
+func openWebUIUIConfig(deployment *llmgeeperiov1alpha1.LMDeployment) map[string]interface{} {
+	ui := map[string]interface{}{"enable_signup": true}
+	if auth := deployment.Spec.OpenWebUI.Auth; auth != nil {
+		ui = map[string]interface{}{
+			"enable_signup":     auth.EnableSignup,
+			"default_user_role": openWebUIDefaultUserRole(auth),
+			"ENABLE_LOGIN_FORM": !auth.DisablePasswordLogin,
+		}
+	}
+	for key, value := range openWebUIBootstrapUIConfig(deployment) {
+		ui[key] = value
+	}
+	return ui
+}
+
+// openWebUIDefaultUserRole returns the role of new users, pending unless configured otherwise
+func openWebUIDefaultUserRole(auth *llmgeeperiov1alpha1.OpenWebUIAuthSpec) string {
+	if auth.DefaultUserRole == "" {
+		return llmgeeperiov1alpha1.UserRolePending
+	}
+	return auth.DefaultUserRole
+}
+
+// openWebUIAuthEnv returns the env vars of the sign in and sign up policy and the OIDC provider of OpenWebUI
+func openWebUIAuthEnv(deployment *llmgeeperiov1alpha1.LMDeployment) []corev1.EnvVar {
+	auth := deployment.Spec.OpenWebUI.Auth
+	if auth == nil {
+		return nil
+	}
+
+	envVars := []corev1.EnvVar{
+		{Name: "ENABLE_SIGNUP", Value: pythonBool(auth.EnableSignup)},
+		{Name: "DEFAULT_USER_ROLE", Value: openWebUIDefaultUserRole(auth)},
+		{Name: "ENABLE_LOGIN_FORM", Value: pythonBool(!auth.DisablePasswordLogin)},
+	}
+
+	oidc := auth.OIDC
+	if oidc == nil {
+		return envVars
+	}
+
+	scopes := oidc.Scopes
+	if len(scopes) == 0 {
+		scopes = defaultOIDCScopes
+	}
+	envVars = append(envVars,
+		corev1.EnvVar{Name: "OPENID_PROVIDER_URL", Value: oidc.ProviderURL},
+		corev1.EnvVar{Name: "OAUTH_CLIENT_ID", Value: oidc.ClientID},
+		corev1.EnvVar{Name: "OAUTH_CLIENT_SECRET", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &oidc.ClientSecretRef}},
+		corev1.EnvVar{Name: "OAUTH_SCOPES", Value: strings.Join(scopes, " ")},
+		corev1.EnvVar{Name: "ENABLE_OAUTH_SIGNUP", Value: pythonBool(oidc.EnableSignup)},
+		corev1.EnvVar{Name: "OAUTH_MERGE_ACCOUNTS_BY_EMAIL", Value: pythonBool(oidc.MergeAccountsByEmail)},
+	)
+	if oidc.ProviderName != "" {
+		envVars = append(envVars, corev1.EnvVar{Name: "OAUTH_PROVIDER_NAME", Value: oidc.ProviderName})
+	}
+
+	// The provider redirects back to the host users reach OpenWebUI on
+	redirectURI := oidc.RedirectURI
+	if externalURL := openWebUIURL(deployment); redirectURI == "" && externalURL != "" {
+		redirectURI = externalURL + "/oauth/oidc/callback"
+	}
+	if redirectURI != "" {
+		envVars = append(envVars, corev1.EnvVar{Name: "OPENID_REDIRECT_URI", Value: redirectURI})
+	}
+
+	if mapping := oidc.RoleMapping; mapping != nil {
+		envVars = append(envVars,
+			corev1.EnvVar{Name: "ENABLE_OAUTH_ROLE_MANAGEMENT", Value: "True"},
+			corev1.EnvVar{Name: "OAUTH_ROLES_CLAIM", Value: claimOrDefault(mapping.Claim, "roles")},
+		)
+		// OpenWebUI allows the user and admin roles unless told otherwise
+		if len(mapping.AdminRoles) > 0 {
+			envVars = append(envVars, corev1.EnvVar{Name: "OAUTH_ADMIN_ROLES", Value: strings.Join(mapping.AdminRoles, ",")})
+		}
+		if len(mapping.AllowedRoles) > 0 {
+			envVars = append(envVars, corev1.EnvVar{Name: "OAUTH_ALLOWED_ROLES", Value: strings.Join(mapping.AllowedRoles, ",")})
+		}
+	}
+
+	if mapping := oidc.GroupMapping; mapping != nil {
+		envVars = append(envVars,
+			corev1.EnvVar{Name: "ENABLE_OAUTH_GROUP_MANAGEMENT", Value: "True"},
+			corev1.EnvVar{Name: "OAUTH_GROUP_CLAIM", Value: claimOrDefault(mapping.Claim, "groups")},
+			corev1.EnvVar{Name: "ENABLE_OAUTH_GROUP_CREATION", Value: pythonBool(mapping.CreateGroups)},
+		)
+	}
+
+	return envVars
+}
+
+// openWebUIAuthSecret returns the name of the secret holding the OIDC client secret, empty without OIDC
+func openWebUIAuthSecret(deployment *llmgeeperiov1alpha1.LMDeployment) string {
+	if auth := deployment.Spec.OpenWebUI.Auth; auth != nil && auth.OIDC != nil {
+		return auth.OIDC.ClientSecretRef.Name
+	}
+	return ""
+}
+
+// claimOrDefault returns the configured claim or the default one
+func claimOrDefault(claim, defaultClaim string) string {
+	if claim == "" {
+		return defaultClaim
+	}
+	return claim
+}
+
+// openWebUIURL returns the external URL of OpenWebUI, the Ingress host is preferred over the HTTPRoute hostnames
+func openWebUIURL(deployment *llmgeeperiov1alpha1.LMDeployment) string {
+	openwebui := deployment.Spec.OpenWebUI
+	if openwebui.Ingress.Host != "" {
+		return openwebui.Ingress.URL()
+	}
+	if openwebui.Gateway != nil {
+		return openwebui.Gateway.URL()
+	}
+	return ""
+}
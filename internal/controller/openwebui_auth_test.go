@@ -0,0 +1,108 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+func TestOpenWebUIAuth(t *testing.T) {
+	scheme := newTestScheme(t)
+	deployment := &llmgeeperiov1alpha1.LMDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "default", UID: "uid"},
+		Spec: llmgeeperiov1alpha1.LMDeploymentSpec{
+			OpenWebUI: llmgeeperiov1alpha1.OpenWebUISpec{
+				Enabled: true,
+				Ingress: llmgeeperiov1alpha1.IngressSpec{Host: "chat.example.com"},
+			},
+		},
+	}
+	reconciler := &LMDeploymentReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).Build(), Scheme: scheme}
+	env := func() map[string]corev1.EnvVar {
+		vars := map[string]corev1.EnvVar{}
+		for _, envVar := range openWebUIAuthEnv(deployment) {
+			vars[envVar.Name] = envVar
+		}
+		return vars
+	}
+	ui := func() map[string]interface{} {
+		secret, err := reconciler.buildOpenWebUIConfig(t.Context(), deployment)
+		require.NoError(t, err)
+		var config struct {
+			UI map[string]interface{} `json:"ui"`
+		}
+		require.NoError(t, json.Unmarshal(secret.Data["config.json"], &config))
+		return config.UI
+	}
+
+	t.Run("should keep signup open without an auth section", func(t *testing.T) {
+		assert.Empty(t, openWebUIAuthEnv(deployment))
+		assert.Equal(t, map[string]interface{}{"enable_signup": true}, ui())
+	})
+
+	t.Run("should sign users in through OIDC only", func(t *testing.T) {
+		deployment.Spec.OpenWebUI.Auth = &llmgeeperiov1alpha1.OpenWebUIAuthSpec{
+			DisablePasswordLogin: true,
+			OIDC: &llmgeeperiov1alpha1.OIDCSpec{
+				ProviderURL:     "https://sso.example.com/.well-known/openid-configuration",
+				ProviderName:    "Example SSO",
+				ClientID:        "openwebui",
+				ClientSecretRef: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "oidc"}, Key: "client-secret"},
+				EnableSignup:    true,
+				RoleMapping:     &llmgeeperiov1alpha1.OIDCRoleMappingSpec{AdminRoles: []string{"llm-admin"}},
+				GroupMapping:    &llmgeeperiov1alpha1.OIDCGroupMappingSpec{Claim: "teams", CreateGroups: true},
+			},
+		}
+
+		assert.Equal(t, map[string]interface{}{"enable_signup": false, "default_user_role": "pending", "ENABLE_LOGIN_FORM": false}, ui())
+
+		vars := env()
+		assert.Equal(t, "False", vars["ENABLE_SIGNUP"].Value)
+		assert.Equal(t, "False", vars["ENABLE_LOGIN_FORM"].Value)
+		assert.Equal(t, "True", vars["ENABLE_OAUTH_SIGNUP"].Value)
+		assert.Equal(t, "openwebui", vars["OAUTH_CLIENT_ID"].Value)
+		assert.Equal(t, &deployment.Spec.OpenWebUI.Auth.OIDC.ClientSecretRef, vars["OAUTH_CLIENT_SECRET"].ValueFrom.SecretKeyRef)
+		assert.Equal(t, "openid email profile", vars["OAUTH_SCOPES"].Value)
+		assert.Equal(t, "http://chat.example.com/oauth/oidc/callback", vars["OPENID_REDIRECT_URI"].Value)
+		assert.Equal(t, "roles", vars["OAUTH_ROLES_CLAIM"].Value)
+		assert.Equal(t, "llm-admin", vars["OAUTH_ADMIN_ROLES"].Value)
+		assert.NotContains(t, vars, "OAUTH_ALLOWED_ROLES", "OpenWebUI keeps its default")
+		assert.Equal(t, "teams", vars["OAUTH_GROUP_CLAIM"].Value)
+		assert.Equal(t, "True", vars["ENABLE_OAUTH_GROUP_CREATION"].Value)
+		assert.Contains(t, referencedSecretNames(deployment), "oidc", "a new client secret rolls out")
+	})
+	t.Run("should derive the redirect URI from the gateway hostname without an ingress host", func(t *testing.T) {
+		deployment.Spec.OpenWebUI.Ingress.Host = ""
+		deployment.Spec.OpenWebUI.Gateway = &llmgeeperiov1alpha1.GatewaySpec{
+			ParentRefs: []llmgeeperiov1alpha1.GatewayParentRef{{Name: "public"}},
+			Hostnames:  []string{"*.example.com", "chat.example.com"},
+		}
+		assert.Equal(t, "https://chat.example.com/oauth/oidc/callback", env()["OPENID_REDIRECT_URI"].Value)
+
+		deployment.Spec.OpenWebUI.Gateway.Hostnames = []string{"*.example.com"}
+		assert.NotContains(t, env(), "OPENID_REDIRECT_URI")
+	})
+}
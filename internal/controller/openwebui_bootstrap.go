@@ -0,0 +1,158 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+// openWebUIBootstrapUIConfig renders the bootstrap settings as they are stored in the ui section of config.json.
+// OpenWebUI imports config.json into its database when it finds the file at start, renames it and reads the settings
+// from the database afterwards. The copy-config init container puts the file back before every start, so each pod
+// start re-imports the settings, and changing them rolls the pods through the config hash.
+func openWebUIBootstrapUIConfig(deployment *llmgeeperiov1alpha1.LMDeployment) map[string]interface{} {
+	bootstrap := deployment.Spec.OpenWebUI.Bootstrap
+	if bootstrap == nil {
+		return nil
+	}
+
+	ui := map[string]interface{}{}
+	if len(bootstrap.DefaultModels) > 0 {
+		ui["default_models"] = strings.Join(bootstrap.DefaultModels, ",")
+	}
+
+	if len(bootstrap.PromptSuggestions) > 0 {
+		suggestions := make([]map[string]interface{}, 0, len(bootstrap.PromptSuggestions))
+		for _, suggestion := range bootstrap.PromptSuggestions {
+			suggestions = append(suggestions, map[string]interface{}{
+				"title":   []string{suggestion.Title, suggestion.Subtitle},
+				"content": suggestion.Content,
+			})
+		}
+		ui["prompt_suggestions"] = suggestions
+	}
+
+	if len(bootstrap.Banners) > 0 {
+		banners := make([]map[string]interface{}, 0, len(bootstrap.Banners))
+		for _, banner := range bootstrap.Banners {
+			bannerType := banner.Type
+			if bannerType == "" {
+				bannerType = "info"
+			}
+			banners = append(banners, map[string]interface{}{
+				"id":          banner.ID,
+				"type":        bannerType,
+				"title":       banner.Title,
+				"content":     banner.Content,
+				"dismissible": banner.Dismissible,
+				// The timestamp has to stay stable for the config hash, the banner exists since the deployment does
+				"timestamp": deployment.CreationTimestamp.Unix(),
+			})
+		}
+		ui["banners"] = banners
+	}
+
+	return ui
+}
+
+// openWebUIBootstrapEnv returns the env vars of the admin account and the model visibility.
+// OpenWebUI creates the admin before it serves any request as long as it has no users.
+func openWebUIBootstrapEnv(deployment *llmgeeperiov1alpha1.LMDeployment) []corev1.EnvVar {
+	bootstrap := deployment.Spec.OpenWebUI.Bootstrap
+	if bootstrap == nil {
+		return nil
+	}
+
+	var envVars []corev1.EnvVar
+	if admin := bootstrap.Admin; admin != nil {
+		name := admin.Name
+		if name == "" {
+			name = "Admin"
+		}
+		envVars = append(envVars,
+			corev1.EnvVar{Name: "WEBUI_ADMIN_NAME", Value: name},
+			corev1.EnvVar{Name: "WEBUI_ADMIN_EMAIL", Value: admin.Email},
+			corev1.EnvVar{Name: "WEBUI_ADMIN_PASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &admin.PasswordSecretRef}},
+		)
+	}
+	if bootstrap.ModelVisibility == llmgeeperiov1alpha1.ModelVisibilityPublic {
+		envVars = append(envVars, corev1.EnvVar{Name: "BYPASS_MODEL_ACCESS_CONTROL", Value: "True"})
+	}
+	return envVars
+}
+
+// openWebUIBootstrapSecret returns the name of the secret holding the admin password, empty without an admin
+func openWebUIBootstrapSecret(deployment *llmgeeperiov1alpha1.LMDeployment) string {
+	if bootstrap := deployment.Spec.OpenWebUI.Bootstrap; bootstrap != nil && bootstrap.Admin != nil {
+		return bootstrap.Admin.PasswordSecretRef.Name
+	}
+	return ""
+}
+
+// setBootstrappedCondition reports whether the current bootstrap settings were imported into the OpenWebUI database.
+// The import happens when a pod starts, before it gets ready, so the settings are imported once all replicas were
+// started from the pod template carrying their config hash and one of them is ready.
+func (r *LMDeploymentReconciler) setBootstrappedCondition(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment) {
+	conditions := &deployment.Status.OpenWebUIStatus.Conditions
+	bootstrap := deployment.Spec.OpenWebUI.Bootstrap
+	if bootstrap == nil {
+		meta.RemoveStatusCondition(conditions, llmgeeperiov1alpha1.ConditionBootstrapped)
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:               llmgeeperiov1alpha1.ConditionBootstrapped,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: deployment.Generation,
+		Reason:             "Imported",
+		Message:            "OpenWebUI imported the bootstrap settings when its pods started, changes made in the admin panel last until the next restart",
+	}
+
+	if admin := bootstrap.Admin; admin != nil {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{Name: admin.PasswordSecretRef.Name, Namespace: deployment.Namespace}, secret); err != nil {
+			condition.Status, condition.Reason, condition.Message = metav1.ConditionFalse, "SecretNotFound", fmt.Sprintf("Secret %s not found", admin.PasswordSecretRef.Name)
+			meta.SetStatusCondition(conditions, condition)
+			return
+		}
+		if _, ok := secret.Data[admin.PasswordSecretRef.Key]; !ok {
+			condition.Status, condition.Reason, condition.Message = metav1.ConditionFalse, "SecretKeyNotFound", fmt.Sprintf("Key %s not found in secret %s", admin.PasswordSecretRef.Key, admin.PasswordSecretRef.Name)
+			meta.SetStatusCondition(conditions, condition)
+			return
+		}
+	}
+
+	openwebui := &appsv1.Deployment{}
+	err := r.Get(ctx, types.NamespacedName{Name: deployment.GetOpenWebUIDeploymentName(), Namespace: deployment.Namespace}, openwebui)
+	switch {
+	case err != nil:
+		condition.Status, condition.Reason, condition.Message = metav1.ConditionFalse, "NotFound", fmt.Sprintf("OpenWebUI deployment %s has not been created yet", deployment.GetOpenWebUIDeploymentName())
+	case openwebui.Status.ReadyReplicas == 0 || rolledOutConfigHash(openwebui, "") != openwebui.Spec.Template.Annotations[llmgeeperiov1alpha1.ConfigHashAnnotation]:
+		condition.Status, condition.Reason, condition.Message = metav1.ConditionFalse, "Pending", "Waiting for OpenWebUI pods to start and import the bootstrap settings"
+	}
+	meta.SetStatusCondition(conditions, condition)
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+func TestOpenWebUIBootstrap(t *testing.T) {
+	ctx := context.Background()
+	scheme := newTestScheme(t)
+	deployment := &llmgeeperiov1alpha1.LMDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "default", UID: "uid"},
+		Spec: llmgeeperiov1alpha1.LMDeploymentSpec{
+			OpenWebUI: llmgeeperiov1alpha1.OpenWebUISpec{
+				Enabled: true,
+				Bootstrap: &llmgeeperiov1alpha1.OpenWebUIBootstrapSpec{
+					Admin: &llmgeeperiov1alpha1.OpenWebUIAdminSpec{
+						Email:             "admin@example.com",
+						PasswordSecretRef: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "openwebui-admin"}, Key: "password"},
+					},
+					DefaultModels:     []string{"llama"},
+					PromptSuggestions: []llmgeeperiov1alpha1.PromptSuggestionSpec{{Title: "Summarize", Subtitle: "a document", Content: "Summarize this:"}},
+					Banners:           []llmgeeperiov1alpha1.BannerSpec{{ID: "maintenance", Type: "warning", Content: "Maintenance on Friday", Dismissible: true}},
+					ModelVisibility:   llmgeeperiov1alpha1.ModelVisibilityPublic,
+				},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	reconciler := &LMDeploymentReconciler{Client: c, Scheme: scheme}
+	condition := func() *metav1.Condition {
+		return meta.FindStatusCondition(deployment.Status.OpenWebUIStatus.Conditions, llmgeeperiov1alpha1.ConditionBootstrapped)
+	}
+
+	t.Run("should render the settings to config.json", func(t *testing.T) {
+		secret, err := reconciler.buildOpenWebUIConfig(ctx, deployment)
+		require.NoError(t, err)
+		var config struct {
+			UI struct {
+				EnableSignup      bool                     `json:"enable_signup"`
+				DefaultModels     string                   `json:"default_models"`
+				PromptSuggestions []map[string]interface{} `json:"prompt_suggestions"`
+				Banners           []map[string]interface{} `json:"banners"`
+			} `json:"ui"`
+		}
+		require.NoError(t, json.Unmarshal(secret.Data["config.json"], &config))
+
+		assert.True(t, config.UI.EnableSignup, "signup is governed by the auth section")
+		assert.Equal(t, "llama", config.UI.DefaultModels)
+		assert.Equal(t, []interface{}{"Summarize", "a document"}, config.UI.PromptSuggestions[0]["title"])
+		require.Len(t, config.UI.Banners, 1)
+		assert.Equal(t, "maintenance", config.UI.Banners[0]["id"])
+		assert.Equal(t, "warning", config.UI.Banners[0]["type"])
+	})
+
+	t.Run("should create the admin from its secret", func(t *testing.T) {
+		env := openWebUIBootstrapEnv(deployment)
+		assert.Contains(t, env, corev1.EnvVar{Name: "WEBUI_ADMIN_NAME", Value: "Admin"})
+		assert.Contains(t, env, corev1.EnvVar{Name: "WEBUI_ADMIN_EMAIL", Value: "admin@example.com"})
+		assert.Contains(t, env, corev1.EnvVar{Name: "BYPASS_MODEL_ACCESS_CONTROL", Value: "True"})
+		assert.Contains(t, referencedSecretNames(deployment), "openwebui-admin", "a new password is picked up on restart")
+	})
+
+	t.Run("should wait for the admin password", func(t *testing.T) {
+		reconciler.setBootstrappedCondition(ctx, deployment)
+		require.NotNil(t, condition())
+		assert.Equal(t, metav1.ConditionFalse, condition().Status)
+		assert.Equal(t, "SecretNotFound", condition().Reason)
+	})
+
+	t.Run("should report the bootstrap once OpenWebUI rolled out", func(t *testing.T) {
+		require.NoError(t, c.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "openwebui-admin", Namespace: "default"},
+			Data:       map[string][]byte{"password": []byte("secret")},
+		}))
+		openwebui := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: deployment.GetOpenWebUIDeploymentName(), Namespace: "default"},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: ptr.To(int32(1)),
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{llmgeeperiov1alpha1.ConfigHashAnnotation: "new"}},
+				},
+			},
+		}
+		require.NoError(t, c.Create(ctx, openwebui))
+
+		reconciler.setBootstrappedCondition(ctx, deployment)
+		assert.Equal(t, "Pending", condition().Reason)
+
+		openwebui.Status = appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, ReadyReplicas: 1}
+		require.NoError(t, c.Status().Update(ctx, openwebui))
+		reconciler.setBootstrappedCondition(ctx, deployment)
+		assert.Equal(t, metav1.ConditionTrue, condition().Status)
+		assert.Equal(t, "Imported", condition().Reason)
+	})
+
+	t.Run("should drop the condition without bootstrap settings", func(t *testing.T) {
+		deployment.Spec.OpenWebUI.Bootstrap = nil
+		reconciler.setBootstrappedCondition(ctx, deployment)
+		assert.Nil(t, condition())
+		assert.Empty(t, openWebUIBootstrapEnv(deployment))
+	})
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+// openWebUIConnection is an API OpenWebUI lists models from and sends requests to
+type openWebUIConnection struct {
+	url string
+	// apiKey references the key sent to the API, nil if it needs none
+	apiKey *corev1.SecretKeySelector
+	// prefixID is prepended to the model IDs to tell apart models of the same name on different connections
+	prefixID string
+	tags     []string
+	// modelIDs restricts the models shown from the connection, empty shows all of them
+	modelIDs []string
+	// connectionType is local for the components of the deployment and external for other providers
+	connectionType string
+	// headers are sent with every request, config.json is the only place OpenWebUI reads them from
+	headers map[string]string
+}
+
+// openWebUIConnections returns the OpenAI-compatible and the Ollama connections of OpenWebUI in a stable order.
+// config.json and the env vars of OpenWebUI are both rendered from them.
+func openWebUIConnections(deployment *llmgeeperiov1alpha1.LMDeployment) (openai, ollama []openWebUIConnection) {
+	if pipelines := deployment.Spec.OpenWebUI.Pipelines; pipelines != nil && pipelines.Enabled {
+		openai = append(openai, openWebUIConnection{
+			url: fmt.Sprintf("http://%s:9099", deployment.GetPipelinesServiceName()),
+			apiKey: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: deployment.GetPipelinesSecretName()},
+				Key:                  "PIPELINES_API_KEY",
+			},
+			tags:           []string{"pipelines"},
+			connectionType: "local",
+		})
+	}
+
+	// The router is deployed with every vLLM deployment and routes to the model services, canaries included
+	if deployment.Spec.VLLM.Enabled {
+		openai = append(openai, openWebUIConnection{
+			url:            fmt.Sprintf("http://%s:%d/v1", deployment.GetVLLMRouterServiceName(), vllmRouterPort(deployment)),
+			apiKey:         vllmAPIKeyRef(deployment),
+			tags:           []string{"vllm"},
+			modelIDs:       getVLLMModelNames(deployment.Spec.VLLM.Models),
+			connectionType: "local",
+		})
+	}
+
+	// External providers come after the components of the deployment, their models are prefixed with the provider name
+	for _, provider := range deployment.Spec.External {
+		openai = append(openai, openWebUIConnection{
+			url:            provider.BaseURL,
+			apiKey:         provider.APIKeySecretRef,
+			prefixID:       provider.Name,
+			tags:           []string{provider.Name},
+			modelIDs:       provider.Models,
+			connectionType: "external",
+			headers:        provider.Headers,
+		})
+	}
+
+	if deployment.Spec.Ollama.Enabled {
+		// The Ollama embedding model can't chat
+		models := slices.Clone(deployment.Spec.Ollama.Models)
+		if deployment.Spec.EmbeddingsBackend() == llmgeeperiov1alpha1.EmbeddingsBackendOllama {
+			models = slices.DeleteFunc(models, func(model string) bool { return model == deployment.Spec.Embeddings.Model })
+		}
+		ollama = append(ollama, openWebUIConnection{
+			url:            fmt.Sprintf("http://%s:%d", deployment.GetOllamaServiceName(), deployment.GetOllamaServicePort()),
+			tags:           []string{"ollama"},
+			modelIDs:       models,
+			connectionType: "local",
+		})
+	}
+
+	return openai, ollama
+}
+
+// apiConfig renders the settings of a connection as OpenWebUI stores them in api_configs
+func (c openWebUIConnection) apiConfig() map[string]interface{} {
+	tags := make([]map[string]string, 0, len(c.tags))
+	for _, tag := range c.tags {
+		tags = append(tags, map[string]string{"name": tag})
+	}
+	modelIDs := c.modelIDs
+	if modelIDs == nil {
+		modelIDs = []string{}
+	}
+	apiConfig := map[string]interface{}{
+		"enable":          true,
+		"tags":            tags,
+		"prefix_id":       c.prefixID,
+		"model_ids":       modelIDs,
+		"connection_type": c.connectionType,
+	}
+	if len(c.headers) > 0 {
+		apiConfig["headers"] = c.headers
+	}
+	return apiConfig
+}
+
+// openWebUIConnectionsConfig renders the openai and ollama sections of the OpenWebUI config.json,
+// the API keys are read from their secrets since config.json holds them in plain text
+func (r *LMDeploymentReconciler) openWebUIConnectionsConfig(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment) (openaiConfig, ollamaConfig map[string]interface{}, err error) {
+	openai, ollama := openWebUIConnections(deployment)
+
+	urls := make([]string, 0, len(openai))
+	keys := make([]string, 0, len(openai))
+	configs := map[string]interface{}{}
+	for i, connection := range openai {
+		key := ""
+		if connection.apiKey != nil {
+			if key, err = r.secretKeyValue(ctx, deployment.Namespace, connection.apiKey); err != nil {
+				return nil, nil, err
+			}
+		}
+		urls = append(urls, connection.url)
+		keys = append(keys, key)
+		configs[strconv.Itoa(i)] = connection.apiConfig()
+	}
+	openaiConfig = map[string]interface{}{
+		"enable":        len(openai) > 0,
+		"api_base_urls": urls,
+		"api_keys":      keys,
+		"api_configs":   configs,
+	}
+
+	urls = make([]string, 0, len(ollama))
+	configs = map[string]interface{}{}
+	for i, connection := range ollama {
+		urls = append(urls, connection.url)
+		configs[strconv.Itoa(i)] = connection.apiConfig()
+	}
+	ollamaConfig = map[string]interface{}{
+		"enable":      len(ollama) > 0,
+		"base_urls":   urls,
+		"api_configs": configs,
+	}
+
+	return openaiConfig, ollamaConfig, nil
+}
+
+// openWebUIConnectionsEnv renders the connections as the env vars OpenWebUI falls back to without config.json.
+// The keys are taken from their secrets into numbered env vars and joined through dependent env var expansion.
+func openWebUIConnectionsEnv(deployment *llmgeeperiov1alpha1.LMDeployment) []corev1.EnvVar {
+	openai, ollama := openWebUIConnections(deployment)
+
+	var envVars []corev1.EnvVar
+	urls := make([]string, 0, len(openai))
+	keys := make([]string, 0, len(openai))
+	for i, connection := range openai {
+		urls = append(urls, connection.url)
+		if connection.apiKey == nil {
+			keys = append(keys, "")
+			continue
+		}
+		name := fmt.Sprintf("OPENAI_API_KEY_%d", i)
+		envVars = append(envVars, corev1.EnvVar{Name: name, ValueFrom: &corev1.EnvVarSource{SecretKeyRef: connection.apiKey}})
+		keys = append(keys, fmt.Sprintf("$(%s)", name))
+	}
+	envVars = append(envVars, corev1.EnvVar{Name: "ENABLE_OPENAI_API", Value: pythonBool(len(openai) > 0)})
+	if len(openai) > 0 {
+		envVars = append(envVars,
+			corev1.EnvVar{Name: "OPENAI_API_BASE_URLS", Value: strings.Join(urls, ";")},
+			corev1.EnvVar{Name: "OPENAI_API_KEYS", Value: strings.Join(keys, ";")},
+		)
+	}
+
+	urls = make([]string, 0, len(ollama))
+	for _, connection := range ollama {
+		urls = append(urls, connection.url)
+	}
+	envVars = append(envVars, corev1.EnvVar{Name: "ENABLE_OLLAMA_API", Value: pythonBool(len(ollama) > 0)})
+	if len(ollama) > 0 {
+		envVars = append(envVars, corev1.EnvVar{Name: "OLLAMA_BASE_URLS", Value: strings.Join(urls, ";")})
+	}
+
+	return envVars
+}
+
+// openWebUIConnectionSecrets returns the names of the secrets holding the API keys of the connections
+func openWebUIConnectionSecrets(deployment *llmgeeperiov1alpha1.LMDeployment) []string {
+	openai, _ := openWebUIConnections(deployment)
+	var names []string
+	for _, connection := range openai {
+		if connection.apiKey != nil && !slices.Contains(names, connection.apiKey.Name) {
+			names = append(names, connection.apiKey.Name)
+		}
+	}
+	return names
+}
+
+// pythonBool formats a boolean the way OpenWebUI reads it from env vars
+func pythonBool(value bool) string {
+	if value {
+		return "True"
+	}
+	return "False"
+}
+
+// secretKeyValue reads a key of a secret in the deployment namespace
+func (r *LMDeploymentReconciler) secretKeyValue(ctx context.Context, namespace string, ref *corev1.SecretKeySelector) (string, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: namespace}, secret); err != nil {
+		return "", fmt.Errorf("failed to read secret %s: %w", ref.Name, err)
+	}
+	value, exists := secret.Data[ref.Key]
+	if !exists {
+		return "", fmt.Errorf("key %s not found in secret %s", ref.Key, ref.Name)
+	}
+	return string(value), nil
+}
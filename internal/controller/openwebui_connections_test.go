@@ -0,0 +1,139 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+func TestOpenWebUIConnections(t *testing.T) {
+	ctx := context.Background()
+	scheme := newTestScheme(t)
+	deployment := &llmgeeperiov1alpha1.LMDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "default", UID: "uid"},
+		Spec: llmgeeperiov1alpha1.LMDeploymentSpec{
+			VLLM: llmgeeperiov1alpha1.VLLMSpec{
+				Enabled: true,
+				Models: []llmgeeperiov1alpha1.VLLMModelSpec{
+					{Name: "llama", Model: "meta-llama/Llama-3.1-8B-Instruct"},
+					{Name: "bge", Model: "BAAI/bge-m3", Task: "embed"},
+				},
+			},
+			Ollama: llmgeeperiov1alpha1.OllamaSpec{Enabled: true, Models: []string{"qwen2.5:7b", "nomic-embed-text"}},
+			OpenWebUI: llmgeeperiov1alpha1.OpenWebUISpec{
+				Enabled:   true,
+				Pipelines: &llmgeeperiov1alpha1.PipelinesSpec{Enabled: true},
+			},
+			Embeddings: &llmgeeperiov1alpha1.EmbeddingsSpec{Backend: llmgeeperiov1alpha1.EmbeddingsBackendOllama, Model: "nomic-embed-text"},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-deployment-vllm-api-key", Namespace: "default"},
+			Data:       map[string][]byte{llmgeeperiov1alpha1.VLLMApiKeySecretKey: []byte("vllm-key")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-deployment-pipelines-secret", Namespace: "default"},
+			Data:       map[string][]byte{"PIPELINES_API_KEY": []byte("pipelines-key")},
+		},
+	).Build()
+	reconciler := &LMDeploymentReconciler{Client: c, Scheme: scheme}
+
+	t.Run("should list pipelines, the vLLM router and Ollama in config.json", func(t *testing.T) {
+		secret, err := reconciler.buildOpenWebUIConfig(ctx, deployment)
+		require.NoError(t, err)
+
+		var config struct {
+			OpenAI struct {
+				Enable      bool                          `json:"enable"`
+				APIBaseURLs []string                      `json:"api_base_urls"`
+				APIKeys     []string                      `json:"api_keys"`
+				APIConfigs  map[string]openWebUIAPIConfig `json:"api_configs"`
+			} `json:"openai"`
+			Ollama struct {
+				Enable     bool                          `json:"enable"`
+				BaseURLs   []string                      `json:"base_urls"`
+				APIConfigs map[string]openWebUIAPIConfig `json:"api_configs"`
+			} `json:"ollama"`
+		}
+		require.NoError(t, json.Unmarshal(secret.Data["config.json"], &config))
+
+		assert.True(t, config.OpenAI.Enable)
+		assert.Equal(t, []string{"http://test-deployment-pipelines:9099", "http://test-deployment-vllm-router:8000/v1"}, config.OpenAI.APIBaseURLs)
+		assert.Equal(t, []string{"pipelines-key", "vllm-key"}, config.OpenAI.APIKeys)
+		assert.Equal(t, []string{"meta-llama/Llama-3.1-8B-Instruct"}, config.OpenAI.APIConfigs["1"].ModelIDs, "the embedding model can't chat")
+		assert.Equal(t, []map[string]string{{"name": "vllm"}}, config.OpenAI.APIConfigs["1"].Tags)
+		assert.True(t, config.Ollama.Enable)
+		assert.Equal(t, []string{"http://test-deployment-ollama:11434"}, config.Ollama.BaseURLs)
+		assert.Equal(t, []string{"qwen2.5:7b"}, config.Ollama.APIConfigs["0"].ModelIDs)
+	})
+
+	t.Run("should render the same connections as env vars", func(t *testing.T) {
+		env := openWebUIConnectionsEnv(deployment)
+		assert.Contains(t, env, corev1.EnvVar{Name: "OPENAI_API_BASE_URLS", Value: "http://test-deployment-pipelines:9099;http://test-deployment-vllm-router:8000/v1"})
+		assert.Contains(t, env, corev1.EnvVar{Name: "OPENAI_API_KEYS", Value: "$(OPENAI_API_KEY_0);$(OPENAI_API_KEY_1)"})
+		assert.Contains(t, env, corev1.EnvVar{Name: "OLLAMA_BASE_URLS", Value: "http://test-deployment-ollama:11434"})
+		assert.Equal(t, "OPENAI_API_KEY_0", env[0].Name)
+		assert.Equal(t, "test-deployment-pipelines-secret", env[0].ValueFrom.SecretKeyRef.Name)
+		assert.Equal(t, []string{"test-deployment-pipelines-secret", "test-deployment-vllm-api-key"}, openWebUIConnectionSecrets(deployment))
+	})
+
+	t.Run("should add external providers with prefixed models", func(t *testing.T) {
+		deployment.Spec.External = []llmgeeperiov1alpha1.ExternalProviderSpec{{
+			Name:    "openai",
+			BaseURL: "https://api.openai.com/v1",
+			Models:  []string{"gpt-4o"},
+			Headers: map[string]string{"OpenAI-Organization": "org-test"},
+		}}
+		openai, _ := openWebUIConnections(deployment)
+		require.Len(t, openai, 3)
+		external := openai[2].apiConfig()
+		assert.Equal(t, "openai", external["prefix_id"])
+		assert.Equal(t, "external", external["connection_type"])
+		assert.Equal(t, map[string]string{"OpenAI-Organization": "org-test"}, external["headers"])
+
+		env := openWebUIConnectionsEnv(deployment)
+		assert.Contains(t, env, corev1.EnvVar{Name: "OPENAI_API_KEYS", Value: "$(OPENAI_API_KEY_0);$(OPENAI_API_KEY_1);"}, "the provider needs no key")
+		deployment.Spec.External = nil
+	})
+
+	t.Run("should disable the APIs without connections", func(t *testing.T) {
+		deployment.Spec.VLLM.Enabled = false
+		deployment.Spec.OpenWebUI.Pipelines = nil
+		env := openWebUIConnectionsEnv(deployment)
+		assert.Contains(t, env, corev1.EnvVar{Name: "ENABLE_OPENAI_API", Value: "False"})
+		assert.Contains(t, env, corev1.EnvVar{Name: "ENABLE_OLLAMA_API", Value: "True"})
+	})
+}
+
+// openWebUIAPIConfig is an entry of api_configs in the OpenWebUI config.json
+type openWebUIAPIConfig struct {
+	Tags           []map[string]string `json:"tags"`
+	PrefixID       string              `json:"prefix_id"`
+	ModelIDs       []string            `json:"model_ids"`
+	ConnectionType string              `json:"connection_type"`
+}
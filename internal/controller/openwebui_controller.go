@@ -27,6 +27,7 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -34,12 +35,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+	operatorconfig "github.com/geeper-io/llm-operator/internal/config"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 // ensurePipelineSecret ensures the pipelines secret exists and returns the API key
 func (r *LMDeploymentReconciler) ensurePipelineSecret(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment) (string, error) {
-	secretName := fmt.Sprintf("%s-pipelines-secret", deployment.Name)
+	secretName := deployment.GetPipelinesSecretName()
 	existingSecret := &corev1.Secret{}
 
 	err := r.Get(ctx, client.ObjectKey{
@@ -97,6 +99,16 @@ func (r *LMDeploymentReconciler) reconcileOpenWebUI(ctx context.Context, deploym
 		return err
 	}
 
+	// Reconcile the PostgreSQL database if the operator runs it
+	if err := r.reconcilePostgres(ctx, deployment); err != nil {
+		return err
+	}
+
+	// Reconcile the Qdrant vector database if the operator runs it
+	if err := r.reconcileQdrant(ctx, deployment); err != nil {
+		return err
+	}
+
 	// Reconcile Pipelines if enabled
 	if deployment.Spec.OpenWebUI.Pipelines != nil && deployment.Spec.OpenWebUI.Pipelines.Enabled {
 		if err := r.reconcilePipelines(ctx, deployment); err != nil {
@@ -131,11 +143,39 @@ func (r *LMDeploymentReconciler) reconcileOpenWebUI(ctx context.Context, deploym
 		return fmt.Errorf("failed to create or update OpenWebUI config secret: %w", err)
 	}
 
+	// Restart OpenWebUI when its config or any consumed secret changes, the init container only copies config at start
+	hasher := newConfigHasher()
+	hasher.addData(openwebuiConfig.Name, openwebuiConfig.Data)
+	consumedSecrets := append([]string{deployment.GetOpenWebUISecretName()}, openWebUIConnectionSecrets(deployment)...)
+	if secret := openWebUIDatabaseSecret(deployment); secret != "" {
+		consumedSecrets = append(consumedSecrets, secret)
+	}
+	for _, ref := range openWebUIVectorDBSecrets(deployment) {
+		consumedSecrets = append(consumedSecrets, ref.Name)
+	}
+	if secret := openWebUIAuthSecret(deployment); secret != "" {
+		consumedSecrets = append(consumedSecrets, secret)
+	}
+	if secret := openWebUIBootstrapSecret(deployment); secret != "" {
+		consumedSecrets = append(consumedSecrets, secret)
+	}
+	if secret := openWebUIRedisSecret(deployment); secret != "" {
+		consumedSecrets = append(consumedSecrets, secret)
+	}
+	if err := r.addSecrets(ctx, hasher, deployment.Namespace, consumedSecrets...); err != nil {
+		return err
+	}
+
 	// Create or update OpenWebUI deployment
 	openwebuiDeployment := r.buildOpenWebUIDeployment(deployment)
+	r.pinImageDigests(deployment, &openwebuiDeployment.Spec.Template)
+	setConfigHash(openwebuiDeployment, hasher.sum())
 	if err := r.createOrUpdateDeployment(ctx, openwebuiDeployment); err != nil {
 		return err
 	}
+	if err := r.reconcilePodDisruptionBudget(ctx, deployment, openwebuiDeployment, deployment.Spec.OpenWebUI.Rollout); err != nil {
+		return err
+	}
 
 	// Create or update OpenWebUI service
 	openwebuiService := r.buildOpenWebUIService(deployment)
@@ -151,17 +191,36 @@ func (r *LMDeploymentReconciler) reconcileOpenWebUI(ctx context.Context, deploym
 		}
 	}
 
+	// Create or update HTTPRoute if a Gateway is configured, remove it otherwise
+	if deployment.Spec.OpenWebUI.Gateway != nil {
+		route := r.buildOpenWebUIHTTPRoute(deployment)
+		if err := r.createOrUpdateHTTPRoute(ctx, route); err != nil {
+			return err
+		}
+	} else if err := r.deleteHTTPRoute(ctx, deployment, deployment.GetOpenWebUIHTTPRouteName()); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 // reconcilePipelines reconciles the OpenWebUI Pipelines deployment
 func (r *LMDeploymentReconciler) reconcilePipelines(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment) error {
 	// Ensure pipeline secret exists and get API key
-	_, err := r.ensurePipelineSecret(ctx, deployment)
+	apiKey, err := r.ensurePipelineSecret(ctx, deployment)
 	if err != nil {
 		return fmt.Errorf("failed to ensure pipeline secret: %w", err)
 	}
 
+	// Restart Pipelines when the API key or the Langfuse credentials change
+	hasher := newConfigHasher()
+	hasher.addString("PIPELINES_API_KEY", apiKey)
+	if langfuse := deployment.Spec.OpenWebUI.Langfuse; langfuse != nil && langfuse.Enabled && langfuse.SecretRef != nil {
+		if err := r.addSecrets(ctx, hasher, deployment.Namespace, langfuse.SecretRef.Name); err != nil {
+			return err
+		}
+	}
+
 	// Create or update Pipelines PVC if persistence is enabled
 	if deployment.Spec.OpenWebUI.Pipelines.Persistence.Enabled {
 		pvc := r.buildPipelinesPVC(deployment)
@@ -172,9 +231,14 @@ func (r *LMDeploymentReconciler) reconcilePipelines(ctx context.Context, deploym
 
 	// Create or update Pipelines deployment
 	pipelinesDeployment := r.buildPipelinesDeployment(deployment)
+	r.pinImageDigests(deployment, &pipelinesDeployment.Spec.Template)
+	setConfigHash(pipelinesDeployment, hasher.sum())
 	if err := r.createOrUpdateDeployment(ctx, pipelinesDeployment); err != nil {
 		return err
 	}
+	if err := r.reconcilePodDisruptionBudget(ctx, deployment, pipelinesDeployment, deployment.Spec.OpenWebUI.Pipelines.Rollout); err != nil {
+		return err
+	}
 
 	// Create or update Pipelines service
 	pipelinesService := r.buildPipelinesService(deployment)
@@ -217,9 +281,11 @@ func (r *LMDeploymentReconciler) buildOpenWebUIPVC(deployment *llmgeeperiov1alph
 	}
 
 	// Add storage class if specified
-	if deployment.Spec.OpenWebUI.Persistence != nil && deployment.Spec.OpenWebUI.Persistence.StorageClass != "" {
-		pvc.Spec.StorageClassName = &deployment.Spec.OpenWebUI.Persistence.StorageClass
+	storageClass := ""
+	if deployment.Spec.OpenWebUI.Persistence != nil {
+		storageClass = deployment.Spec.OpenWebUI.Persistence.StorageClass
 	}
+	pvc.Spec.StorageClassName = r.operatorConfig().StorageClassOrDefault(storageClass)
 
 	// Note: We don't set controller reference on PVCs because they should persist
 	// even if the LMDeployment is deleted to preserve user data
@@ -241,7 +307,7 @@ func (r *LMDeploymentReconciler) buildOpenWebUIDeployment(deployment *llmgeeperi
 			ValueFrom: &corev1.EnvVarSource{
 				SecretKeyRef: &corev1.SecretKeySelector{
 					LocalObjectReference: corev1.LocalObjectReference{
-						Name: fmt.Sprintf("%s-openwebui-secret", deployment.Name),
+						Name: deployment.GetOpenWebUISecretName(),
 					},
 					Key: "WEBUI_SECRET_KEY",
 				},
@@ -249,58 +315,35 @@ func (r *LMDeploymentReconciler) buildOpenWebUIDeployment(deployment *llmgeeperi
 		},
 	}
 
-	if deployment.Spec.Ollama.Enabled {
-		envVars = append(envVars, corev1.EnvVar{
-			Name: "OLLAMA_BASE_URL", Value: fmt.Sprintf("http://%s:%d", deployment.GetOllamaServiceName(), deployment.GetOllamaServicePort()),
-		})
-	}
-	if deployment.Spec.VLLM.Enabled {
-		envVars = append(envVars,
-			corev1.EnvVar{Name: "ENABLE_OPENAI_API", Value: "True"},
-			corev1.EnvVar{Name: "OPENAI_API_BASE_URL", Value: fmt.Sprintf("http://%s:8000", deployment.GetVLLMRouterServiceName())},
-			corev1.EnvVar{Name: "OPENAI_API_KEY", ValueFrom: &corev1.EnvVarSource{
-				SecretKeyRef: &corev1.SecretKeySelector{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: deployment.GetVLLMApiKeySecretName(),
-					},
-					Key: llmgeeperiov1alpha1.VLLMApiKeySecretKey,
-				},
-			}},
-		)
-
-	} else {
-		envVars = append(envVars, corev1.EnvVar{
-			Name: "ENABLE_OPENAI_API", Value: "False",
-		})
-	}
+	// Connect OpenWebUI to the same APIs as in config.json
+	envVars = append(envVars, openWebUIConnectionsEnv(deployment)...)
 
 	if deployment.Spec.OpenWebUI.Ingress.Host != "" {
+		// The scheme follows the ingress TLS settings
+		externalURL := deployment.Spec.OpenWebUI.Ingress.URL()
 		envVars = append(envVars, []corev1.EnvVar{
-			// TODO: Use the correct protocol based on deployment type
-			{Name: "WEBUI_HOST", Value: fmt.Sprintf("https://%s", deployment.Spec.OpenWebUI.Ingress.Host)},
-			{Name: "CORS_ALLOW_ORIGIN", Value: fmt.Sprintf("http://%s;https://%s", deployment.Spec.OpenWebUI.Ingress.Host, deployment.Spec.OpenWebUI.Ingress.Host)},
+			{Name: "WEBUI_HOST", Value: externalURL},
+			{Name: "CORS_ALLOW_ORIGIN", Value: externalURL},
 		}...)
 	}
 
-	// Add Redis environment variables if Redis is enabled
-	if deployment.Spec.OpenWebUI.Redis.Enabled {
-		var redisURL string
-		if deployment.Spec.OpenWebUI.Redis.RedisURL != "" {
-			// Use external Redis URL
-			redisURL = deployment.Spec.OpenWebUI.Redis.RedisURL
-		} else {
-			// Use internal Redis service
-			redisURL = fmt.Sprintf("redis://:%s@%s:%d/0",
-				deployment.Spec.OpenWebUI.Redis.Password,
-				deployment.GetRedisServiceName(),
-				deployment.Spec.OpenWebUI.Redis.Service.Port)
-		}
+	// Sign users in through OIDC and restrict who may sign up
+	envVars = append(envVars, openWebUIAuthEnv(deployment)...)
 
-		// Add Redis environment variables for OpenWebUI
-		envVars = append(envVars, []corev1.EnvVar{
-			{Name: "REDIS_URL", Value: redisURL},
-		}...)
-	}
+	// Create the admin before anyone can sign up
+	envVars = append(envVars, openWebUIBootstrapEnv(deployment)...)
+
+	// Share websockets and sessions between replicas through Redis
+	envVars = append(envVars, openWebUIRedisEnv(deployment)...)
+
+	// Point OpenWebUI at PostgreSQL instead of the SQLite file in the data volume
+	envVars = append(envVars, openWebUIDatabaseEnv(deployment)...)
+
+	// Point OpenWebUI at its vector database for RAG instead of the embedded Chroma
+	envVars = append(envVars, openWebUIVectorDBEnv(deployment)...)
+
+	// Embed documents with the model served by the deployment instead of inside the OpenWebUI pod
+	envVars = append(envVars, openWebUIEmbeddingsEnv(deployment)...)
 
 	// Add custom environment variables if specified
 	if len(deployment.Spec.OpenWebUI.EnvVars) > 0 {
@@ -363,7 +406,7 @@ func (r *LMDeploymentReconciler) buildOpenWebUIDeployment(deployment *llmgeeperi
 	// Build init container to copy config file
 	initContainer := corev1.Container{
 		Name:    "copy-config",
-		Image:   "busybox:1.35",
+		Image:   r.operatorConfig().Image(r.operatorConfig().Images.Init),
 		Command: []string{"/bin/sh", "-c"},
 		Args: []string{
 			"mkdir -p /app/backend/data && cp /tmp/config/config.json /app/backend/data/config.json && echo 'Config file copied successfully'",
@@ -374,7 +417,7 @@ func (r *LMDeploymentReconciler) buildOpenWebUIDeployment(deployment *llmgeeperi
 	// Build container
 	container := corev1.Container{
 		Name:  "openwebui",
-		Image: deployment.Spec.OpenWebUI.Image,
+		Image: r.imageOrDefault(deployment.Spec.OpenWebUI.Image, r.operatorConfig().Images.OpenWebUI),
 		Ports: []corev1.ContainerPort{
 			{
 				Name:          "http",
@@ -382,7 +425,7 @@ func (r *LMDeploymentReconciler) buildOpenWebUIDeployment(deployment *llmgeeperi
 				Protocol:      corev1.ProtocolTCP,
 			},
 		},
-		Resources:    r.buildResourceRequirements(deployment.Spec.OpenWebUI.Resources),
+		Resources:    r.buildResourceRequirements(operatorconfig.ResourcesOrDefault(deployment.Spec.OpenWebUI.Resources, r.operatorConfig().Resources.OpenWebUI)),
 		Env:          envVars,
 		VolumeMounts: volumeMounts,
 	}
@@ -394,7 +437,7 @@ func (r *LMDeploymentReconciler) buildOpenWebUIDeployment(deployment *llmgeeperi
 			Labels:    labels,
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: &deployment.Spec.OpenWebUI.Replicas,
+			Replicas: r.desiredReplicas(deployment, scheduledReplicas(deployment.Spec.OpenWebUI.Schedules, deployment.Spec.OpenWebUI.Replicas)),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: labels,
 			},
@@ -412,6 +455,10 @@ func (r *LMDeploymentReconciler) buildOpenWebUIDeployment(deployment *llmgeeperi
 		},
 	}
 
+	// Apply pod template overrides
+	r.applyPodTemplateOverrides(&openwebuiDeployment.Spec.Template, deployment.Spec.OpenWebUI.PodTemplateOverrides)
+	r.applyRolloutStrategy(openwebuiDeployment, deployment.Spec.OpenWebUI.Rollout)
+
 	// Set owner reference
 	_ = controllerutil.SetControllerReference(deployment, openwebuiDeployment, r.Scheme)
 	return openwebuiDeployment
@@ -427,10 +474,7 @@ func (r *LMDeploymentReconciler) buildPipelinesDeployment(deployment *llmgeeperi
 	pipelinesSpec := deployment.Spec.OpenWebUI.Pipelines
 
 	// Set default values
-	image := pipelinesSpec.Image
-	if image == "" {
-		image = "ghcr.io/open-webui/pipelines:main"
-	}
+	image := r.imageOrDefault(pipelinesSpec.Image, r.operatorConfig().Images.Pipelines)
 
 	port := pipelinesSpec.Port
 	if port == 0 {
@@ -453,7 +497,7 @@ func (r *LMDeploymentReconciler) buildPipelinesDeployment(deployment *llmgeeperi
 	// Add PIPELINES_API_KEY if pipelines are enabled
 	if deployment.Spec.OpenWebUI.Pipelines != nil && deployment.Spec.OpenWebUI.Pipelines.Enabled {
 		// Read PIPELINES_API_KEY from the pipelines secret
-		secretName := fmt.Sprintf("%s-pipelines-secret", deployment.Name)
+		secretName := deployment.GetPipelinesSecretName()
 		envVars = append(envVars, corev1.EnvVar{
 			Name: "PIPELINES_API_KEY",
 			ValueFrom: &corev1.EnvVarSource{
@@ -532,7 +576,7 @@ func (r *LMDeploymentReconciler) buildPipelinesDeployment(deployment *llmgeeperi
 
 	// Automatically add Langfuse monitoring pipeline if Langfuse is enabled
 	if deployment.Spec.OpenWebUI.Langfuse != nil && deployment.Spec.OpenWebUI.Langfuse.Enabled {
-		langfusePipelineURL := "https://github.com/open-webui/pipelines/blob/main/examples/filters/langfuse_filter_pipeline.py"
+		langfusePipelineURL := r.operatorConfig().LangfusePipelineURL
 
 		// Check if Langfuse pipeline is already in the list
 		langfusePipelineExists := false
@@ -571,12 +615,10 @@ func (r *LMDeploymentReconciler) buildPipelinesDeployment(deployment *llmgeeperi
 		Env: envVars,
 	}
 
-	// Add resource requirements if specified
-	if pipelinesSpec.Resources.Requests != nil || pipelinesSpec.Resources.Limits != nil {
-		container.Resources = corev1.ResourceRequirements{
-			Requests: pipelinesSpec.Resources.Requests,
-			Limits:   pipelinesSpec.Resources.Limits,
-		}
+	// Add resource requirements if specified, falling back to the operator defaults
+	resources := operatorconfig.ResourcesOrDefault(pipelinesSpec.Resources, r.operatorConfig().Resources.Pipelines)
+	if resources.Requests != nil || resources.Limits != nil {
+		container.Resources = r.buildResourceRequirements(resources)
 	}
 
 	// Add volume mounts and volumes if specified
@@ -619,7 +661,7 @@ func (r *LMDeploymentReconciler) buildPipelinesDeployment(deployment *llmgeeperi
 			Labels:    labels,
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: &replicas,
+			Replicas: r.desiredReplicas(deployment, replicas),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: labels,
 			},
@@ -635,6 +677,10 @@ func (r *LMDeploymentReconciler) buildPipelinesDeployment(deployment *llmgeeperi
 		},
 	}
 
+	// Apply pod template overrides
+	r.applyPodTemplateOverrides(&deploymentObj.Spec.Template, pipelinesSpec.PodTemplateOverrides)
+	r.applyRolloutStrategy(deploymentObj, pipelinesSpec.Rollout)
+
 	// Set owner reference
 	_ = controllerutil.SetControllerReference(deployment, deploymentObj, r.Scheme)
 	return deploymentObj
@@ -699,8 +745,6 @@ func (r *LMDeploymentReconciler) buildPipelinesPVC(deployment *llmgeeperiov1alph
 		size = "10Gi"
 	}
 
-	storageClass := persistenceSpec.StorageClass
-
 	pvc := &corev1.PersistentVolumeClaim{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      fmt.Sprintf("%s-pipelines-data", deployment.Name),
@@ -720,9 +764,7 @@ func (r *LMDeploymentReconciler) buildPipelinesPVC(deployment *llmgeeperiov1alph
 	}
 
 	// Add storage class if specified
-	if storageClass != "" {
-		pvc.Spec.StorageClassName = &storageClass
-	}
+	pvc.Spec.StorageClassName = r.operatorConfig().StorageClassOrDefault(persistenceSpec.StorageClass)
 
 	// Note: We don't set controller reference on PVCs because they should persist
 	// even if the LMDeployment is deleted to preserve user data
@@ -768,45 +810,20 @@ func (r *LMDeploymentReconciler) buildOpenWebUIIngress(deployment *llmgeeperiov1
 		"llm-deployment": deployment.Name,
 	}
 
-	serviceName := deployment.GetOpenWebUIServiceName()
-	pathType := networkingv1.PathTypePrefix
+	ingressSpec := deployment.Spec.OpenWebUI.Ingress
+	return r.buildIngress(deployment, deployment.GetOpenWebUIIngressName(), labels, ingressSpec, ingressSpec.Host,
+		deployment.GetOpenWebUIServiceName(), deployment.Spec.OpenWebUI.Service.Port)
+}
 
-	ingress := &networkingv1.Ingress{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      deployment.GetOpenWebUIIngressName(),
-			Namespace: deployment.Namespace,
-			Labels:    labels,
-		},
-		Spec: networkingv1.IngressSpec{
-			Rules: []networkingv1.IngressRule{
-				{
-					Host: deployment.Spec.OpenWebUI.Ingress.Host,
-					IngressRuleValue: networkingv1.IngressRuleValue{
-						HTTP: &networkingv1.HTTPIngressRuleValue{
-							Paths: []networkingv1.HTTPIngressPath{
-								{
-									Path:     "/",
-									PathType: &pathType,
-									Backend: networkingv1.IngressBackend{
-										Service: &networkingv1.IngressServiceBackend{
-											Name: serviceName,
-											Port: networkingv1.ServiceBackendPort{
-												Number: deployment.Spec.OpenWebUI.Service.Port,
-											},
-										},
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-		},
+// buildOpenWebUIHTTPRoute builds the OpenWebUI HTTPRoute object
+func (r *LMDeploymentReconciler) buildOpenWebUIHTTPRoute(deployment *llmgeeperiov1alpha1.LMDeployment) *unstructured.Unstructured {
+	labels := map[string]string{
+		"app":            "openwebui",
+		"llm-deployment": deployment.Name,
 	}
 
-	// Set owner reference
-	_ = controllerutil.SetControllerReference(deployment, ingress, r.Scheme)
-	return ingress
+	return r.buildHTTPRoute(deployment, deployment.GetOpenWebUIHTTPRouteName(), labels, *deployment.Spec.OpenWebUI.Gateway,
+		deployment.GetOpenWebUIServiceName(), deployment.Spec.OpenWebUI.Service.Port)
 }
 
 // buildOpenWebUISecret builds the OpenWebUI secret for WEBUI_SECRET_KEY
@@ -819,7 +836,7 @@ func (r *LMDeploymentReconciler) buildOpenWebUISecret(deployment *llmgeeperiov1a
 
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-openwebui-secret", deployment.Name),
+			Name:      deployment.GetOpenWebUISecretName(),
 			Namespace: deployment.Namespace,
 			Labels: map[string]string{
 				"app":            "openwebui",
@@ -838,46 +855,24 @@ func (r *LMDeploymentReconciler) buildOpenWebUISecret(deployment *llmgeeperiov1a
 
 // buildOpenWebUIConfig creates the config.json Secret for OpenWebUI
 func (r *LMDeploymentReconciler) buildOpenWebUIConfig(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment) (*corev1.Secret, error) {
-	// Base configuration based on the provided config file
-	config := map[string]interface{}{
-		"version": 0,
-		"ui": map[string]interface{}{
-			"enable_signup": true,
-		},
-		"openai": map[string]interface{}{
-			"enable":        true,
-			"api_base_urls": []string{},
-			"api_keys":      []string{},
-			"api_configs":   map[string]interface{}{},
-		},
-	}
-
-	// Add pipeline configuration if enabled
+	// The pipelines API key is generated on first use
 	if deployment.Spec.OpenWebUI.Pipelines != nil && deployment.Spec.OpenWebUI.Pipelines.Enabled {
-		// Get API key from the existing pipelines secret
-		apiKey, err := r.ensurePipelineSecret(ctx, deployment)
-		if err != nil {
+		if _, err := r.ensurePipelineSecret(ctx, deployment); err != nil {
 			return nil, fmt.Errorf("failed to ensure pipeline secret: %w", err)
 		}
+	}
 
-		// Add pipeline to OpenAI api_base_urls and api_configs
-		openaiConfig := config["openai"].(map[string]interface{})
-		openaiConfig["api_base_urls"] = []string{
-			fmt.Sprintf("http://%s:9099", deployment.GetPipelinesServiceName()),
-		}
-		openaiConfig["api_configs"] = map[string]interface{}{
-			"0": map[string]interface{}{
-				"enable":          true,
-				"tags":            []string{},
-				"prefix_id":       "",
-				"model_ids":       []string{},
-				"connection_type": "local",
-			},
-		}
-		openaiConfig["api_keys"] = []string{
-			apiKey,
-		}
-		config["openai"] = openaiConfig
+	openaiConfig, ollamaConfig, err := r.openWebUIConnectionsConfig(ctx, deployment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build OpenWebUI connections: %w", err)
+	}
+
+	// Base configuration based on the provided config file
+	config := map[string]interface{}{
+		"version": 0,
+		"ui":      openWebUIUIConfig(deployment),
+		"openai":  openaiConfig,
+		"ollama":  ollamaConfig,
 	}
 
 	// Convert config to JSON
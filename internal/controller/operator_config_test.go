@@ -0,0 +1,85 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+	operatorconfig "github.com/geeper-io/llm-operator/internal/config"
+)
+
+func TestOperatorConfig_Builders(t *testing.T) {
+	store, err := operatorconfig.NewStore("")
+	require.NoError(t, err)
+
+	reconciler := &LMDeploymentReconciler{Scheme: newTestScheme(t), Config: store}
+	deployment := &llmgeeperiov1alpha1.LMDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "default"},
+		Spec: llmgeeperiov1alpha1.LMDeploymentSpec{
+			Tabby: llmgeeperiov1alpha1.TabbySpec{
+				Enabled: true,
+				Persistence: llmgeeperiov1alpha1.TabbyPersistenceSpec{
+					Enabled: true,
+				},
+			},
+		},
+	}
+
+	t.Run("should use the built-in defaults", func(t *testing.T) {
+		tabby := reconciler.buildTabbyDeployment(deployment)
+		assert.Equal(t, "tabbyml/tabby:latest", tabby.Spec.Template.Spec.Containers[0].Image)
+		assert.Equal(t, "busybox:1.35", tabby.Spec.Template.Spec.InitContainers[0].Image)
+		assert.Nil(t, reconciler.buildTabbyPVC(deployment).Spec.StorageClassName)
+	})
+
+	t.Run("should pick up a reloaded config", func(t *testing.T) {
+		cfg := operatorconfig.Default()
+		cfg.RegistryMirror = "registry.internal/mirror"
+		cfg.Images.Tabby = "tabbyml/tabby:0.30.0"
+		cfg.StorageClass = "fast"
+		cfg.Resources.Tabby = llmgeeperiov1alpha1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+		}
+		store.Set(cfg)
+
+		tabby := reconciler.buildTabbyDeployment(deployment)
+		container := tabby.Spec.Template.Spec.Containers[0]
+		assert.Equal(t, "registry.internal/mirror/tabbyml/tabby:0.30.0", container.Image)
+		assert.Equal(t, "registry.internal/mirror/busybox:1.35", tabby.Spec.Template.Spec.InitContainers[0].Image)
+		assert.Equal(t, resource.MustParse("4Gi"), container.Resources.Limits[corev1.ResourceMemory])
+		assert.Equal(t, "fast", *reconciler.buildTabbyPVC(deployment).Spec.StorageClassName)
+	})
+
+	t.Run("should prefer values set on the deployment", func(t *testing.T) {
+		deployment.Spec.Tabby.Image = "tabbyml/tabby:custom"
+		deployment.Spec.Tabby.Persistence.StorageClass = "standard"
+		deployment.Spec.Tabby.Resources.Requests = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}
+
+		tabby := reconciler.buildTabbyDeployment(deployment)
+		container := tabby.Spec.Template.Spec.Containers[0]
+		assert.Equal(t, "tabbyml/tabby:custom", container.Image)
+		assert.Empty(t, container.Resources.Limits)
+		assert.Equal(t, "standard", *reconciler.buildTabbyPVC(deployment).Spec.StorageClassName)
+	})
+}
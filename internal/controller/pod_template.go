@@ -0,0 +1,129 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+// applyPodTemplateOverrides applies the common pod template overrides of a component to the pod template of a generated workload
+func (r *LMDeploymentReconciler) applyPodTemplateOverrides(template *corev1.PodTemplateSpec, overrides llmgeeperiov1alpha1.PodTemplateOverrides) {
+	// Merge into new maps as the selector may share the labels map, generated values win
+	if len(overrides.PodLabels) > 0 {
+		template.Labels = mergeStringMaps(overrides.PodLabels, template.Labels)
+	}
+	if len(overrides.PodAnnotations) > 0 {
+		template.Annotations = mergeStringMaps(overrides.PodAnnotations, template.Annotations)
+	}
+
+	podSpec := &template.Spec
+	if len(overrides.NodeSelector) > 0 {
+		podSpec.NodeSelector = overrides.NodeSelector
+	}
+	if len(overrides.Tolerations) > 0 {
+		podSpec.Tolerations = overrides.Tolerations
+	}
+	if overrides.PriorityClassName != "" {
+		podSpec.PriorityClassName = overrides.PriorityClassName
+	}
+	if len(overrides.TopologySpreadConstraints) > 0 {
+		podSpec.TopologySpreadConstraints = overrides.TopologySpreadConstraints
+	}
+	if overrides.PodSecurityContext != nil {
+		podSpec.SecurityContext = overrides.PodSecurityContext
+	}
+	if len(overrides.ImagePullSecrets) > 0 {
+		podSpec.ImagePullSecrets = overrides.ImagePullSecrets
+	}
+	if overrides.ServiceAccountName != "" {
+		podSpec.ServiceAccountName = overrides.ServiceAccountName
+	}
+	if overrides.RuntimeClassName != nil {
+		podSpec.RuntimeClassName = overrides.RuntimeClassName
+	}
+	if overrides.TerminationGracePeriodSeconds != nil {
+		podSpec.TerminationGracePeriodSeconds = overrides.TerminationGracePeriodSeconds
+	}
+
+	if overrides.SecurityContext != nil {
+		for i := range podSpec.InitContainers {
+			podSpec.InitContainers[i].SecurityContext = overrides.SecurityContext
+		}
+		for i := range podSpec.Containers {
+			podSpec.Containers[i].SecurityContext = overrides.SecurityContext
+		}
+	}
+}
+
+// mergePodTemplateOverrides fills unset fields of the overrides with the given defaults.
+// Labels and annotations are merged, with the overrides taking precedence.
+func mergePodTemplateOverrides(overrides llmgeeperiov1alpha1.PodTemplateOverrides, defaults *llmgeeperiov1alpha1.PodTemplateOverrides) llmgeeperiov1alpha1.PodTemplateOverrides {
+	if defaults == nil {
+		return overrides
+	}
+
+	merged := overrides
+	if len(merged.NodeSelector) == 0 {
+		merged.NodeSelector = defaults.NodeSelector
+	}
+	if len(merged.Tolerations) == 0 {
+		merged.Tolerations = defaults.Tolerations
+	}
+	if merged.PriorityClassName == "" {
+		merged.PriorityClassName = defaults.PriorityClassName
+	}
+	if len(merged.TopologySpreadConstraints) == 0 {
+		merged.TopologySpreadConstraints = defaults.TopologySpreadConstraints
+	}
+	if merged.PodSecurityContext == nil {
+		merged.PodSecurityContext = defaults.PodSecurityContext
+	}
+	if merged.SecurityContext == nil {
+		merged.SecurityContext = defaults.SecurityContext
+	}
+	if len(merged.ImagePullSecrets) == 0 {
+		merged.ImagePullSecrets = defaults.ImagePullSecrets
+	}
+	if merged.ServiceAccountName == "" {
+		merged.ServiceAccountName = defaults.ServiceAccountName
+	}
+	if merged.RuntimeClassName == nil {
+		merged.RuntimeClassName = defaults.RuntimeClassName
+	}
+	if merged.TerminationGracePeriodSeconds == nil {
+		merged.TerminationGracePeriodSeconds = defaults.TerminationGracePeriodSeconds
+	}
+	merged.PodLabels = mergeStringMaps(defaults.PodLabels, overrides.PodLabels)
+	merged.PodAnnotations = mergeStringMaps(defaults.PodAnnotations, overrides.PodAnnotations)
+	return merged
+}
+
+// mergeStringMaps merges the given maps into a new map, later maps take precedence
+func mergeStringMaps(maps ...map[string]string) map[string]string {
+	var merged map[string]string
+	for _, m := range maps {
+		for key, value := range m {
+			if merged == nil {
+				merged = map[string]string{}
+			}
+			merged[key] = value
+		}
+	}
+	return merged
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+func TestPodTemplateOverrides_Apply(t *testing.T) {
+	reconciler := &LMDeploymentReconciler{Scheme: newTestScheme(t)}
+	deployment := &llmgeeperiov1alpha1.LMDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "default"},
+		Spec: llmgeeperiov1alpha1.LMDeploymentSpec{
+			Tabby: llmgeeperiov1alpha1.TabbySpec{
+				Enabled: true,
+				PodTemplateOverrides: llmgeeperiov1alpha1.PodTemplateOverrides{
+					NodeSelector:       map[string]string{"pool": "gpu"},
+					Tolerations:        []corev1.Toleration{{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists}},
+					PriorityClassName:  "high",
+					PodLabels:          map[string]string{"team": "ml", "app": "overridden"},
+					PodAnnotations:     map[string]string{"example.com/owner": "ml"},
+					ImagePullSecrets:   []corev1.LocalObjectReference{{Name: "registry"}},
+					ServiceAccountName: "tabby",
+					RuntimeClassName:   ptr.To("nvidia"),
+					SecurityContext:    &corev1.SecurityContext{RunAsNonRoot: ptr.To(true)},
+					PodSecurityContext: &corev1.PodSecurityContext{FSGroup: ptr.To(int64(1000))},
+
+					TerminationGracePeriodSeconds: ptr.To(int64(60)),
+				},
+			},
+		},
+	}
+
+	tabby := reconciler.buildTabbyDeployment(deployment)
+	podSpec := tabby.Spec.Template.Spec
+
+	assert.Equal(t, map[string]string{"pool": "gpu"}, podSpec.NodeSelector)
+	assert.Len(t, podSpec.Tolerations, 1)
+	assert.Equal(t, "high", podSpec.PriorityClassName)
+	assert.Equal(t, "tabby", podSpec.ServiceAccountName)
+	assert.Equal(t, "nvidia", *podSpec.RuntimeClassName)
+	assert.Equal(t, int64(60), *podSpec.TerminationGracePeriodSeconds)
+	assert.Equal(t, int64(1000), *podSpec.SecurityContext.FSGroup)
+	assert.Equal(t, "registry", podSpec.ImagePullSecrets[0].Name)
+	for _, container := range append(podSpec.InitContainers, podSpec.Containers...) {
+		require.NotNil(t, container.SecurityContext, container.Name)
+		assert.True(t, *container.SecurityContext.RunAsNonRoot, container.Name)
+	}
+
+	t.Run("should keep generated labels and leave the selector untouched", func(t *testing.T) {
+		assert.Equal(t, "tabby", tabby.Spec.Template.Labels["app"])
+		assert.Equal(t, "ml", tabby.Spec.Template.Labels["team"])
+		assert.NotContains(t, tabby.Spec.Selector.MatchLabels, "team")
+		assert.Equal(t, "ml", tabby.Spec.Template.Annotations["example.com/owner"])
+	})
+}
+
+func TestPodTemplateOverrides_VLLMGlobalDefaults(t *testing.T) {
+	reconciler := &LMDeploymentReconciler{Scheme: newTestScheme(t)}
+	deployment := &llmgeeperiov1alpha1.LMDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "default"},
+		Spec: llmgeeperiov1alpha1.LMDeploymentSpec{
+			VLLM: llmgeeperiov1alpha1.VLLMSpec{
+				Enabled: true,
+				GlobalConfig: &llmgeeperiov1alpha1.VLLMGlobalConfig{
+					PodTemplateOverrides: llmgeeperiov1alpha1.PodTemplateOverrides{
+						NodeSelector:      map[string]string{"pool": "gpu"},
+						PriorityClassName: "default-priority",
+						PodLabels:         map[string]string{"team": "ml", "tier": "default"},
+					},
+				},
+				Models: []llmgeeperiov1alpha1.VLLMModelSpec{
+					{
+						Name:  "llama",
+						Model: "meta-llama/Llama-2-7b-chat-hf",
+						PodTemplateOverrides: llmgeeperiov1alpha1.PodTemplateOverrides{
+							PriorityClassName: "model-priority",
+							PodLabels:         map[string]string{"tier": "model"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	vllm := reconciler.buildVLLMModelDeployment(deployment, deployment.Spec.VLLM.Models[0])
+	podSpec := vllm.Spec.Template.Spec
+
+	assert.Equal(t, map[string]string{"pool": "gpu"}, podSpec.NodeSelector, "unset fields fall back to the global config")
+	assert.Equal(t, "model-priority", podSpec.PriorityClassName, "model fields take precedence")
+	assert.Equal(t, "ml", vllm.Spec.Template.Labels["team"])
+	assert.Equal(t, "model", vllm.Spec.Template.Labels["tier"])
+}
@@ -0,0 +1,308 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+	operatorconfig "github.com/geeper-io/llm-operator/internal/config"
+)
+
+const (
+	// postgresPort is the port PostgreSQL listens on
+	postgresPort = 5432
+
+	// postgresUser is the user created for OpenWebUI
+	postgresUser = "openwebui"
+
+	// postgresPasswordKey is the key of the generated password in the PostgreSQL secret
+	postgresPasswordKey = "POSTGRES_PASSWORD"
+)
+
+// postgresDeployed reports whether the operator runs a PostgreSQL instance for OpenWebUI
+func postgresDeployed(deployment *llmgeeperiov1alpha1.LMDeployment) bool {
+	return deployment.Spec.OpenWebUI.Enabled && deployment.Spec.OpenWebUI.DatabaseType() == llmgeeperiov1alpha1.DatabaseTypePostgres
+}
+
+// postgresDatabase returns the name of the database created for OpenWebUI
+func postgresDatabase(deployment *llmgeeperiov1alpha1.LMDeployment) string {
+	if database := deployment.Spec.OpenWebUI.Database; database != nil && database.Postgres.Database != "" {
+		return database.Postgres.Database
+	}
+	return "openwebui"
+}
+
+// reconcilePostgres reconciles the PostgreSQL deployment for OpenWebUI, and removes it once OpenWebUI uses another database.
+// The data volume and the credentials are kept, like the other PVCs.
+func (r *LMDeploymentReconciler) reconcilePostgres(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment) error {
+	if !postgresDeployed(deployment) {
+		if err := r.deleteControlled(ctx, deployment, &appsv1.Deployment{}, deployment.GetPostgresDeploymentName()); err != nil {
+			return err
+		}
+		return r.deleteControlled(ctx, deployment, &corev1.Service{}, deployment.GetPostgresServiceName())
+	}
+
+	// Generate the credentials once, PostgreSQL only applies them when it initializes the data volume
+	secret, err := r.buildPostgresSecret(deployment)
+	if err != nil {
+		return err
+	}
+	if err := r.createSecretIfNotExists(ctx, secret); err != nil {
+		return err
+	}
+
+	if err := r.ensurePVC(ctx, r.buildPostgresPVC(deployment)); err != nil {
+		return err
+	}
+
+	postgresDeployment := r.buildPostgresDeployment(deployment)
+	r.pinImageDigests(deployment, &postgresDeployment.Spec.Template)
+	if err := r.createOrUpdateDeployment(ctx, postgresDeployment); err != nil {
+		return err
+	}
+
+	return r.createOrUpdateService(ctx, r.buildPostgresService(deployment))
+}
+
+// buildPostgresSecret builds the secret holding a newly generated PostgreSQL password
+func (r *LMDeploymentReconciler) buildPostgresSecret(deployment *llmgeeperiov1alpha1.LMDeployment) (*corev1.Secret, error) {
+	// Hex keeps the password usable in the DATABASE_URL without escaping
+	secret, err := buildVolumeSecret(deployment, deployment.GetPostgresSecretName(), "postgres", postgresPasswordKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate PostgreSQL password: %w", err)
+	}
+	return secret, nil
+}
+
+// postgresPasswordEnv returns the env var reading the generated PostgreSQL password
+func postgresPasswordEnv(deployment *llmgeeperiov1alpha1.LMDeployment) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: postgresPasswordKey,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: deployment.GetPostgresSecretName(),
+				},
+				Key: postgresPasswordKey,
+			},
+		},
+	}
+}
+
+// openWebUIDatabaseEnv returns the env vars pointing OpenWebUI at its database, none for SQLite
+func openWebUIDatabaseEnv(deployment *llmgeeperiov1alpha1.LMDeployment) []corev1.EnvVar {
+	switch deployment.Spec.OpenWebUI.DatabaseType() {
+	case llmgeeperiov1alpha1.DatabaseTypeExternal:
+		return []corev1.EnvVar{
+			{
+				Name:      "DATABASE_URL",
+				ValueFrom: &corev1.EnvVarSource{SecretKeyRef: deployment.Spec.OpenWebUI.Database.URLSecretRef},
+			},
+		}
+	case llmgeeperiov1alpha1.DatabaseTypePostgres:
+		// The password is expanded from the preceding env var
+		return []corev1.EnvVar{
+			postgresPasswordEnv(deployment),
+			{
+				Name: "DATABASE_URL",
+				Value: fmt.Sprintf("postgresql://%s:$(%s)@%s:%d/%s",
+					postgresUser, postgresPasswordKey, deployment.GetPostgresServiceName(), postgresPort, postgresDatabase(deployment)),
+			},
+		}
+	default:
+		return nil
+	}
+}
+
+// openWebUIDatabaseSecret returns the name of the secret holding the database credentials of OpenWebUI, if any
+func openWebUIDatabaseSecret(deployment *llmgeeperiov1alpha1.LMDeployment) string {
+	switch deployment.Spec.OpenWebUI.DatabaseType() {
+	case llmgeeperiov1alpha1.DatabaseTypeExternal:
+		return deployment.Spec.OpenWebUI.Database.URLSecretRef.Name
+	case llmgeeperiov1alpha1.DatabaseTypePostgres:
+		return deployment.GetPostgresSecretName()
+	default:
+		return ""
+	}
+}
+
+// buildPostgresDeployment builds the PostgreSQL deployment object
+func (r *LMDeploymentReconciler) buildPostgresDeployment(deployment *llmgeeperiov1alpha1.LMDeployment) *appsv1.Deployment {
+	labels := map[string]string{
+		"app":            "postgres",
+		"llm-deployment": deployment.Name,
+	}
+	var spec llmgeeperiov1alpha1.PostgresSpec
+	if deployment.Spec.OpenWebUI.Database != nil {
+		spec = deployment.Spec.OpenWebUI.Database.Postgres
+	}
+
+	postgresDeployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deployment.GetPostgresDeploymentName(),
+			Namespace: deployment.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: r.desiredReplicas(deployment, 1), // PostgreSQL runs a single instance on its volume
+			// The volume can only be mounted by one pod, stop the old one first
+			Strategy: appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "postgres",
+							Image: r.imageOrDefault(spec.Image, r.operatorConfig().Images.Postgres),
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "postgres",
+									ContainerPort: postgresPort,
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
+							Env: []corev1.EnvVar{
+								{Name: "POSTGRES_USER", Value: postgresUser},
+								{Name: "POSTGRES_DB", Value: postgresDatabase(deployment)},
+								postgresPasswordEnv(deployment),
+								// A subdirectory, the mount point of some volumes isn't empty
+								{Name: "PGDATA", Value: "/var/lib/postgresql/data/pgdata"},
+							},
+							Resources: r.buildResourceRequirements(operatorconfig.ResourcesOrDefault(spec.Resources, r.operatorConfig().Resources.Postgres)),
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									Exec: &corev1.ExecAction{
+										Command: []string{"sh", "-c", `pg_isready -U "$POSTGRES_USER" -d "$POSTGRES_DB"`},
+									},
+								},
+								PeriodSeconds: 10,
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "postgres-data",
+									MountPath: "/var/lib/postgresql/data",
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "postgres-data",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: deployment.GetPostgresPVCName(),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Apply pod template overrides
+	r.applyPodTemplateOverrides(&postgresDeployment.Spec.Template, spec.PodTemplateOverrides)
+
+	// Set owner reference
+	_ = controllerutil.SetControllerReference(deployment, postgresDeployment, r.Scheme)
+	return postgresDeployment
+}
+
+// buildPostgresService builds the PostgreSQL service object
+func (r *LMDeploymentReconciler) buildPostgresService(deployment *llmgeeperiov1alpha1.LMDeployment) *corev1.Service {
+	labels := map[string]string{
+		"app":            "postgres",
+		"llm-deployment": deployment.Name,
+	}
+
+	postgresService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deployment.GetPostgresServiceName(),
+			Namespace: deployment.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "postgres",
+					Port:       postgresPort,
+					TargetPort: intstr.FromInt32(postgresPort),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+			Selector: labels,
+		},
+	}
+
+	// Set owner reference
+	_ = controllerutil.SetControllerReference(deployment, postgresService, r.Scheme)
+	return postgresService
+}
+
+// buildPostgresPVC builds the PostgreSQL PVC object
+func (r *LMDeploymentReconciler) buildPostgresPVC(deployment *llmgeeperiov1alpha1.LMDeployment) *corev1.PersistentVolumeClaim {
+	labels := map[string]string{
+		"app":            "postgres",
+		"llm-deployment": deployment.Name,
+	}
+
+	// Set default size if not specified
+	size := "10Gi"
+	var storageClass string
+	if database := deployment.Spec.OpenWebUI.Database; database != nil {
+		if database.Postgres.Persistence.Size != "" {
+			size = database.Postgres.Persistence.Size
+		}
+		storageClass = database.Postgres.Persistence.StorageClass
+	}
+
+	// Note: We don't set controller reference on PVCs because they should persist
+	// even if the LMDeployment is deleted to preserve user data
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deployment.GetPostgresPVCName(),
+			Namespace: deployment.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{
+				corev1.ReadWriteOnce,
+			},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse(size),
+				},
+			},
+			StorageClassName: r.operatorConfig().StorageClassOrDefault(storageClass),
+		},
+	}
+}
@@ -0,0 +1,347 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+	operatorconfig "github.com/geeper-io/llm-operator/internal/config"
+)
+
+const (
+	// qdrantPort is the port of the Qdrant HTTP API
+	qdrantPort = 6333
+
+	// qdrantAPIKeyKey is the key of the generated API key in the Qdrant secret
+	qdrantAPIKeyKey = "QDRANT_API_KEY"
+)
+
+// vectorDBEnvNames are the OpenWebUI env vars of the URL, the API key and the user of each vector database type
+var vectorDBEnvNames = map[string]struct{ url, apiKey, username string }{
+	llmgeeperiov1alpha1.VectorDBTypeQdrant:     {url: "QDRANT_URI", apiKey: "QDRANT_API_KEY"},
+	llmgeeperiov1alpha1.VectorDBTypeMilvus:     {url: "MILVUS_URI", apiKey: "MILVUS_TOKEN"},
+	llmgeeperiov1alpha1.VectorDBTypePgvector:   {url: "PGVECTOR_DB_URL"},
+	llmgeeperiov1alpha1.VectorDBTypeOpenSearch: {url: "OPENSEARCH_URI", apiKey: "OPENSEARCH_PASSWORD", username: "OPENSEARCH_USERNAME"},
+}
+
+// qdrantDeployed reports whether the operator runs a Qdrant instance for OpenWebUI
+func qdrantDeployed(deployment *llmgeeperiov1alpha1.LMDeployment) bool {
+	openwebui := deployment.Spec.OpenWebUI
+	return openwebui.Enabled && openwebui.VectorDBType() == llmgeeperiov1alpha1.VectorDBTypeQdrant && openwebui.VectorDB.External == nil
+}
+
+// reconcileQdrant reconciles the Qdrant deployment for OpenWebUI, and removes it once OpenWebUI uses another vector database.
+// The storage volume is kept, like the other PVCs.
+func (r *LMDeploymentReconciler) reconcileQdrant(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment) error {
+	if !qdrantDeployed(deployment) {
+		if err := r.deleteControlled(ctx, deployment, &appsv1.Deployment{}, deployment.GetQdrantDeploymentName()); err != nil {
+			return err
+		}
+		return r.deleteControlled(ctx, deployment, &corev1.Service{}, deployment.GetQdrantServiceName())
+	}
+
+	secret, err := r.buildQdrantSecret(deployment)
+	if err != nil {
+		return err
+	}
+	if err := r.createSecretIfNotExists(ctx, secret); err != nil {
+		return err
+	}
+
+	if err := r.ensurePVC(ctx, r.buildQdrantPVC(deployment)); err != nil {
+		return err
+	}
+
+	qdrantDeployment := r.buildQdrantDeployment(deployment)
+	r.pinImageDigests(deployment, &qdrantDeployment.Spec.Template)
+	if err := r.createOrUpdateDeployment(ctx, qdrantDeployment); err != nil {
+		return err
+	}
+
+	return r.createOrUpdateService(ctx, r.buildQdrantService(deployment))
+}
+
+// buildQdrantSecret builds the secret holding a newly generated Qdrant API key
+func (r *LMDeploymentReconciler) buildQdrantSecret(deployment *llmgeeperiov1alpha1.LMDeployment) (*corev1.Secret, error) {
+	secret, err := buildVolumeSecret(deployment, deployment.GetQdrantSecretName(), "qdrant", qdrantAPIKeyKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate Qdrant API key: %w", err)
+	}
+	return secret, nil
+}
+
+// qdrantAPIKeyRef references the generated Qdrant API key
+func qdrantAPIKeyRef(deployment *llmgeeperiov1alpha1.LMDeployment) *corev1.SecretKeySelector {
+	return &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: deployment.GetQdrantSecretName(),
+		},
+		Key: qdrantAPIKeyKey,
+	}
+}
+
+// openWebUIVectorDBEnv returns the env vars pointing OpenWebUI at its vector database, none for the embedded Chroma
+func openWebUIVectorDBEnv(deployment *llmgeeperiov1alpha1.LMDeployment) []corev1.EnvVar {
+	vectorDBType := deployment.Spec.OpenWebUI.VectorDBType()
+	if vectorDBType == llmgeeperiov1alpha1.VectorDBTypeChroma {
+		return nil
+	}
+
+	envVars := []corev1.EnvVar{{Name: "VECTOR_DB", Value: vectorDBType}}
+	if qdrantDeployed(deployment) {
+		return append(envVars,
+			corev1.EnvVar{Name: "QDRANT_URI", Value: fmt.Sprintf("http://%s:%d", deployment.GetQdrantServiceName(), qdrantPort)},
+			corev1.EnvVar{Name: "QDRANT_API_KEY", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: qdrantAPIKeyRef(deployment)}},
+		)
+	}
+
+	external := deployment.Spec.OpenWebUI.VectorDB.External
+	names := vectorDBEnvNames[vectorDBType]
+	envVars = append(envVars, corev1.EnvVar{Name: names.url, ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &external.URLSecretRef}})
+	if external.APIKeySecretRef != nil && names.apiKey != "" {
+		envVars = append(envVars, corev1.EnvVar{Name: names.apiKey, ValueFrom: &corev1.EnvVarSource{SecretKeyRef: external.APIKeySecretRef}})
+	}
+	if external.Username != "" && names.username != "" {
+		envVars = append(envVars, corev1.EnvVar{Name: names.username, Value: external.Username})
+	}
+	return envVars
+}
+
+// openWebUIVectorDBSecrets returns the secret key references OpenWebUI reads its vector database connection from
+func openWebUIVectorDBSecrets(deployment *llmgeeperiov1alpha1.LMDeployment) []*corev1.SecretKeySelector {
+	if deployment.Spec.OpenWebUI.VectorDBType() == llmgeeperiov1alpha1.VectorDBTypeChroma {
+		return nil
+	}
+	if qdrantDeployed(deployment) {
+		return []*corev1.SecretKeySelector{qdrantAPIKeyRef(deployment)}
+	}
+	external := deployment.Spec.OpenWebUI.VectorDB.External
+	refs := []*corev1.SecretKeySelector{&external.URLSecretRef}
+	if external.APIKeySecretRef != nil {
+		refs = append(refs, external.APIKeySecretRef)
+	}
+	return refs
+}
+
+// setVectorDBCondition reports whether the Qdrant run by the operator is ready, or the secrets of an external vector database exist
+func (r *LMDeploymentReconciler) setVectorDBCondition(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment) {
+	conditions := &deployment.Status.OpenWebUIStatus.Conditions
+	vectorDBType := deployment.Spec.OpenWebUI.VectorDBType()
+	if vectorDBType == llmgeeperiov1alpha1.VectorDBTypeChroma {
+		meta.RemoveStatusCondition(conditions, llmgeeperiov1alpha1.ConditionVectorDBReady)
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:               llmgeeperiov1alpha1.ConditionVectorDBReady,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: deployment.Generation,
+	}
+	if qdrantDeployed(deployment) {
+		qdrant := &appsv1.Deployment{}
+		err := r.Get(ctx, types.NamespacedName{Name: deployment.GetQdrantDeploymentName(), Namespace: deployment.Namespace}, qdrant)
+		switch {
+		case err != nil:
+			condition.Status, condition.Reason, condition.Message = metav1.ConditionFalse, "NotFound", fmt.Sprintf("Qdrant deployment %s has not been created yet", deployment.GetQdrantDeploymentName())
+		case qdrant.Status.ReadyReplicas == 0:
+			condition.Status, condition.Reason, condition.Message = metav1.ConditionFalse, "NotReady", "Waiting for Qdrant to become ready"
+		default:
+			condition.Reason, condition.Message = "Ready", "Qdrant is ready"
+		}
+		meta.SetStatusCondition(conditions, condition)
+		return
+	}
+
+	// The operator can't reach into external databases, it only checks their connection details exist
+	condition.Reason, condition.Message = "External", fmt.Sprintf("Using the external %s database", vectorDBType)
+	for _, ref := range openWebUIVectorDBSecrets(deployment) {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: deployment.Namespace}, secret); err != nil {
+			condition.Status, condition.Reason, condition.Message = metav1.ConditionFalse, "SecretNotFound", fmt.Sprintf("Secret %s not found", ref.Name)
+			break
+		}
+		if _, ok := secret.Data[ref.Key]; !ok {
+			condition.Status, condition.Reason, condition.Message = metav1.ConditionFalse, "SecretKeyNotFound", fmt.Sprintf("Key %s not found in secret %s", ref.Key, ref.Name)
+			break
+		}
+	}
+	meta.SetStatusCondition(conditions, condition)
+}
+
+// buildQdrantDeployment builds the Qdrant deployment object
+func (r *LMDeploymentReconciler) buildQdrantDeployment(deployment *llmgeeperiov1alpha1.LMDeployment) *appsv1.Deployment {
+	labels := map[string]string{
+		"app":            "qdrant",
+		"llm-deployment": deployment.Name,
+	}
+	spec := deployment.Spec.OpenWebUI.VectorDB.Qdrant
+
+	qdrantDeployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deployment.GetQdrantDeploymentName(),
+			Namespace: deployment.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: r.desiredReplicas(deployment, 1), // Qdrant runs a single node on its volume
+			// The volume can only be mounted by one pod, stop the old one first
+			Strategy: appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "qdrant",
+							Image: r.imageOrDefault(spec.Image, r.operatorConfig().Images.Qdrant),
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "http",
+									ContainerPort: qdrantPort,
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
+							Env: []corev1.EnvVar{
+								{
+									Name:      "QDRANT__SERVICE__API_KEY",
+									ValueFrom: &corev1.EnvVarSource{SecretKeyRef: qdrantAPIKeyRef(deployment)},
+								},
+							},
+							Resources: r.buildResourceRequirements(operatorconfig.ResourcesOrDefault(spec.Resources, r.operatorConfig().Resources.Qdrant)),
+							// The health endpoints don't require the API key
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									HTTPGet: &corev1.HTTPGetAction{
+										Path: "/readyz",
+										Port: intstr.FromString("http"),
+									},
+								},
+								PeriodSeconds: 10,
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "qdrant-storage",
+									MountPath: "/qdrant/storage",
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "qdrant-storage",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: deployment.GetQdrantPVCName(),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Apply pod template overrides
+	r.applyPodTemplateOverrides(&qdrantDeployment.Spec.Template, spec.PodTemplateOverrides)
+
+	// Set owner reference
+	_ = controllerutil.SetControllerReference(deployment, qdrantDeployment, r.Scheme)
+	return qdrantDeployment
+}
+
+// buildQdrantService builds the Qdrant service object
+func (r *LMDeploymentReconciler) buildQdrantService(deployment *llmgeeperiov1alpha1.LMDeployment) *corev1.Service {
+	labels := map[string]string{
+		"app":            "qdrant",
+		"llm-deployment": deployment.Name,
+	}
+
+	qdrantService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deployment.GetQdrantServiceName(),
+			Namespace: deployment.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "http",
+					Port:       qdrantPort,
+					TargetPort: intstr.FromInt32(qdrantPort),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+			Selector: labels,
+		},
+	}
+
+	// Set owner reference
+	_ = controllerutil.SetControllerReference(deployment, qdrantService, r.Scheme)
+	return qdrantService
+}
+
+// buildQdrantPVC builds the Qdrant PVC object
+func (r *LMDeploymentReconciler) buildQdrantPVC(deployment *llmgeeperiov1alpha1.LMDeployment) *corev1.PersistentVolumeClaim {
+	labels := map[string]string{
+		"app":            "qdrant",
+		"llm-deployment": deployment.Name,
+	}
+	persistence := deployment.Spec.OpenWebUI.VectorDB.Qdrant.Persistence
+
+	// Set default size if not specified
+	size := "10Gi"
+	if persistence.Size != "" {
+		size = persistence.Size
+	}
+
+	// Note: We don't set controller reference on PVCs because they should persist
+	// even if the LMDeployment is deleted to preserve user data
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deployment.GetQdrantPVCName(),
+			Namespace: deployment.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{
+				corev1.ReadWriteOnce,
+			},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse(size),
+				},
+			},
+			StorageClassName: r.operatorConfig().StorageClassOrDefault(persistence.StorageClass),
+		},
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+func TestOpenWebUIVectorDBEnv(t *testing.T) {
+	deployment := &llmgeeperiov1alpha1.LMDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "default"},
+		Spec: llmgeeperiov1alpha1.LMDeploymentSpec{
+			OpenWebUI: llmgeeperiov1alpha1.OpenWebUISpec{Enabled: true},
+		},
+	}
+
+	t.Run("should keep the embedded Chroma by default", func(t *testing.T) {
+		assert.Empty(t, openWebUIVectorDBEnv(deployment))
+		assert.Empty(t, openWebUIVectorDBSecrets(deployment))
+	})
+
+	t.Run("should read the connection of an external database from its secrets", func(t *testing.T) {
+		url := corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "opensearch"}, Key: "url"}
+		password := &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "opensearch"}, Key: "password"}
+		deployment.Spec.OpenWebUI.VectorDB = &llmgeeperiov1alpha1.VectorDBSpec{
+			Type:     llmgeeperiov1alpha1.VectorDBTypeOpenSearch,
+			External: &llmgeeperiov1alpha1.ExternalVectorDBSpec{URLSecretRef: url, APIKeySecretRef: password, Username: "admin"},
+		}
+
+		env := openWebUIVectorDBEnv(deployment)
+		require.Len(t, env, 4)
+		assert.Equal(t, corev1.EnvVar{Name: "VECTOR_DB", Value: "opensearch"}, env[0])
+		assert.Equal(t, "OPENSEARCH_URI", env[1].Name)
+		assert.Equal(t, &url, env[1].ValueFrom.SecretKeyRef)
+		assert.Equal(t, "OPENSEARCH_PASSWORD", env[2].Name)
+		assert.Equal(t, corev1.EnvVar{Name: "OPENSEARCH_USERNAME", Value: "admin"}, env[3])
+		assert.Len(t, openWebUIVectorDBSecrets(deployment), 2)
+		assert.Contains(t, referencedSecretNames(deployment), "opensearch", "new connection details roll out")
+	})
+
+	t.Run("should connect to the operator-managed Qdrant", func(t *testing.T) {
+		deployment.Spec.OpenWebUI.VectorDB = &llmgeeperiov1alpha1.VectorDBSpec{Type: llmgeeperiov1alpha1.VectorDBTypeQdrant}
+
+		env := openWebUIVectorDBEnv(deployment)
+		require.Len(t, env, 3)
+		assert.Equal(t, corev1.EnvVar{Name: "QDRANT_URI", Value: "http://test-deployment-qdrant:6333"}, env[1])
+		assert.Equal(t, "test-deployment-qdrant", env[2].ValueFrom.SecretKeyRef.Name)
+	})
+}
+
+func TestReconcileQdrant(t *testing.T) {
+	ctx := context.Background()
+	scheme := newTestScheme(t)
+	deployment := &llmgeeperiov1alpha1.LMDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "default", UID: "uid"},
+		Spec: llmgeeperiov1alpha1.LMDeploymentSpec{
+			OpenWebUI: llmgeeperiov1alpha1.OpenWebUISpec{
+				Enabled: true,
+				VectorDB: &llmgeeperiov1alpha1.VectorDBSpec{
+					Type: llmgeeperiov1alpha1.VectorDBTypeQdrant,
+					Qdrant: llmgeeperiov1alpha1.QdrantSpec{
+						Persistence: llmgeeperiov1alpha1.QdrantPersistenceSpec{Size: "20Gi"},
+					},
+				},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	reconciler := &LMDeploymentReconciler{Client: c, Scheme: scheme}
+	key := types.NamespacedName{Name: "test-deployment-qdrant", Namespace: "default"}
+	condition := func() *metav1.Condition {
+		return meta.FindStatusCondition(deployment.Status.OpenWebUIStatus.Conditions, llmgeeperiov1alpha1.ConditionVectorDBReady)
+	}
+
+	t.Run("should run Qdrant with a generated API key", func(t *testing.T) {
+		require.NoError(t, reconciler.reconcileQdrant(ctx, deployment))
+
+		secret := &corev1.Secret{}
+		require.NoError(t, c.Get(ctx, key, secret))
+		apiKey := secret.Data[qdrantAPIKeyKey]
+		assert.Len(t, apiKey, 48)
+		assert.Empty(t, secret.OwnerReferences, "the API key outlives the deployment like the volume")
+
+		pvc := &corev1.PersistentVolumeClaim{}
+		require.NoError(t, c.Get(ctx, key, pvc))
+		assert.Equal(t, "20Gi", pvc.Spec.Resources.Requests.Storage().String())
+
+		workload := &appsv1.Deployment{}
+		require.NoError(t, c.Get(ctx, key, workload))
+		assert.Equal(t, appsv1.RecreateDeploymentStrategyType, workload.Spec.Strategy.Type)
+		container := workload.Spec.Template.Spec.Containers[0]
+		assert.Equal(t, "qdrant/qdrant:v1.13.4", container.Image)
+		assert.Equal(t, "QDRANT__SERVICE__API_KEY", container.Env[0].Name)
+		require.NoError(t, c.Get(ctx, key, &corev1.Service{}))
+
+		// The API key is generated only once
+		require.NoError(t, reconciler.reconcileQdrant(ctx, deployment))
+		require.NoError(t, c.Get(ctx, key, secret))
+		assert.Equal(t, apiKey, secret.Data[qdrantAPIKeyKey])
+	})
+
+	t.Run("should report the readiness of Qdrant", func(t *testing.T) {
+		reconciler.setVectorDBCondition(ctx, deployment)
+		require.NotNil(t, condition())
+		assert.Equal(t, metav1.ConditionFalse, condition().Status)
+		assert.Equal(t, "NotReady", condition().Reason)
+
+		workload := &appsv1.Deployment{}
+		require.NoError(t, c.Get(ctx, key, workload))
+		workload.Status.ReadyReplicas = 1
+		require.NoError(t, c.Status().Update(ctx, workload))
+
+		reconciler.setVectorDBCondition(ctx, deployment)
+		assert.Equal(t, metav1.ConditionTrue, condition().Status)
+	})
+
+	t.Run("should point OpenWebUI at Qdrant", func(t *testing.T) {
+		env := reconciler.buildOpenWebUIDeployment(deployment).Spec.Template.Spec.Containers[0].Env
+		assert.Contains(t, env, corev1.EnvVar{Name: "VECTOR_DB", Value: "qdrant"})
+		assert.Contains(t, env, corev1.EnvVar{Name: "QDRANT_URI", Value: "http://test-deployment-qdrant:6333"})
+	})
+
+	t.Run("should remove Qdrant but keep its data once OpenWebUI uses an external database", func(t *testing.T) {
+		deployment.Spec.OpenWebUI.VectorDB.External = &llmgeeperiov1alpha1.ExternalVectorDBSpec{
+			URLSecretRef: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "qdrant-cloud"}, Key: "url"},
+		}
+		require.NoError(t, reconciler.reconcileQdrant(ctx, deployment))
+
+		assert.True(t, errors.IsNotFound(c.Get(ctx, key, &appsv1.Deployment{})))
+		assert.True(t, errors.IsNotFound(c.Get(ctx, key, &corev1.Service{})))
+		assert.NoError(t, c.Get(ctx, key, &corev1.PersistentVolumeClaim{}))
+
+		reconciler.setVectorDBCondition(ctx, deployment)
+		assert.Equal(t, metav1.ConditionFalse, condition().Status)
+		assert.Equal(t, "SecretNotFound", condition().Reason)
+	})
+
+	t.Run("should drop the condition for the embedded Chroma", func(t *testing.T) {
+		deployment.Spec.OpenWebUI.VectorDB = nil
+		reconciler.setVectorDBCondition(ctx, deployment)
+		assert.Nil(t, condition())
+	})
+}
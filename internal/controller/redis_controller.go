@@ -18,41 +18,126 @@ package controller
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/intstr"
-	"k8s.io/utils/ptr"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+	operatorconfig "github.com/geeper-io/llm-operator/internal/config"
 )
 
-// reconcileRedis reconciles the Redis deployment for OpenWebUI
-func (r *LMDeploymentReconciler) reconcileRedis(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment) error {
-	// Only deploy Redis if OpenWebUI is enabled
-	if !deployment.Spec.OpenWebUI.Enabled {
+const (
+	// redisPasswordKey is the key of the generated Redis password in the secret of the operator
+	redisPasswordKey = "REDIS_PASSWORD"
+	// redisURLKey is the key of the deprecated plain text Redis URL in the secret of the operator
+	redisURLKey = "REDIS_URL"
+)
+
+// openWebUIRedisEnabled reports whether OpenWebUI shares its state through Redis, which it needs with more than one replica
+func openWebUIRedisEnabled(deployment *llmgeeperiov1alpha1.LMDeployment) bool {
+	return deployment.Spec.OpenWebUI.Enabled && (deployment.Spec.OpenWebUI.Redis.Enabled || openWebUIMultiReplica(deployment))
+}
+
+// redisDeployed reports whether the operator runs a Redis instance for OpenWebUI
+func redisDeployed(deployment *llmgeeperiov1alpha1.LMDeployment) bool {
+	return openWebUIRedisEnabled(deployment) && !deployment.Spec.OpenWebUI.Redis.External()
+}
+
+// openWebUIMultiReplica reports whether OpenWebUI runs more than one replica, at any time of its schedules
+func openWebUIMultiReplica(deployment *llmgeeperiov1alpha1.LMDeployment) bool {
+	return maxScheduledReplicas(deployment.Spec.OpenWebUI.Schedules, deployment.Spec.OpenWebUI.Replicas) > 1
+}
+
+// redisPasswordRef references the password of the Redis deployed by the operator, generated unless configured
+func redisPasswordRef(deployment *llmgeeperiov1alpha1.LMDeployment) *corev1.SecretKeySelector {
+	if ref := deployment.Spec.OpenWebUI.Redis.PasswordSecretRef; ref != nil {
+		return ref
+	}
+	return &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: deployment.GetRedisSecretName()},
+		Key:                  redisPasswordKey,
+	}
+}
+
+// redisURLRef references the URL of an external Redis, nil for the Redis deployed by the operator
+func redisURLRef(deployment *llmgeeperiov1alpha1.LMDeployment) *corev1.SecretKeySelector {
+	redis := deployment.Spec.OpenWebUI.Redis
+	switch {
+	case redis.URLSecretRef != nil:
+		return redis.URLSecretRef
+	case redis.RedisURL != "":
+		// The deprecated plain text URL is copied to the secret of the operator
+		return &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: deployment.GetRedisSecretName()},
+			Key:                  redisURLKey,
+		}
+	}
+	return nil
+}
+
+// openWebUIRedisSecret returns the name of the secret OpenWebUI reads the Redis connection from, empty without Redis
+func openWebUIRedisSecret(deployment *llmgeeperiov1alpha1.LMDeployment) string {
+	if !openWebUIRedisEnabled(deployment) {
+		return ""
+	}
+	if ref := redisURLRef(deployment); ref != nil {
+		return ref.Name
+	}
+	return redisPasswordRef(deployment).Name
+}
+
+// openWebUIRedisEnv returns the env vars connecting OpenWebUI to Redis, the credentials are only read from secrets
+func openWebUIRedisEnv(deployment *llmgeeperiov1alpha1.LMDeployment) []corev1.EnvVar {
+	if !openWebUIRedisEnabled(deployment) {
 		return nil
 	}
+	if ref := redisURLRef(deployment); ref != nil {
+		return []corev1.EnvVar{{Name: "REDIS_URL", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: ref}}}
+	}
 
-	// Auto-enable Redis if OpenWebUI has multiple replicas and Redis is not explicitly disabled
-	if deployment.Spec.OpenWebUI.Replicas > 1 && !deployment.Spec.OpenWebUI.Redis.Enabled && deployment.Spec.OpenWebUI.Redis.RedisURL == "" {
-		// Automatically enable Redis for multi-instance deployments
-		deployment.Spec.OpenWebUI.Redis.Enabled = true
+	if redisSentinelMode(deployment) {
+		return openWebUIRedisSentinelEnv(deployment)
 	}
 
-	// Only deploy Redis if Redis is enabled
-	if !deployment.Spec.OpenWebUI.Redis.Enabled {
+	// The password is put into the URL through dependent env var expansion
+	return []corev1.EnvVar{
+		{Name: "REDIS_PASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: redisPasswordRef(deployment)}},
+		{Name: "REDIS_URL", Value: fmt.Sprintf("redis://:$(REDIS_PASSWORD)@%s:%d/0",
+			deployment.GetRedisServiceName(), deployment.Spec.OpenWebUI.Redis.Service.Port)},
+	}
+}
+
+// reconcileRedis reconciles the Redis deployment for OpenWebUI
+func (r *LMDeploymentReconciler) reconcileRedis(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment) error {
+	// Only deploy Redis if OpenWebUI uses it, Redis is enabled automatically for multiple replicas
+	if !openWebUIRedisEnabled(deployment) {
 		return nil
 	}
 
+	if err := r.ensureRedisSecret(ctx, deployment); err != nil {
+		return err
+	}
+
 	// If Redis URL is provided, don't deploy Redis
-	if deployment.Spec.OpenWebUI.Redis.RedisURL != "" {
+	if !redisDeployed(deployment) {
 		return nil
 	}
 
+	if redisSentinelMode(deployment) {
+		return r.reconcileRedisSentinel(ctx, deployment)
+	}
+	if err := r.cleanupRedisSentinel(ctx, deployment); err != nil {
+		return err
+	}
+
 	// Create or update Redis PVC if persistence is enabled
 	if deployment.Spec.OpenWebUI.Redis.Persistence.Enabled {
 		redisPVC := r.buildRedisPVC(deployment)
@@ -61,11 +146,20 @@ func (r *LMDeploymentReconciler) reconcileRedis(ctx context.Context, deployment
 		}
 	}
 
-	// Create or update Redis deployment
+	// Create or update Redis deployment, restarting it when its password changes
 	redisDeployment := r.buildRedisDeployment(deployment)
+	r.pinImageDigests(deployment, &redisDeployment.Spec.Template)
+	hasher := newConfigHasher()
+	if err := r.addSecrets(ctx, hasher, deployment.Namespace, redisPasswordRef(deployment).Name); err != nil {
+		return err
+	}
+	setConfigHash(redisDeployment, hasher.sum())
 	if err := r.createOrUpdateDeployment(ctx, redisDeployment); err != nil {
 		return err
 	}
+	if err := r.reconcilePodDisruptionBudget(ctx, deployment, redisDeployment, deployment.Spec.OpenWebUI.Redis.Rollout); err != nil {
+		return err
+	}
 
 	// Create or update Redis service
 	redisService := r.buildRedisService(deployment)
@@ -76,6 +170,57 @@ func (r *LMDeploymentReconciler) reconcileRedis(ctx context.Context, deployment
 	return nil
 }
 
+// ensureRedisSecret keeps the generated Redis password and the deprecated plain text fields in the secret of the operator.
+// A generated password is kept, a plain text password or URL in the spec is copied over on every reconcile.
+func (r *LMDeploymentReconciler) ensureRedisSecret(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment) error {
+	redis := deployment.Spec.OpenWebUI.Redis
+	data := map[string][]byte{}
+
+	if redisDeployed(deployment) && redis.PasswordSecretRef == nil {
+		password := redis.Password
+		if password == "" {
+			existing := &corev1.Secret{}
+			err := r.Get(ctx, types.NamespacedName{Name: deployment.GetRedisSecretName(), Namespace: deployment.Namespace}, existing)
+			if err != nil && !errors.IsNotFound(err) {
+				return fmt.Errorf("failed to get Redis secret: %w", err)
+			}
+			password = string(existing.Data[redisPasswordKey])
+		}
+		if password == "" {
+			// Hex keeps the password URL-safe
+			bytes := make([]byte, 24)
+			if _, err := rand.Read(bytes); err != nil {
+				return fmt.Errorf("failed to generate Redis password: %w", err)
+			}
+			password = hex.EncodeToString(bytes)
+		}
+		data[redisPasswordKey] = []byte(password)
+	}
+	if redis.URLSecretRef == nil && redis.RedisURL != "" {
+		data[redisURLKey] = []byte(redis.RedisURL)
+	}
+	if len(data) == 0 {
+		return nil
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deployment.GetRedisSecretName(),
+			Namespace: deployment.Namespace,
+			Labels: map[string]string{
+				"app":            "redis",
+				"llm-deployment": deployment.Name,
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: data,
+	}
+
+	// Set owner reference
+	_ = controllerutil.SetControllerReference(deployment, secret, r.Scheme)
+	return r.createOrUpdateSecret(ctx, secret)
+}
+
 // buildRedisDeployment builds the Redis deployment object
 func (r *LMDeploymentReconciler) buildRedisDeployment(deployment *llmgeeperiov1alpha1.LMDeployment) *appsv1.Deployment {
 	labels := map[string]string{
@@ -86,8 +231,8 @@ func (r *LMDeploymentReconciler) buildRedisDeployment(deployment *llmgeeperiov1a
 	// Build environment variables
 	envVars := []corev1.EnvVar{
 		{
-			Name:  "REDIS_PASSWORD",
-			Value: deployment.Spec.OpenWebUI.Redis.Password,
+			Name:      "REDIS_PASSWORD",
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: redisPasswordRef(deployment)},
 		},
 	}
 
@@ -119,7 +264,7 @@ func (r *LMDeploymentReconciler) buildRedisDeployment(deployment *llmgeeperiov1a
 			Labels:    labels,
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: ptr.To(int32(1)), // Redis should only have 1 replica
+			Replicas: r.desiredReplicas(deployment, 1), // Redis should only have 1 replica
 			Selector: &metav1.LabelSelector{
 				MatchLabels: labels,
 			},
@@ -131,7 +276,7 @@ func (r *LMDeploymentReconciler) buildRedisDeployment(deployment *llmgeeperiov1a
 					Containers: []corev1.Container{
 						{
 							Name:  "redis",
-							Image: deployment.Spec.OpenWebUI.Redis.Image,
+							Image: r.imageOrDefault(deployment.Spec.OpenWebUI.Redis.Image, r.operatorConfig().Images.Redis),
 							Ports: []corev1.ContainerPort{
 								{
 									Name:          "redis",
@@ -139,7 +284,7 @@ func (r *LMDeploymentReconciler) buildRedisDeployment(deployment *llmgeeperiov1a
 									Protocol:      corev1.ProtocolTCP,
 								},
 							},
-							Resources:    r.buildResourceRequirements(deployment.Spec.OpenWebUI.Redis.Resources),
+							Resources:    r.buildResourceRequirements(operatorconfig.ResourcesOrDefault(deployment.Spec.OpenWebUI.Redis.Resources, r.operatorConfig().Resources.Redis)),
 							Env:          envVars,
 							VolumeMounts: volumeMounts,
 							Command: []string{
@@ -147,7 +292,7 @@ func (r *LMDeploymentReconciler) buildRedisDeployment(deployment *llmgeeperiov1a
 								"--appendonly",
 								"yes",
 								"--requirepass",
-								deployment.Spec.OpenWebUI.Redis.Password,
+								"$(REDIS_PASSWORD)",
 							},
 						},
 					},
@@ -157,6 +302,10 @@ func (r *LMDeploymentReconciler) buildRedisDeployment(deployment *llmgeeperiov1a
 		},
 	}
 
+	// Apply pod template overrides
+	r.applyPodTemplateOverrides(&redisDeployment.Spec.Template, deployment.Spec.OpenWebUI.Redis.PodTemplateOverrides)
+	r.applyRolloutStrategy(redisDeployment, deployment.Spec.OpenWebUI.Redis.Rollout)
+
 	// Set owner reference
 	_ = controllerutil.SetControllerReference(deployment, redisDeployment, r.Scheme)
 	return redisDeployment
@@ -176,15 +325,8 @@ func (r *LMDeploymentReconciler) buildRedisService(deployment *llmgeeperiov1alph
 			Labels:    labels,
 		},
 		Spec: corev1.ServiceSpec{
-			Type: deployment.Spec.OpenWebUI.Redis.Service.Type,
-			Ports: []corev1.ServicePort{
-				{
-					Name:       "redis",
-					Port:       deployment.Spec.OpenWebUI.Redis.Service.Port,
-					TargetPort: intstr.FromInt32(deployment.Spec.OpenWebUI.Redis.Service.Port),
-					Protocol:   corev1.ProtocolTCP,
-				},
-			},
+			Type:     deployment.Spec.OpenWebUI.Redis.Service.Type,
+			Ports:    redisServicePorts(deployment),
 			Selector: labels,
 		},
 	}
@@ -212,10 +354,7 @@ func (r *LMDeploymentReconciler) buildRedisPVC(deployment *llmgeeperiov1alpha1.L
 		},
 	}
 
-	storageClass := deployment.Spec.OpenWebUI.Redis.Persistence.StorageClass
-	if storageClass != "" {
-		pvcSpec.StorageClassName = &storageClass
-	}
+	pvcSpec.StorageClassName = r.operatorConfig().StorageClassOrDefault(deployment.Spec.OpenWebUI.Redis.Persistence.StorageClass)
 
 	redisPVC := &corev1.PersistentVolumeClaim{
 		ObjectMeta: metav1.ObjectMeta{
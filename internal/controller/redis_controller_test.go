@@ -0,0 +1,110 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+func TestReconcileRedis(t *testing.T) {
+	ctx := context.Background()
+	scheme := newTestScheme(t)
+	deployment := &llmgeeperiov1alpha1.LMDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "default", UID: "uid"},
+		Spec: llmgeeperiov1alpha1.LMDeploymentSpec{
+			OpenWebUI: llmgeeperiov1alpha1.OpenWebUISpec{
+				Enabled: true,
+				Redis: llmgeeperiov1alpha1.RedisSpec{
+					Enabled: true,
+					Service: llmgeeperiov1alpha1.ServiceSpec{Port: 6379},
+				},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	reconciler := &LMDeploymentReconciler{Client: c, Scheme: scheme}
+	key := types.NamespacedName{Name: "test-deployment-redis", Namespace: "default"}
+	envVar := func(env []corev1.EnvVar, name string) corev1.EnvVar {
+		for _, envVar := range env {
+			if envVar.Name == name {
+				return envVar
+			}
+		}
+		t.Fatalf("env var %s not found", name)
+		return corev1.EnvVar{}
+	}
+
+	t.Run("should generate a password and only pass it through the secret", func(t *testing.T) {
+		require.NoError(t, reconciler.reconcileRedis(ctx, deployment))
+
+		secret := &corev1.Secret{}
+		require.NoError(t, c.Get(ctx, key, secret))
+		password := secret.Data[redisPasswordKey]
+		assert.Len(t, password, 48)
+
+		workload := &appsv1.Deployment{}
+		require.NoError(t, c.Get(ctx, key, workload))
+		container := workload.Spec.Template.Spec.Containers[0]
+		assert.Equal(t, "test-deployment-redis", envVar(container.Env, "REDIS_PASSWORD").ValueFrom.SecretKeyRef.Name)
+		assert.Contains(t, container.Command, "$(REDIS_PASSWORD)")
+		assert.NotEmpty(t, workload.Spec.Template.Annotations[llmgeeperiov1alpha1.ConfigHashAnnotation])
+
+		env := reconciler.buildOpenWebUIDeployment(deployment).Spec.Template.Spec.Containers[0].Env
+		assert.Equal(t, "redis://:$(REDIS_PASSWORD)@test-deployment-redis:6379/0", envVar(env, "REDIS_URL").Value)
+		assert.Equal(t, redisPasswordKey, envVar(env, "REDIS_PASSWORD").ValueFrom.SecretKeyRef.Key)
+
+		// The password is generated only once
+		require.NoError(t, reconciler.reconcileRedis(ctx, deployment))
+		require.NoError(t, c.Get(ctx, key, secret))
+		assert.Equal(t, password, secret.Data[redisPasswordKey])
+	})
+
+	t.Run("should move the deprecated plain text password into the secret", func(t *testing.T) {
+		deployment.Spec.OpenWebUI.Redis.Password = "plain"
+		require.NoError(t, reconciler.reconcileRedis(ctx, deployment))
+
+		secret := &corev1.Secret{}
+		require.NoError(t, c.Get(ctx, key, secret))
+		assert.Equal(t, "plain", string(secret.Data[redisPasswordKey]))
+		for _, envVar := range reconciler.buildRedisDeployment(deployment).Spec.Template.Spec.Containers[0].Env {
+			assert.NotEqual(t, "plain", envVar.Value)
+		}
+		deployment.Spec.OpenWebUI.Redis.Password = ""
+	})
+
+	t.Run("should read the URL of an external Redis from its secret", func(t *testing.T) {
+		url := &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "redis-cloud"}, Key: "url"}
+		deployment.Spec.OpenWebUI.Redis.URLSecretRef = url
+		assert.False(t, redisDeployed(deployment))
+
+		env := openWebUIRedisEnv(deployment)
+		require.Len(t, env, 1)
+		assert.Equal(t, url, env[0].ValueFrom.SecretKeyRef)
+		assert.Contains(t, referencedSecretNames(deployment), "redis-cloud", "a new URL rolls out")
+	})
+}
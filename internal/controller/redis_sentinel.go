@@ -0,0 +1,535 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+	operatorconfig "github.com/geeper-io/llm-operator/internal/config"
+	"github.com/geeper-io/llm-operator/internal/sentinel"
+)
+
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
+
+const (
+	// redisSentinelPort is the port Sentinel listens on in every Redis pod
+	redisSentinelPort = 26379
+
+	// redisProbeInterval is how long the Sentinel view of Redis is reused before Sentinel is queried again
+	redisProbeInterval = 30 * time.Second
+
+	// redisProbeTimeout bounds a Sentinel query, the status update waits for it
+	redisProbeTimeout = 2 * time.Second
+)
+
+// defaultSentinelClient queries Sentinel when the reconciler is not configured with a Sentinel client
+var defaultSentinelClient = sentinel.NewClient(sentinel.Options{Timeout: redisProbeTimeout})
+
+// redisProbe is the last Sentinel view of the Redis of a deployment
+type redisProbe struct {
+	masterName    string
+	readyReplicas int32
+	checkedAt     time.Time
+	master        *sentinel.MasterState
+	err           error
+}
+
+// sentinelClient returns the client used to report the health of Redis in sentinel mode
+func (r *LMDeploymentReconciler) sentinelClient() sentinel.Client {
+	if r.Sentinel == nil {
+		return defaultSentinelClient
+	}
+	return r.Sentinel
+}
+
+// redisSentinelMode reports whether the operator runs Redis as a master and replicas monitored by Sentinel
+func redisSentinelMode(deployment *llmgeeperiov1alpha1.LMDeployment) bool {
+	return redisDeployed(deployment) && deployment.Spec.OpenWebUI.Redis.SentinelMode()
+}
+
+// redisSentinelReplicas returns the number of Redis pods in sentinel mode
+func redisSentinelReplicas(deployment *llmgeeperiov1alpha1.LMDeployment) int32 {
+	if replicas := deployment.Spec.OpenWebUI.Redis.Sentinel.Replicas; replicas > 0 {
+		return replicas
+	}
+	return 3
+}
+
+// redisSentinelQuorum returns the number of Sentinels agreeing on a failover, a majority of the replicas by default
+func redisSentinelQuorum(deployment *llmgeeperiov1alpha1.LMDeployment) int32 {
+	if quorum := deployment.Spec.OpenWebUI.Redis.Sentinel.Quorum; quorum > 0 {
+		return quorum
+	}
+	return redisSentinelReplicas(deployment)/2 + 1
+}
+
+// redisMasterName returns the name Sentinel monitors the master under
+func redisMasterName(deployment *llmgeeperiov1alpha1.LMDeployment) string {
+	if name := deployment.Spec.OpenWebUI.Redis.Sentinel.MasterName; name != "" {
+		return name
+	}
+	return "mymaster"
+}
+
+// redisPodHosts returns the stable hostnames of the Redis pods through the headless service
+func redisPodHosts(deployment *llmgeeperiov1alpha1.LMDeployment) []string {
+	hosts := make([]string, 0, redisSentinelReplicas(deployment))
+	for i := range redisSentinelReplicas(deployment) {
+		hosts = append(hosts, fmt.Sprintf("%s-%d.%s.%s.svc",
+			deployment.GetRedisDeploymentName(), i, deployment.GetRedisHeadlessServiceName(), deployment.Namespace))
+	}
+	return hosts
+}
+
+// openWebUIRedisSentinelEnv returns the env vars connecting OpenWebUI to the master through Sentinel.
+// OpenWebUI reads the master name from the host of the URL and asks the Sentinels for its address.
+func openWebUIRedisSentinelEnv(deployment *llmgeeperiov1alpha1.LMDeployment) []corev1.EnvVar {
+	hosts := strings.Join(redisPodHosts(deployment), ",")
+	port := strconv.Itoa(redisSentinelPort)
+	return []corev1.EnvVar{
+		{Name: "REDIS_PASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: redisPasswordRef(deployment)}},
+		{Name: "REDIS_URL", Value: fmt.Sprintf("redis://:$(REDIS_PASSWORD)@%s:%d/0",
+			redisMasterName(deployment), deployment.Spec.OpenWebUI.Redis.Service.Port)},
+		{Name: "REDIS_SENTINEL_HOSTS", Value: hosts},
+		{Name: "REDIS_SENTINEL_PORT", Value: port},
+		// The websocket manager doesn't fall back to the Sentinels of the main connection
+		{Name: "WEBSOCKET_SENTINEL_HOSTS", Value: hosts},
+		{Name: "WEBSOCKET_SENTINEL_PORT", Value: port},
+	}
+}
+
+// reconcileRedisSentinel reconciles the Redis StatefulSet and its services in sentinel mode
+func (r *LMDeploymentReconciler) reconcileRedisSentinel(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment) error {
+	// Switching from standalone mode replaces the Redis deployment, its PVC is kept
+	if err := r.deleteControlled(ctx, deployment, &appsv1.Deployment{}, deployment.GetRedisDeploymentName()); err != nil {
+		return err
+	}
+
+	// The headless service has to exist before the pods to resolve each other
+	if err := r.createOrUpdateService(ctx, r.buildRedisHeadlessService(deployment)); err != nil {
+		return err
+	}
+
+	// Create or update the Redis StatefulSet, restarting it when its password changes
+	statefulSet := r.buildRedisStatefulSet(deployment)
+	r.pinImageDigests(deployment, &statefulSet.Spec.Template)
+	hasher := newConfigHasher()
+	if err := r.addSecrets(ctx, hasher, deployment.Namespace, redisPasswordRef(deployment).Name); err != nil {
+		return err
+	}
+	statefulSet.Spec.Template.Annotations = mergeStringMaps(statefulSet.Spec.Template.Annotations,
+		map[string]string{llmgeeperiov1alpha1.ConfigHashAnnotation: hasher.sum()})
+	if err := r.createOrUpdateStatefulSet(ctx, statefulSet); err != nil {
+		return err
+	}
+
+	// Losing more than one pod at a time may lose the quorum, so the pods get a budget by default
+	pdb := r.buildWorkloadPodDisruptionBudget(deployment, statefulSet, statefulSet.Spec.Selector, true, deployment.Spec.OpenWebUI.Redis.Rollout)
+	if err := r.applyPodDisruptionBudget(ctx, deployment, statefulSet, pdb); err != nil {
+		return err
+	}
+
+	return r.createOrUpdateService(ctx, r.buildRedisService(deployment))
+}
+
+// cleanupRedisSentinel removes the StatefulSet and headless service of sentinel mode, the PVCs of its pods are kept
+func (r *LMDeploymentReconciler) cleanupRedisSentinel(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment) error {
+	if err := r.deleteControlled(ctx, deployment, &appsv1.StatefulSet{}, deployment.GetRedisDeploymentName()); err != nil {
+		return err
+	}
+	return r.deleteControlled(ctx, deployment, &corev1.Service{}, deployment.GetRedisHeadlessServiceName())
+}
+
+// redisSentinelScript renders the script writing the Redis and Sentinel configuration of a pod.
+// A pod asks the other Sentinels for the current master and replicates from it. Without an answering Sentinel it
+// follows a running master, or the replica holding the most replicated data. Only when no other Redis answers, as
+// when the whole set starts, the first pod starts as the master while the others wait for it.
+// The pods are announced with their stable hostnames as their IPs change.
+func redisSentinelScript(deployment *llmgeeperiov1alpha1.LMDeployment) string {
+	hosts := redisPodHosts(deployment)
+	port := deployment.Spec.OpenWebUI.Redis.Service.Port
+	masterName := redisMasterName(deployment)
+
+	lines := []string{
+		"set -e",
+		`export REDISCLI_AUTH="$REDIS_PASSWORD"`,
+		fmt.Sprintf(`self="${HOSTNAME}.%s.%s.svc"`, deployment.GetRedisHeadlessServiceName(), deployment.Namespace),
+		`master=""`,
+		fmt.Sprintf("for host in %s; do", strings.Join(hosts, " ")),
+		`  if [ "$host" != "$self" ]; then`,
+		fmt.Sprintf(`    master=$(timeout 3 redis-cli -h "$host" -p %d --raw sentinel get-master-addr-by-name %s 2>/dev/null | head -n 1 || true)`, redisSentinelPort, masterName),
+		`    [ -n "$master" ] && break`,
+		"  fi",
+		"done",
+		`while [ -z "$master" ]; do`,
+		"  offset=-1",
+		fmt.Sprintf("  for host in %s; do", strings.Join(hosts, " ")),
+		`    [ "$host" = "$self" ] && continue`,
+		fmt.Sprintf(`    info=$(timeout 3 redis-cli -h "$host" -p %d info replication 2>/dev/null | tr -d '\r' || true)`, port),
+		`    if echo "$info" | grep -q '^role:master'; then master="$host"; break; fi`,
+		`    replicated=$(echo "$info" | sed -n 's/^slave_repl_offset://p')`,
+		`    if [ -n "$replicated" ] && [ "$replicated" -gt "$offset" ]; then offset="$replicated"; master="$host"; fi`,
+		"  done",
+		`  if [ -z "$master" ]; then`,
+		fmt.Sprintf(`    if [ "$self" = "%s" ]; then master="$self"; else echo "waiting for the Redis master"; sleep 5; fi`, hosts[0]),
+		"  fi",
+		"done",
+		"{",
+		fmt.Sprintf(`  echo "port %d"`, port),
+		`  echo "dir /data"`,
+		`  echo "appendonly yes"`,
+		`  echo "requirepass $REDIS_PASSWORD"`,
+		`  echo "masterauth $REDIS_PASSWORD"`,
+		`  echo "replica-announce-ip $self"`,
+		fmt.Sprintf(`  if [ "$master" != "$self" ]; then echo "replicaof $master %d"; fi`, port),
+		"} > /etc/redis/redis.conf",
+		"{",
+		fmt.Sprintf(`  echo "port %d"`, redisSentinelPort),
+		`  echo "sentinel resolve-hostnames yes"`,
+		`  echo "sentinel announce-hostnames yes"`,
+		`  echo "sentinel announce-ip $self"`,
+		fmt.Sprintf(`  echo "sentinel monitor %s $master %d %d"`, masterName, port, redisSentinelQuorum(deployment)),
+		fmt.Sprintf(`  echo "sentinel auth-pass %s $REDIS_PASSWORD"`, masterName),
+		fmt.Sprintf(`  echo "sentinel down-after-milliseconds %s 5000"`, masterName),
+		fmt.Sprintf(`  echo "sentinel failover-timeout %s 60000"`, masterName),
+		fmt.Sprintf(`  echo "sentinel parallel-syncs %s 1"`, masterName),
+		"} > /etc/redis/sentinel.conf",
+	}
+	return strings.Join(lines, "\n")
+}
+
+// buildRedisStatefulSet builds the Redis StatefulSet of sentinel mode, every pod runs a Redis server and a Sentinel
+func (r *LMDeploymentReconciler) buildRedisStatefulSet(deployment *llmgeeperiov1alpha1.LMDeployment) *appsv1.StatefulSet {
+	redis := deployment.Spec.OpenWebUI.Redis
+	labels := map[string]string{
+		"app":            "redis",
+		"llm-deployment": deployment.Name,
+	}
+	image := r.imageOrDefault(redis.Image, r.operatorConfig().Images.Redis)
+	password := corev1.EnvVar{Name: "REDIS_PASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: redisPasswordRef(deployment)}}
+	configMount := corev1.VolumeMount{Name: "redis-config", MountPath: "/etc/redis"}
+
+	volumes := []corev1.Volume{
+		{Name: "redis-config", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+	}
+	var claims []corev1.PersistentVolumeClaim
+	if redis.Persistence.Enabled {
+		// Every pod gets its own PVC, kept when the StatefulSet is removed
+		claims = append(claims, corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "redis-data", Labels: labels},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse(redis.Persistence.Size),
+					},
+				},
+				StorageClassName: r.operatorConfig().StorageClassOrDefault(redis.Persistence.StorageClass),
+			},
+		})
+	} else {
+		volumes = append(volumes, corev1.Volume{Name: "redis-data", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}})
+	}
+
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deployment.GetRedisDeploymentName(),
+			Namespace: deployment.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:    r.desiredReplicas(deployment, redisSentinelReplicas(deployment)),
+			ServiceName: deployment.GetRedisHeadlessServiceName(),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					// Spread the pods over nodes so that losing a node doesn't take the master and its replicas down together
+					Affinity: &corev1.Affinity{
+						PodAntiAffinity: &corev1.PodAntiAffinity{
+							PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+								{
+									Weight: 100,
+									PodAffinityTerm: corev1.PodAffinityTerm{
+										LabelSelector: &metav1.LabelSelector{MatchLabels: labels},
+										TopologyKey:   corev1.LabelHostname,
+									},
+								},
+							},
+						},
+					},
+					InitContainers: []corev1.Container{
+						{
+							Name:         "config",
+							Image:        image,
+							Command:      []string{"sh", "-c", redisSentinelScript(deployment)},
+							Env:          []corev1.EnvVar{password},
+							VolumeMounts: []corev1.VolumeMount{configMount},
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							Name:  "redis",
+							Image: image,
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "redis",
+									ContainerPort: redis.Service.Port,
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
+							Resources: r.buildResourceRequirements(operatorconfig.ResourcesOrDefault(redis.Resources, r.operatorConfig().Resources.Redis)),
+							Command:   []string{"redis-server", "/etc/redis/redis.conf"},
+							// redis-cli authenticates with REDISCLI_AUTH
+							Env: []corev1.EnvVar{{Name: "REDISCLI_AUTH", ValueFrom: password.ValueFrom}},
+							// A replica loading the data of the master answers LOADING instead of PONG
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									Exec: &corev1.ExecAction{
+										Command: []string{"sh", "-c", fmt.Sprintf("redis-cli -p %d ping | grep -q PONG", redis.Service.Port)},
+									},
+								},
+								PeriodSeconds: 10,
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{Name: "redis-data", MountPath: "/data"},
+								configMount,
+							},
+						},
+						{
+							Name:  "sentinel",
+							Image: image,
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "sentinel",
+									ContainerPort: redisSentinelPort,
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
+							Resources: r.buildResourceRequirements(redis.Sentinel.Resources),
+							Command:   []string{"redis-sentinel", "/etc/redis/sentinel.conf"},
+							// Sentinel is ready once it monitors the master
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									Exec: &corev1.ExecAction{
+										Command: []string{"sh", "-c", fmt.Sprintf("redis-cli -p %d sentinel get-master-addr-by-name %s | grep -q .", redisSentinelPort, redisMasterName(deployment))},
+									},
+								},
+								PeriodSeconds: 10,
+							},
+							VolumeMounts: []corev1.VolumeMount{configMount},
+						},
+					},
+					Volumes: volumes,
+				},
+			},
+			VolumeClaimTemplates: claims,
+		},
+	}
+
+	// Apply pod template overrides
+	r.applyPodTemplateOverrides(&statefulSet.Spec.Template, redis.PodTemplateOverrides)
+
+	// Set owner reference
+	_ = controllerutil.SetControllerReference(deployment, statefulSet, r.Scheme)
+	return statefulSet
+}
+
+// buildRedisHeadlessService builds the headless service giving every Redis pod a stable hostname.
+// Not ready pods are published as well, a starting pod looks up the Sentinels of the others before it is ready.
+func (r *LMDeploymentReconciler) buildRedisHeadlessService(deployment *llmgeeperiov1alpha1.LMDeployment) *corev1.Service {
+	labels := map[string]string{
+		"app":            "redis",
+		"llm-deployment": deployment.Name,
+	}
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deployment.GetRedisHeadlessServiceName(),
+			Namespace: deployment.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP:                corev1.ClusterIPNone,
+			PublishNotReadyAddresses: true,
+			Ports:                    redisPodPorts(deployment),
+			Selector:                 labels,
+		},
+	}
+
+	// Set owner reference
+	_ = controllerutil.SetControllerReference(deployment, service, r.Scheme)
+	return service
+}
+
+// redisServicePorts returns the ports of the Redis service. In sentinel mode it only publishes Sentinel, the service
+// selects replicas as well and a write through it may reach a read-only replica. Clients ask Sentinel for the master
+// and connect to it through the headless service.
+func redisServicePorts(deployment *llmgeeperiov1alpha1.LMDeployment) []corev1.ServicePort {
+	if redisSentinelMode(deployment) {
+		return []corev1.ServicePort{redisSentinelServicePort()}
+	}
+	return redisPodPorts(deployment)
+}
+
+// redisSentinelServicePort returns the Sentinel port of the Redis services
+func redisSentinelServicePort() corev1.ServicePort {
+	return corev1.ServicePort{
+		Name:       "sentinel",
+		Port:       redisSentinelPort,
+		TargetPort: intstr.FromInt32(redisSentinelPort),
+		Protocol:   corev1.ProtocolTCP,
+	}
+}
+
+// redisPodPorts returns the ports of every Redis pod, with the Sentinel port in sentinel mode
+func redisPodPorts(deployment *llmgeeperiov1alpha1.LMDeployment) []corev1.ServicePort {
+	port := deployment.Spec.OpenWebUI.Redis.Service.Port
+	ports := []corev1.ServicePort{
+		{
+			Name:       "redis",
+			Port:       port,
+			TargetPort: intstr.FromInt32(port),
+			Protocol:   corev1.ProtocolTCP,
+		},
+	}
+	if redisSentinelMode(deployment) {
+		ports = append(ports, redisSentinelServicePort())
+	}
+	return ports
+}
+
+// setRedisStatus reports the master and replicas of Redis in sentinel mode as seen by Sentinel.
+// The last report is kept while reconciliation is paused.
+func (r *LMDeploymentReconciler) setRedisStatus(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment) {
+	status := &deployment.Status.OpenWebUIStatus
+	if !redisSentinelMode(deployment) {
+		r.redisProbes.Delete(client.ObjectKeyFromObject(deployment))
+		status.Redis = nil
+		meta.RemoveStatusCondition(&status.Conditions, llmgeeperiov1alpha1.ConditionRedisReady)
+		return
+	}
+	if deployment.IsReconcilePaused() {
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:               llmgeeperiov1alpha1.ConditionRedisReady,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: deployment.Generation,
+	}
+
+	statefulSet := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, types.NamespacedName{Name: deployment.GetRedisDeploymentName(), Namespace: deployment.Namespace}, statefulSet); err != nil {
+		status.Redis = nil
+		condition.Reason, condition.Message = "NotFound", fmt.Sprintf("Redis StatefulSet %s has not been created yet", deployment.GetRedisDeploymentName())
+		meta.SetStatusCondition(&status.Conditions, condition)
+		return
+	}
+	if statefulSet.Status.ReadyReplicas == 0 {
+		status.Redis = nil
+		condition.Reason, condition.Message = "Pending", "Waiting for the Redis pods to start"
+		meta.SetStatusCondition(&status.Conditions, condition)
+		return
+	}
+
+	master, err := r.queryRedisMaster(ctx, deployment, statefulSet.Status.ReadyReplicas)
+	if err != nil {
+		status.Redis = nil
+		condition.Reason, condition.Message = "SentinelUnreachable", fmt.Sprintf("Failed to query Sentinel: %v", err)
+		meta.SetStatusCondition(&status.Conditions, condition)
+		return
+	}
+
+	status.Redis = &llmgeeperiov1alpha1.RedisStatus{
+		// Pods are announced with their hostname through the headless service
+		Master:    strings.SplitN(master.Host, ".", 2)[0],
+		Replicas:  int32(len(master.Replicas)),
+		Sentinels: int32(master.OtherSentinels + 1),
+	}
+	for _, replica := range master.Replicas {
+		if replica.Healthy() {
+			status.Redis.HealthyReplicas++
+		}
+	}
+
+	switch {
+	case master.Down():
+		condition.Reason, condition.Message = "MasterDown", fmt.Sprintf("Master %s is down", status.Redis.Master)
+	case status.Redis.Sentinels < redisSentinelQuorum(deployment):
+		condition.Reason, condition.Message = "NoQuorum", fmt.Sprintf("%d Sentinels are below the quorum of %d, a failover is not possible", status.Redis.Sentinels, redisSentinelQuorum(deployment))
+	default:
+		condition.Status, condition.Reason = metav1.ConditionTrue, "Ready"
+		condition.Message = fmt.Sprintf("Master %s with %d of %d replicas in sync", status.Redis.Master, status.Redis.HealthyReplicas, status.Redis.Replicas)
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
+// queryRedisMaster asks Sentinel for the master and replicas of a deployment.
+// The answer is reused for redisProbeInterval unless the ready pods change, reconciles don't wait for Sentinel each time.
+func (r *LMDeploymentReconciler) queryRedisMaster(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment, readyReplicas int32) (*sentinel.MasterState, error) {
+	key := client.ObjectKeyFromObject(deployment)
+	name := redisMasterName(deployment)
+	if cached, ok := r.redisProbes.Load(key); ok {
+		probe := cached.(*redisProbe)
+		if probe.masterName == name && probe.readyReplicas == readyReplicas && time.Since(probe.checkedAt) < redisProbeInterval {
+			return probe.master, probe.err
+		}
+	}
+
+	// Any Sentinel knows the master and replicas, the service picks one of the ready pods
+	addr := net.JoinHostPort(fmt.Sprintf("%s.%s.svc", deployment.GetRedisServiceName(), deployment.Namespace), strconv.Itoa(redisSentinelPort))
+	queryCtx, cancel := context.WithTimeout(ctx, redisProbeTimeout)
+	defer cancel()
+	master, err := r.sentinelClient().Master(queryCtx, addr, name)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to query Redis Sentinel", "address", addr)
+	}
+	r.redisProbes.Store(key, &redisProbe{masterName: name, readyReplicas: readyReplicas, checkedAt: time.Now(), master: master, err: err})
+	return master, err
+}
+
+// redisStatusRequeueAfter returns when to query Sentinel again while Redis in sentinel mode is not ready.
+// Sentinel may recover without any change to the pods the controller watches.
+func redisStatusRequeueAfter(deployment *llmgeeperiov1alpha1.LMDeployment) time.Duration {
+	if !deployment.Spec.OpenWebUI.Enabled || !redisSentinelMode(deployment) ||
+		meta.IsStatusConditionTrue(deployment.Status.OpenWebUIStatus.Conditions, llmgeeperiov1alpha1.ConditionRedisReady) {
+		return 0
+	}
+	return redisProbeInterval
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+	"github.com/geeper-io/llm-operator/internal/sentinel"
+)
+
+// fakeSentinelClient returns a fixed master state, or an error
+type fakeSentinelClient struct {
+	master *sentinel.MasterState
+	err    error
+	addr   string
+	calls  int
+}
+
+func (f *fakeSentinelClient) Master(_ context.Context, addr, _ string) (*sentinel.MasterState, error) {
+	f.addr = addr
+	f.calls++
+	return f.master, f.err
+}
+
+func TestReconcileRedisSentinel(t *testing.T) {
+	ctx := context.Background()
+	scheme := newTestScheme(t)
+	deployment := &llmgeeperiov1alpha1.LMDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "default", UID: "uid"},
+		Spec: llmgeeperiov1alpha1.LMDeploymentSpec{
+			OpenWebUI: llmgeeperiov1alpha1.OpenWebUISpec{
+				Enabled: true,
+				Redis: llmgeeperiov1alpha1.RedisSpec{
+					Enabled:     true,
+					Service:     llmgeeperiov1alpha1.ServiceSpec{Port: 6379},
+					Persistence: llmgeeperiov1alpha1.RedisPersistenceSpec{Enabled: true, Size: "1Gi"},
+				},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&appsv1.StatefulSet{}).Build()
+	sentinelClient := &fakeSentinelClient{}
+	reconciler := &LMDeploymentReconciler{Client: c, Scheme: scheme, Sentinel: sentinelClient}
+	key := types.NamespacedName{Name: "test-deployment-redis", Namespace: "default"}
+	condition := func() *metav1.Condition {
+		return meta.FindStatusCondition(deployment.Status.OpenWebUIStatus.Conditions, llmgeeperiov1alpha1.ConditionRedisReady)
+	}
+
+	t.Run("should replace the standalone deployment with a StatefulSet", func(t *testing.T) {
+		require.NoError(t, reconciler.reconcileRedis(ctx, deployment))
+		require.NoError(t, c.Get(ctx, key, &appsv1.Deployment{}))
+
+		deployment.Spec.OpenWebUI.Redis.Mode = llmgeeperiov1alpha1.RedisModeSentinel
+		require.NoError(t, reconciler.reconcileRedis(ctx, deployment))
+		assert.Error(t, c.Get(ctx, key, &appsv1.Deployment{}))
+
+		statefulSet := &appsv1.StatefulSet{}
+		require.NoError(t, c.Get(ctx, key, statefulSet))
+		assert.Equal(t, int32(3), *statefulSet.Spec.Replicas)
+		assert.Equal(t, "test-deployment-redis-headless", statefulSet.Spec.ServiceName)
+		require.Len(t, statefulSet.Spec.VolumeClaimTemplates, 1, "every pod gets its own volume")
+		assert.Equal(t, "redis-data", statefulSet.Spec.VolumeClaimTemplates[0].Name)
+		containers := statefulSet.Spec.Template.Spec.Containers
+		require.Len(t, containers, 2)
+		assert.Equal(t, "sentinel", containers[1].Name)
+		assert.NotEmpty(t, statefulSet.Spec.Template.Annotations[llmgeeperiov1alpha1.ConfigHashAnnotation])
+
+		script := statefulSet.Spec.Template.Spec.InitContainers[0].Command[2]
+		assert.Contains(t, script, "sentinel monitor mymaster $master 6379 2")
+		assert.Contains(t, script, `info replication`, "without Sentinel the pods follow the running master or the most up to date replica")
+		assert.Contains(t, script, `if [ "$self" = "test-deployment-redis-0.test-deployment-redis-headless.default.svc" ]; then master="$self"`)
+		for _, container := range containers {
+			require.NotNil(t, container.ReadinessProbe, container.Name)
+			assert.NotNil(t, container.ReadinessProbe.Exec, container.Name)
+		}
+
+		headless := &corev1.Service{}
+		require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "test-deployment-redis-headless", Namespace: "default"}, headless))
+		assert.Equal(t, corev1.ClusterIPNone, headless.Spec.ClusterIP)
+		assert.True(t, headless.Spec.PublishNotReadyAddresses)
+		assert.Len(t, headless.Spec.Ports, 2, "the pods reach Redis and Sentinel of each other")
+
+		service := &corev1.Service{}
+		require.NoError(t, c.Get(ctx, key, service))
+		require.Len(t, service.Spec.Ports, 1, "clients can't write to a replica through the service")
+		assert.Equal(t, int32(redisSentinelPort), service.Spec.Ports[0].Port)
+
+		pdb := &policyv1.PodDisruptionBudget{}
+		require.NoError(t, c.Get(ctx, key, pdb))
+		assert.Equal(t, 1, pdb.Spec.MaxUnavailable.IntValue())
+	})
+
+	t.Run("should point OpenWebUI at the Sentinels", func(t *testing.T) {
+		env := map[string]string{}
+		for _, envVar := range openWebUIRedisEnv(deployment) {
+			env[envVar.Name] = envVar.Value
+		}
+		assert.Equal(t, "redis://:$(REDIS_PASSWORD)@mymaster:6379/0", env["REDIS_URL"])
+		assert.Equal(t, "test-deployment-redis-0.test-deployment-redis-headless.default.svc,"+
+			"test-deployment-redis-1.test-deployment-redis-headless.default.svc,"+
+			"test-deployment-redis-2.test-deployment-redis-headless.default.svc", env["REDIS_SENTINEL_HOSTS"])
+		assert.Equal(t, "26379", env["REDIS_SENTINEL_PORT"])
+		assert.Equal(t, env["REDIS_SENTINEL_HOSTS"], env["WEBSOCKET_SENTINEL_HOSTS"])
+	})
+
+	t.Run("should report the master and replicas", func(t *testing.T) {
+		reconciler.setRedisStatus(ctx, deployment)
+		assert.Equal(t, "Pending", condition().Reason)
+
+		statefulSet := &appsv1.StatefulSet{}
+		require.NoError(t, c.Get(ctx, key, statefulSet))
+		statefulSet.Status.ReadyReplicas = 3
+		require.NoError(t, c.Status().Update(ctx, statefulSet))
+
+		sentinelClient.err = errors.New("connection refused")
+		reconciler.setRedisStatus(ctx, deployment)
+		assert.Equal(t, "SentinelUnreachable", condition().Reason)
+		assert.Nil(t, deployment.Status.OpenWebUIStatus.Redis)
+
+		sentinelClient.err = nil
+		reconciler.redisProbes.Clear()
+		sentinelClient.master = &sentinel.MasterState{
+			Host:           "test-deployment-redis-1.test-deployment-redis-headless.default.svc",
+			Flags:          []string{"master"},
+			OtherSentinels: 2,
+			Replicas: []sentinel.ReplicaState{
+				{Flags: []string{"slave"}, MasterLinkStatus: "ok"},
+				{Flags: []string{"s_down", "slave", "disconnected"}, MasterLinkStatus: "err"},
+			},
+		}
+		reconciler.setRedisStatus(ctx, deployment)
+		assert.Equal(t, "test-deployment-redis.default.svc:26379", sentinelClient.addr)
+		assert.Equal(t, &llmgeeperiov1alpha1.RedisStatus{Master: "test-deployment-redis-1", Replicas: 2, HealthyReplicas: 1, Sentinels: 3},
+			deployment.Status.OpenWebUIStatus.Redis)
+		assert.Equal(t, metav1.ConditionTrue, condition().Status)
+
+		sentinelClient.master.OtherSentinels = 0
+		reconciler.redisProbes.Clear()
+		reconciler.setRedisStatus(ctx, deployment)
+		assert.Equal(t, "NoQuorum", condition().Reason)
+		assert.Equal(t, redisProbeInterval, redisStatusRequeueAfter(deployment), "Sentinel is queried again until Redis is ready")
+	})
+
+	t.Run("should reuse the last Sentinel view", func(t *testing.T) {
+		calls := sentinelClient.calls
+		recovered := *sentinelClient.master
+		recovered.OtherSentinels = 2
+		sentinelClient.master = &recovered
+		reconciler.setRedisStatus(ctx, deployment)
+		assert.Equal(t, calls, sentinelClient.calls)
+		assert.Equal(t, "NoQuorum", condition().Reason)
+
+		statefulSet := &appsv1.StatefulSet{}
+		require.NoError(t, c.Get(ctx, key, statefulSet))
+		statefulSet.Status.ReadyReplicas = 2
+		require.NoError(t, c.Status().Update(ctx, statefulSet))
+		reconciler.setRedisStatus(ctx, deployment)
+		assert.Equal(t, calls+1, sentinelClient.calls, "a change of the ready pods queries Sentinel again")
+		assert.Equal(t, metav1.ConditionTrue, condition().Status)
+		assert.Zero(t, redisStatusRequeueAfter(deployment))
+	})
+
+	t.Run("should not query Sentinel while paused", func(t *testing.T) {
+		calls := sentinelClient.calls
+		reconciler.redisProbes.Clear()
+		deployment.Annotations = map[string]string{llmgeeperiov1alpha1.ReconcilePausedAnnotation: "true"}
+		defer func() { deployment.Annotations = nil }()
+
+		reconciler.setRedisStatus(ctx, deployment)
+		assert.Equal(t, calls, sentinelClient.calls)
+		assert.Equal(t, metav1.ConditionTrue, condition().Status, "the last report is kept")
+	})
+
+	t.Run("should switch back to standalone mode", func(t *testing.T) {
+		deployment.Spec.OpenWebUI.Redis.Mode = llmgeeperiov1alpha1.RedisModeStandalone
+		require.NoError(t, reconciler.reconcileRedis(ctx, deployment))
+		assert.Error(t, c.Get(ctx, key, &appsv1.StatefulSet{}))
+		require.NoError(t, c.Get(ctx, key, &appsv1.Deployment{}))
+
+		reconciler.setRedisStatus(ctx, deployment)
+		assert.Nil(t, condition())
+		assert.Nil(t, deployment.Status.OpenWebUIStatus.Redis)
+	})
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
+
+// requestsGPU reports whether any container of the pod template requests a GPU, e.g. nvidia.com/gpu or amd.com/gpu
+func requestsGPU(podSpec *corev1.PodSpec) bool {
+	for _, container := range podSpec.Containers {
+		for _, resources := range []corev1.ResourceList{container.Resources.Limits, container.Resources.Requests} {
+			for name := range resources {
+				if strings.Contains(string(name), "gpu") {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// applyRolloutStrategy sets the update strategy of a workload.
+// GPU-backed workloads replace one pod at a time without surge, a surge pod can't schedule on a saturated GPU pool.
+func (r *LMDeploymentReconciler) applyRolloutStrategy(workload *appsv1.Deployment, rollout *llmgeeperiov1alpha1.RolloutSpec) {
+	gpu := requestsGPU(&workload.Spec.Template.Spec)
+	if rollout == nil && !gpu {
+		return
+	}
+	if rollout == nil {
+		rollout = &llmgeeperiov1alpha1.RolloutSpec{}
+	}
+
+	if rollout.Strategy == appsv1.RecreateDeploymentStrategyType {
+		workload.Spec.Strategy = appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}
+		return
+	}
+
+	rollingUpdate := &appsv1.RollingUpdateDeployment{}
+	if gpu {
+		rollingUpdate.MaxSurge = ptrIntOrString(intstr.FromInt32(0))
+		rollingUpdate.MaxUnavailable = ptrIntOrString(intstr.FromInt32(1))
+	}
+	if rollout.MaxSurge != nil {
+		rollingUpdate.MaxSurge = rollout.MaxSurge
+	}
+	if rollout.MaxUnavailable != nil {
+		rollingUpdate.MaxUnavailable = rollout.MaxUnavailable
+	}
+	workload.Spec.Strategy = appsv1.DeploymentStrategy{
+		Type:          appsv1.RollingUpdateDeploymentStrategyType,
+		RollingUpdate: rollingUpdate,
+	}
+}
+
+// buildPodDisruptionBudget builds the PodDisruptionBudget of a workload, nil when it should not have one.
+// GPU-backed workloads with more than one replica get one by default, a budget on a single replica would block node drains.
+func (r *LMDeploymentReconciler) buildPodDisruptionBudget(deployment *llmgeeperiov1alpha1.LMDeployment, workload *appsv1.Deployment, rollout *llmgeeperiov1alpha1.RolloutSpec) *policyv1.PodDisruptionBudget {
+	// Autoscaled workloads have no replica count and may run several replicas
+	enabled := requestsGPU(&workload.Spec.Template.Spec) && (workload.Spec.Replicas == nil || *workload.Spec.Replicas > 1)
+	return r.buildWorkloadPodDisruptionBudget(deployment, workload, workload.Spec.Selector, enabled, rollout)
+}
+
+// buildWorkloadPodDisruptionBudget builds the PodDisruptionBudget of the pods matching selector, nil when it should not have one.
+// The rollout settings override whether the workload has a budget by default.
+func (r *LMDeploymentReconciler) buildWorkloadPodDisruptionBudget(deployment *llmgeeperiov1alpha1.LMDeployment, workload client.Object, selector *metav1.LabelSelector, enabled bool, rollout *llmgeeperiov1alpha1.RolloutSpec) *policyv1.PodDisruptionBudget {
+	var budget *llmgeeperiov1alpha1.PodDisruptionBudgetSpec
+	if rollout != nil {
+		budget = rollout.PodDisruptionBudget
+	}
+
+	if budget != nil && budget.Enabled != nil {
+		enabled = *budget.Enabled
+	} else if budget != nil && (budget.MinAvailable != nil || budget.MaxUnavailable != nil) {
+		enabled = true
+	}
+	if !enabled {
+		return nil
+	}
+
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      workload.GetName(),
+			Namespace: workload.GetNamespace(),
+			Labels:    mergeStringMaps(workload.GetLabels()),
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: selector,
+		},
+	}
+	switch {
+	case budget != nil && budget.MinAvailable != nil:
+		pdb.Spec.MinAvailable = budget.MinAvailable
+	case budget != nil && budget.MaxUnavailable != nil:
+		pdb.Spec.MaxUnavailable = budget.MaxUnavailable
+	default:
+		pdb.Spec.MaxUnavailable = ptrIntOrString(intstr.FromInt32(1))
+	}
+
+	// Set owner reference
+	_ = controllerutil.SetControllerReference(deployment, pdb, r.Scheme)
+	return pdb
+}
+
+// reconcilePodDisruptionBudget creates, updates or removes the PodDisruptionBudget of a workload
+func (r *LMDeploymentReconciler) reconcilePodDisruptionBudget(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment, workload *appsv1.Deployment, rollout *llmgeeperiov1alpha1.RolloutSpec) error {
+	return r.applyPodDisruptionBudget(ctx, deployment, workload, r.buildPodDisruptionBudget(deployment, workload, rollout))
+}
+
+// applyPodDisruptionBudget creates or updates the PodDisruptionBudget of a workload, or removes it when pdb is nil
+func (r *LMDeploymentReconciler) applyPodDisruptionBudget(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment, workload client.Object, pdb *policyv1.PodDisruptionBudget) error {
+	existing := &policyv1.PodDisruptionBudget{}
+	err := r.Get(ctx, types.NamespacedName{Name: workload.GetName(), Namespace: workload.GetNamespace()}, existing)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	found := err == nil
+
+	switch {
+	case pdb == nil && found:
+		// Only remove budgets created by the operator
+		if !metav1.IsControlledBy(existing, deployment) {
+			return nil
+		}
+		if err := r.Delete(ctx, existing); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete pod disruption budget %s: %w", existing.Name, err)
+		}
+	case pdb != nil && !found:
+		// Create new pod disruption budget
+		if err := r.Create(ctx, pdb); err != nil {
+			return err
+		}
+	case pdb != nil && found:
+		// Update existing pod disruption budget using patch helper
+		if !reflect.DeepEqual(existing.Spec, pdb.Spec) {
+			patchHelper, err := patch.NewHelper(existing, r.Client)
+			if err != nil {
+				return fmt.Errorf("failed to create patch helper for pod disruption budget %s: %w", pdb.Name, err)
+			}
+
+			existing.Spec = pdb.Spec
+			if err := patchHelper.Patch(ctx, existing); err != nil {
+				return fmt.Errorf("failed to patch pod disruption budget %s: %w", pdb.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// vllmModelRollout returns the rollout settings of a model, falling back to the global defaults
+func vllmModelRollout(deployment *llmgeeperiov1alpha1.LMDeployment, modelSpec llmgeeperiov1alpha1.VLLMModelSpec) *llmgeeperiov1alpha1.RolloutSpec {
+	if modelSpec.Rollout == nil && deployment.Spec.VLLM.GlobalConfig != nil {
+		return deployment.Spec.VLLM.GlobalConfig.Rollout
+	}
+	return modelSpec.Rollout
+}
+
+// ptrIntOrString returns a pointer to an IntOrString
+func ptrIntOrString(value intstr.IntOrString) *intstr.IntOrString {
+	return &value
+}
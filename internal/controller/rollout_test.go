@@ -0,0 +1,140 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+func newRolloutTestDeployment() *llmgeeperiov1alpha1.LMDeployment {
+	return &llmgeeperiov1alpha1.LMDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "default", UID: "uid"},
+		Spec: llmgeeperiov1alpha1.LMDeploymentSpec{
+			VLLM: llmgeeperiov1alpha1.VLLMSpec{
+				Enabled: true,
+				Models: []llmgeeperiov1alpha1.VLLMModelSpec{
+					{
+						Name:     "llama",
+						Model:    "meta-llama/Llama-3.1-8B-Instruct",
+						Replicas: 2,
+						Resources: llmgeeperiov1alpha1.ResourceRequirements{
+							Limits: corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestRollout_Strategy(t *testing.T) {
+	reconciler := &LMDeploymentReconciler{Scheme: newTestScheme(t)}
+	deployment := newRolloutTestDeployment()
+
+	t.Run("should not surge GPU workloads by default", func(t *testing.T) {
+		workload := reconciler.buildVLLMModelDeployment(deployment, deployment.Spec.VLLM.Models[0])
+		assert.Equal(t, appsv1.RollingUpdateDeploymentStrategyType, workload.Spec.Strategy.Type)
+		assert.Equal(t, intstr.FromInt32(0), *workload.Spec.Strategy.RollingUpdate.MaxSurge)
+		assert.Equal(t, intstr.FromInt32(1), *workload.Spec.Strategy.RollingUpdate.MaxUnavailable)
+	})
+
+	t.Run("should fall back to the global rollout", func(t *testing.T) {
+		deployment.Spec.VLLM.GlobalConfig = &llmgeeperiov1alpha1.VLLMGlobalConfig{
+			Rollout: &llmgeeperiov1alpha1.RolloutSpec{Strategy: appsv1.RecreateDeploymentStrategyType},
+		}
+		workload := reconciler.buildVLLMModelDeployment(deployment, deployment.Spec.VLLM.Models[0])
+		assert.Equal(t, appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}, workload.Spec.Strategy)
+	})
+
+	t.Run("should let explicit values override the GPU defaults", func(t *testing.T) {
+		deployment.Spec.VLLM.Models[0].Rollout = &llmgeeperiov1alpha1.RolloutSpec{MaxSurge: ptr.To(intstr.FromString("50%"))}
+		workload := reconciler.buildVLLMModelDeployment(deployment, deployment.Spec.VLLM.Models[0])
+		assert.Equal(t, intstr.FromString("50%"), *workload.Spec.Strategy.RollingUpdate.MaxSurge)
+		assert.Equal(t, intstr.FromInt32(1), *workload.Spec.Strategy.RollingUpdate.MaxUnavailable)
+	})
+
+	t.Run("should keep the Kubernetes defaults for CPU workloads", func(t *testing.T) {
+		router := reconciler.buildVLLMRouterDeployment(deployment)
+		assert.Empty(t, router.Spec.Strategy)
+	})
+}
+
+func TestRollout_PodDisruptionBudget(t *testing.T) {
+	scheme := newTestScheme(t)
+	deployment := newRolloutTestDeployment()
+	reconciler := &LMDeploymentReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Scheme: scheme,
+	}
+	workload := reconciler.buildVLLMModelDeployment(deployment, deployment.Spec.VLLM.Models[0])
+	getBudget := func() (*policyv1.PodDisruptionBudget, error) {
+		pdb := &policyv1.PodDisruptionBudget{}
+		err := reconciler.Get(t.Context(), types.NamespacedName{Name: workload.Name, Namespace: "default"}, pdb)
+		return pdb, err
+	}
+
+	t.Run("should protect replicated GPU workloads by default", func(t *testing.T) {
+		require.NoError(t, reconciler.reconcilePodDisruptionBudget(t.Context(), deployment, workload, nil))
+		pdb, err := getBudget()
+		require.NoError(t, err)
+		assert.Equal(t, intstr.FromInt32(1), *pdb.Spec.MaxUnavailable)
+		assert.Equal(t, workload.Spec.Selector, pdb.Spec.Selector)
+		assert.True(t, metav1.IsControlledBy(pdb, deployment))
+	})
+
+	t.Run("should apply the configured budget", func(t *testing.T) {
+		rollout := &llmgeeperiov1alpha1.RolloutSpec{
+			PodDisruptionBudget: &llmgeeperiov1alpha1.PodDisruptionBudgetSpec{MinAvailable: ptr.To(intstr.FromString("50%"))},
+		}
+		require.NoError(t, reconciler.reconcilePodDisruptionBudget(t.Context(), deployment, workload, rollout))
+		pdb, err := getBudget()
+		require.NoError(t, err)
+		assert.Equal(t, intstr.FromString("50%"), *pdb.Spec.MinAvailable)
+		assert.Nil(t, pdb.Spec.MaxUnavailable)
+	})
+
+	t.Run("should remove the budget of a single replica", func(t *testing.T) {
+		workload.Spec.Replicas = ptr.To(int32(1))
+		require.NoError(t, reconciler.reconcilePodDisruptionBudget(t.Context(), deployment, workload, nil))
+		_, err := getBudget()
+		assert.True(t, errors.IsNotFound(err), "a budget on a single replica would block node drains")
+	})
+
+	t.Run("should create a budget when enabled explicitly", func(t *testing.T) {
+		rollout := &llmgeeperiov1alpha1.RolloutSpec{
+			PodDisruptionBudget: &llmgeeperiov1alpha1.PodDisruptionBudgetSpec{Enabled: ptr.To(true)},
+		}
+		require.NoError(t, reconciler.reconcilePodDisruptionBudget(t.Context(), deployment, workload, rollout))
+		_, err := getBudget()
+		assert.NoError(t, err)
+	})
+}
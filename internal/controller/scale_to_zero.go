@@ -0,0 +1,379 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+// behindActivatorLabel marks the pods of a model with an idle timeout, the router reaches them through the activator
+const behindActivatorLabel = "llm.geeper.io/behind-activator"
+
+// +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list;watch;create;update;patch;delete
+
+// scaleToZeroEnabled reports whether any vLLM model has an idle timeout
+func scaleToZeroEnabled(deployment *llmgeeperiov1alpha1.LMDeployment) bool {
+	for _, modelSpec := range deployment.Spec.VLLM.Models {
+		if modelSpec.IdleTimeout != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// vllmActivatorLabels returns the labels of the activator pods of a model
+func vllmActivatorLabels(deployment *llmgeeperiov1alpha1.LMDeployment, modelSpec llmgeeperiov1alpha1.VLLMModelSpec) map[string]string {
+	return map[string]string{
+		"app":            "vllm-activator",
+		"llm-deployment": deployment.Name,
+		"vllm-model":     modelSpec.Name,
+	}
+}
+
+// vllmModelPort returns the port a model is served on, falling back to the global default
+func vllmModelPort(deployment *llmgeeperiov1alpha1.LMDeployment, modelSpec llmgeeperiov1alpha1.VLLMModelSpec) int32 {
+	servicePort := modelSpec.Service.Port
+	if servicePort == 0 && deployment.Spec.VLLM.GlobalConfig != nil {
+		servicePort = deployment.Spec.VLLM.GlobalConfig.Service.Port
+	}
+	if servicePort == 0 {
+		servicePort = 8000
+	}
+	return servicePort
+}
+
+// applyIdleScale scales a model with an idle timeout to zero once it received no requests for that long.
+// It returns whether the model is idle, and otherwise how long until it becomes idle.
+func (r *LMDeploymentReconciler) applyIdleScale(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment, modelSpec llmgeeperiov1alpha1.VLLMModelSpec, workload *appsv1.Deployment) (bool, time.Duration, error) {
+	if modelSpec.IdleTimeout == nil || deployment.Spec.Suspend {
+		return false, 0, nil
+	}
+	timeout := modelSpec.IdleTimeout.Duration
+
+	existing := &appsv1.Deployment{}
+	err := r.Get(ctx, types.NamespacedName{Name: workload.Name, Namespace: workload.Namespace}, existing)
+	if errors.IsNotFound(err) {
+		return false, timeout, nil
+	}
+	if err != nil {
+		return false, 0, err
+	}
+
+	// Requests only reach the activator once the router stopped sending them to the model pods directly
+	if existing.Spec.Template.Labels[behindActivatorLabel] != "true" {
+		return false, timeout, nil
+	}
+
+	remaining := time.Until(lastActivity(existing).Add(timeout))
+	if remaining > 0 {
+		return false, remaining, nil
+	}
+	workload.Spec.Replicas = ptr.To(int32(0))
+	return true, 0, nil
+}
+
+// lastActivity returns the time a model was last requested, activated or rolled out
+func lastActivity(workload *appsv1.Deployment) time.Time {
+	last := workload.CreationTimestamp.Time
+	for _, annotation := range []string{llmgeeperiov1alpha1.LastRequestAnnotation, llmgeeperiov1alpha1.LastActivationAnnotation} {
+		if t, err := time.Parse(time.RFC3339, workload.Annotations[annotation]); err == nil && t.After(last) {
+			last = t
+		}
+	}
+	for _, condition := range workload.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.LastUpdateTime.After(last) {
+			last = condition.LastUpdateTime.Time
+		}
+	}
+	return last
+}
+
+// reconcileVLLMActivator creates the activator of a model with an idle timeout and removes it once the timeout is unset
+func (r *LMDeploymentReconciler) reconcileVLLMActivator(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment, modelSpec llmgeeperiov1alpha1.VLLMModelSpec) error {
+	if modelSpec.IdleTimeout == nil {
+		if err := r.deleteControlled(ctx, deployment, &appsv1.Deployment{}, deployment.GetVLLMModelActivatorName(modelSpec.Name)); err != nil {
+			return err
+		}
+		return r.deleteControlled(ctx, deployment, &corev1.Service{}, deployment.GetVLLMModelBackendServiceName(modelSpec.Name))
+	}
+
+	activator := r.buildVLLMActivatorDeployment(deployment, modelSpec)
+	r.pinImageDigests(deployment, &activator.Spec.Template)
+	if err := r.createOrUpdateDeployment(ctx, activator); err != nil {
+		return err
+	}
+	return r.createOrUpdateService(ctx, r.buildVLLMModelBackendService(deployment, modelSpec))
+}
+
+// buildVLLMModelBackendService builds the service the activator of a model forwards requests to
+func (r *LMDeploymentReconciler) buildVLLMModelBackendService(deployment *llmgeeperiov1alpha1.LMDeployment, modelSpec llmgeeperiov1alpha1.VLLMModelSpec) *corev1.Service {
+	labels := map[string]string{
+		"app":             "vllm",
+		"llm-deployment":  deployment.Name,
+		"vllm-model":      modelSpec.Name,
+		"vllm-model-name": modelSpec.Model,
+	}
+	servicePort := vllmModelPort(deployment, modelSpec)
+
+	backendService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deployment.GetVLLMModelBackendServiceName(modelSpec.Name),
+			Namespace: deployment.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "http",
+					Port:       servicePort,
+					TargetPort: intstr.FromInt32(servicePort),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+			Selector: labels,
+		},
+	}
+
+	// Set owner reference
+	_ = controllerutil.SetControllerReference(deployment, backendService, r.Scheme)
+	return backendService
+}
+
+// buildVLLMActivatorDeployment builds the activator deployment of a model with an idle timeout.
+// The activator listens on the model port and answers /v1/models itself, so the router keeps listing the model while it is scaled to zero.
+func (r *LMDeploymentReconciler) buildVLLMActivatorDeployment(deployment *llmgeeperiov1alpha1.LMDeployment, modelSpec llmgeeperiov1alpha1.VLLMModelSpec) *appsv1.Deployment {
+	labels := vllmActivatorLabels(deployment, modelSpec)
+	servicePort := vllmModelPort(deployment, modelSpec)
+
+	container := corev1.Container{
+		Name:    "activator",
+		Image:   r.imageOrDefault("", r.operatorConfig().Images.Activator),
+		Command: []string{"/activator"},
+		Args: []string{
+			"--listen-address", fmt.Sprintf(":%d", servicePort),
+			"--namespace", deployment.Namespace,
+			"--deployment", deployment.GetVLLMModelDeploymentName(modelSpec.Name),
+			"--backend", fmt.Sprintf("http://%s:%d", deployment.GetVLLMModelBackendServiceName(modelSpec.Name), servicePort),
+			"--models", modelSpec.Model,
+		},
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          "http",
+				ContainerPort: servicePort,
+				Protocol:      corev1.ProtocolTCP,
+			},
+		},
+		ReadinessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path: "/healthz",
+					Port: intstr.FromInt32(servicePort),
+				},
+			},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: ptr.To(false),
+			RunAsNonRoot:             ptr.To(true),
+			Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+		},
+	}
+
+	activatorDeployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deployment.GetVLLMModelActivatorName(modelSpec.Name),
+			Namespace: deployment.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: r.desiredReplicas(deployment, 1),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: deployment.GetVLLMActivatorServiceAccountName(),
+					Containers:         []corev1.Container{container},
+				},
+			},
+		},
+	}
+
+	// Set owner reference
+	_ = controllerutil.SetControllerReference(deployment, activatorDeployment, r.Scheme)
+	return activatorDeployment
+}
+
+// reconcileVLLMActivatorRBAC lets the activators scale their model and record its activity, and removes the access once no model has an idle timeout
+func (r *LMDeploymentReconciler) reconcileVLLMActivatorRBAC(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment) error {
+	name := deployment.GetVLLMActivatorServiceAccountName()
+	if !scaleToZeroEnabled(deployment) {
+		if err := r.deleteControlled(ctx, deployment, &rbacv1.RoleBinding{}, name); err != nil {
+			return err
+		}
+		if err := r.deleteControlled(ctx, deployment, &rbacv1.Role{}, name); err != nil {
+			return err
+		}
+		return r.deleteControlled(ctx, deployment, &corev1.ServiceAccount{}, name)
+	}
+
+	labels := map[string]string{
+		"app":            "vllm-activator",
+		"llm-deployment": deployment.Name,
+	}
+
+	serviceAccount := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: deployment.Namespace, Labels: labels},
+	}
+	_ = controllerutil.SetControllerReference(deployment, serviceAccount, r.Scheme)
+	if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: deployment.Namespace}, &corev1.ServiceAccount{}); errors.IsNotFound(err) {
+		if err := r.Create(ctx, serviceAccount); err != nil {
+			return fmt.Errorf("failed to create service account %s: %w", name, err)
+		}
+	} else if err != nil {
+		return err
+	}
+
+	if err := r.createOrUpdateRole(ctx, r.buildVLLMActivatorRole(deployment, labels)); err != nil {
+		return err
+	}
+
+	roleBinding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: deployment.Namespace, Labels: labels},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     name,
+		},
+		Subjects: []rbacv1.Subject{
+			{Kind: rbacv1.ServiceAccountKind, Name: name, Namespace: deployment.Namespace},
+		},
+	}
+	_ = controllerutil.SetControllerReference(deployment, roleBinding, r.Scheme)
+	if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: deployment.Namespace}, &rbacv1.RoleBinding{}); errors.IsNotFound(err) {
+		if err := r.Create(ctx, roleBinding); err != nil {
+			return fmt.Errorf("failed to create role binding %s: %w", name, err)
+		}
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+// buildVLLMActivatorRole builds the role of the activators, limited to the Deployments of the models with an idle timeout
+func (r *LMDeploymentReconciler) buildVLLMActivatorRole(deployment *llmgeeperiov1alpha1.LMDeployment, labels map[string]string) *rbacv1.Role {
+	var names []string
+	for _, modelSpec := range deployment.Spec.VLLM.Models {
+		if modelSpec.IdleTimeout != nil {
+			names = append(names, deployment.GetVLLMModelDeploymentName(modelSpec.Name))
+		}
+	}
+
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deployment.GetVLLMActivatorServiceAccountName(),
+			Namespace: deployment.Namespace,
+			Labels:    labels,
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups:     []string{appsv1.GroupName},
+				Resources:     []string{"deployments"},
+				ResourceNames: names,
+				Verbs:         []string{"get", "patch"},
+			},
+		},
+	}
+
+	// Set owner reference
+	_ = controllerutil.SetControllerReference(deployment, role, r.Scheme)
+	return role
+}
+
+// createOrUpdateRole creates or updates a role using patch helper to avoid unnecessary reconciliations
+func (r *LMDeploymentReconciler) createOrUpdateRole(ctx context.Context, role *rbacv1.Role) error {
+	existing := &rbacv1.Role{}
+	err := r.Get(ctx, types.NamespacedName{Name: role.Name, Namespace: role.Namespace}, existing)
+	if err != nil && errors.IsNotFound(err) {
+		// Create new role
+		if err := r.Create(ctx, role); err != nil {
+			return err
+		}
+	} else if err == nil {
+		// Update existing role using patch helper
+		if !reflect.DeepEqual(existing.Rules, role.Rules) {
+			patchHelper, err := patch.NewHelper(existing, r.Client)
+			if err != nil {
+				return fmt.Errorf("failed to create patch helper for role %s: %w", role.Name, err)
+			}
+
+			existing.Rules = role.Rules
+			if err := patchHelper.Patch(ctx, existing); err != nil {
+				return fmt.Errorf("failed to patch role %s: %w", role.Name, err)
+			}
+		}
+	} else {
+		return err
+	}
+	return nil
+}
+
+// setScaleToZeroStatus reports the activity the activator recorded on the Deployment of a model with an idle timeout
+func setScaleToZeroStatus(deployment *llmgeeperiov1alpha1.LMDeployment, modelSpec llmgeeperiov1alpha1.VLLMModelSpec, workload *appsv1.Deployment, statuses *[]llmgeeperiov1alpha1.ScaleToZeroStatus) {
+	if modelSpec.IdleTimeout == nil {
+		return
+	}
+
+	status := llmgeeperiov1alpha1.ScaleToZeroStatus{Name: modelSpec.Name}
+	if workload != nil {
+		status.Idle = !deployment.Spec.Suspend && workload.Spec.Replicas != nil && *workload.Spec.Replicas == 0
+		if t, err := time.Parse(time.RFC3339, workload.Annotations[llmgeeperiov1alpha1.LastRequestAnnotation]); err == nil {
+			status.LastRequestTime = &metav1.Time{Time: t}
+		}
+		if t, err := time.Parse(time.RFC3339, workload.Annotations[llmgeeperiov1alpha1.LastActivationAnnotation]); err == nil {
+			status.LastActivationTime = &metav1.Time{Time: t}
+		}
+		if activations, err := strconv.ParseInt(workload.Annotations[llmgeeperiov1alpha1.ActivationsAnnotation], 10, 32); err == nil {
+			status.Activations = int32(activations)
+		}
+		if d, err := time.ParseDuration(workload.Annotations[llmgeeperiov1alpha1.LastColdStartAnnotation]); err == nil {
+			status.LastColdStart = &metav1.Duration{Duration: d}
+		}
+	}
+	*statuses = append(*statuses, status)
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+func newScaleToZeroTestDeployment() *llmgeeperiov1alpha1.LMDeployment {
+	return &llmgeeperiov1alpha1.LMDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "default", UID: "uid"},
+		Spec: llmgeeperiov1alpha1.LMDeploymentSpec{
+			VLLM: llmgeeperiov1alpha1.VLLMSpec{
+				Enabled: true,
+				Models: []llmgeeperiov1alpha1.VLLMModelSpec{
+					{
+						Name:        "llama",
+						Model:       "meta-llama/Llama-3.1-8B-Instruct",
+						Replicas:    2,
+						IdleTimeout: &metav1.Duration{Duration: 10 * time.Minute},
+					},
+					{
+						Name:  "qwen",
+						Model: "Qwen/Qwen2.5-7B-Instruct",
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestScaleToZero_Routing(t *testing.T) {
+	scheme := newTestScheme(t)
+	deployment := newScaleToZeroTestDeployment()
+	reconciler := &LMDeploymentReconciler{Scheme: scheme}
+	idleModel, model := deployment.Spec.VLLM.Models[0], deployment.Spec.VLLM.Models[1]
+
+	t.Run("should serve a model with an idle timeout through its activator", func(t *testing.T) {
+		service := reconciler.buildVLLMModelService(deployment, idleModel)
+		assert.Equal(t, vllmActivatorLabels(deployment, idleModel), service.Spec.Selector)
+
+		backend := reconciler.buildVLLMModelBackendService(deployment, idleModel)
+		workload := reconciler.buildVLLMModelDeployment(deployment, idleModel)
+		assert.Equal(t, workload.Spec.Selector.MatchLabels, backend.Spec.Selector)
+		assert.Equal(t, "true", workload.Spec.Template.Labels[behindActivatorLabel])
+		assert.NotContains(t, workload.Spec.Selector.MatchLabels, behindActivatorLabel)
+
+		activator := reconciler.buildVLLMActivatorDeployment(deployment, idleModel)
+		args := activator.Spec.Template.Spec.Containers[0].Args
+		assert.Contains(t, args, "test-deployment-vllm-llama")
+		assert.Contains(t, args, "http://test-deployment-vllm-llama-backend:8000")
+		assert.Equal(t, "test-deployment-vllm-activator", activator.Spec.Template.Spec.ServiceAccountName)
+	})
+
+	t.Run("should leave models without an idle timeout unchanged", func(t *testing.T) {
+		service := reconciler.buildVLLMModelService(deployment, model)
+		assert.Equal(t, "vllm", service.Spec.Selector["app"])
+		assert.NotContains(t, reconciler.buildVLLMModelDeployment(deployment, model).Spec.Template.Labels, behindActivatorLabel)
+	})
+
+	t.Run("should discover the activators instead of the pods behind them", func(t *testing.T) {
+		args := reconciler.buildVLLMRouterDeployment(deployment).Spec.Template.Spec.Containers[0].Args
+		assert.Contains(t, args, "app in (vllm,vllm-activator),llm-deployment=test-deployment,!llm.geeper.io/behind-activator")
+	})
+}
+
+func TestScaleToZero_IdleScale(t *testing.T) {
+	scheme := newTestScheme(t)
+	deployment := newScaleToZeroTestDeployment()
+	modelSpec := deployment.Spec.VLLM.Models[0]
+	reconciler := &LMDeploymentReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Scheme: scheme,
+	}
+	key := types.NamespacedName{Name: "test-deployment-vllm-llama", Namespace: "default"}
+
+	existing := reconciler.buildVLLMModelDeployment(deployment, modelSpec)
+	existing.Annotations = map[string]string{
+		llmgeeperiov1alpha1.LastRequestAnnotation: time.Now().Add(-5 * time.Minute).UTC().Format(time.RFC3339),
+	}
+	require.NoError(t, reconciler.Create(t.Context(), existing))
+
+	t.Run("should keep a recently requested model running", func(t *testing.T) {
+		workload := reconciler.buildVLLMModelDeployment(deployment, modelSpec)
+		idle, requeueAfter, err := reconciler.applyIdleScale(t.Context(), deployment, modelSpec, workload)
+		require.NoError(t, err)
+		assert.False(t, idle)
+		assert.InDelta(t, (5 * time.Minute).Seconds(), requeueAfter.Seconds(), 5)
+		assert.Equal(t, int32(2), *workload.Spec.Replicas)
+	})
+
+	t.Run("should scale an idle model to zero", func(t *testing.T) {
+		current := &appsv1.Deployment{}
+		require.NoError(t, reconciler.Get(t.Context(), key, current))
+		current.Annotations[llmgeeperiov1alpha1.LastRequestAnnotation] = time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+		require.NoError(t, reconciler.Update(t.Context(), current))
+
+		workload := reconciler.buildVLLMModelDeployment(deployment, modelSpec)
+		idle, requeueAfter, err := reconciler.applyIdleScale(t.Context(), deployment, modelSpec, workload)
+		require.NoError(t, err)
+		assert.True(t, idle)
+		assert.Zero(t, requeueAfter)
+		assert.Equal(t, int32(0), *workload.Spec.Replicas)
+	})
+
+	t.Run("should report the activity recorded by the activator", func(t *testing.T) {
+		current := &appsv1.Deployment{}
+		require.NoError(t, reconciler.Get(t.Context(), key, current))
+		current.Spec.Replicas = new(int32)
+		current.Annotations[llmgeeperiov1alpha1.ActivationsAnnotation] = "3"
+		current.Annotations[llmgeeperiov1alpha1.LastColdStartAnnotation] = "2m30s"
+
+		var statuses []llmgeeperiov1alpha1.ScaleToZeroStatus
+		setScaleToZeroStatus(deployment, modelSpec, current, &statuses)
+		require.Len(t, statuses, 1)
+		assert.True(t, statuses[0].Idle)
+		assert.Equal(t, int32(3), statuses[0].Activations)
+		assert.Equal(t, 150*time.Second, statuses[0].LastColdStart.Duration)
+		assert.NotNil(t, statuses[0].LastRequestTime)
+	})
+}
+
+func TestScaleToZero_ActivatorRBAC(t *testing.T) {
+	scheme := newTestScheme(t)
+	deployment := newScaleToZeroTestDeployment()
+	reconciler := &LMDeploymentReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Scheme: scheme,
+	}
+	key := types.NamespacedName{Name: "test-deployment-vllm-activator", Namespace: "default"}
+
+	t.Run("should only let the activators scale the models with an idle timeout", func(t *testing.T) {
+		require.NoError(t, reconciler.reconcileVLLMActivatorRBAC(t.Context(), deployment))
+
+		role := &rbacv1.Role{}
+		require.NoError(t, reconciler.Get(t.Context(), key, role))
+		require.Len(t, role.Rules, 1)
+		assert.Equal(t, []string{"test-deployment-vllm-llama"}, role.Rules[0].ResourceNames)
+		assert.NoError(t, reconciler.Get(t.Context(), key, &rbacv1.RoleBinding{}))
+		assert.NoError(t, reconciler.Get(t.Context(), key, &corev1.ServiceAccount{}))
+	})
+
+	t.Run("should remove the activator access without idle timeouts", func(t *testing.T) {
+		deployment.Spec.VLLM.Models[0].IdleTimeout = nil
+		require.NoError(t, reconciler.reconcileVLLMActivatorRBAC(t.Context(), deployment))
+
+		assert.True(t, errors.IsNotFound(reconciler.Get(t.Context(), key, &rbacv1.Role{})))
+		assert.True(t, errors.IsNotFound(reconciler.Get(t.Context(), key, &rbacv1.RoleBinding{})))
+		assert.True(t, errors.IsNotFound(reconciler.Get(t.Context(), key, &corev1.ServiceAccount{})))
+	})
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+	"github.com/geeper-io/llm-operator/internal/cron"
+)
+
+// scheduleRequeuePadding delays the reconciliation at a schedule boundary so the new schedule has fired
+const scheduleRequeuePadding = time.Second
+
+// scheduleWindow is the active schedule of a component and the boundaries of its window
+type scheduleWindow struct {
+	// schedule is the active schedule, nil while none has fired yet
+	schedule *llmgeeperiov1alpha1.ScheduleSpec
+	// since is the time the active schedule fired
+	since time.Time
+	// next is the time the next schedule fires, zero when none fires again
+	next time.Time
+}
+
+// activeScheduleWindow returns the schedule that fired last before now and when the next one fires.
+// Schedules with an invalid expression or time zone are ignored, they are rejected by the webhook.
+func activeScheduleWindow(schedules []llmgeeperiov1alpha1.ScheduleSpec, now time.Time) scheduleWindow {
+	var window scheduleWindow
+	for i := range schedules {
+		schedule := &schedules[i]
+		parsed, err := cron.Parse(schedule.Cron)
+		if err != nil {
+			continue
+		}
+		location, err := time.LoadLocation(schedule.Timezone)
+		if err != nil {
+			continue
+		}
+
+		local := now.In(location)
+		if prev := parsed.Prev(local); !prev.IsZero() && (window.schedule == nil || prev.After(window.since)) {
+			window.schedule = schedule
+			window.since = prev
+		}
+		if next := parsed.Next(local); !next.IsZero() && (window.next.IsZero() || next.Before(window.next)) {
+			window.next = next
+		}
+	}
+	return window
+}
+
+// scheduledReplicas returns the replicas of the active schedule, or the given replicas when no schedule is active
+func scheduledReplicas(schedules []llmgeeperiov1alpha1.ScheduleSpec, replicas int32) int32 {
+	if len(schedules) == 0 {
+		return replicas
+	}
+	if window := activeScheduleWindow(schedules, time.Now()); window.schedule != nil {
+		return window.schedule.Replicas
+	}
+	return replicas
+}
+
+// maxScheduledReplicas returns the most replicas a component runs with any of its schedules
+func maxScheduledReplicas(schedules []llmgeeperiov1alpha1.ScheduleSpec, replicas int32) int32 {
+	for _, schedule := range schedules {
+		if schedule.Replicas > replicas {
+			replicas = schedule.Replicas
+		}
+	}
+	return replicas
+}
+
+// componentSchedules returns the schedules of every enabled component and vLLM model by name
+func componentSchedules(deployment *llmgeeperiov1alpha1.LMDeployment) map[string][]llmgeeperiov1alpha1.ScheduleSpec {
+	schedules := map[string][]llmgeeperiov1alpha1.ScheduleSpec{}
+	if deployment.Spec.Ollama.Enabled {
+		schedules["ollama"] = deployment.Spec.Ollama.Schedules
+	}
+	if deployment.Spec.VLLM.Enabled {
+		for _, modelSpec := range deployment.Spec.VLLM.Models {
+			schedules["vllm-"+modelSpec.Name] = modelSpec.Schedules
+		}
+	}
+	if deployment.Spec.OpenWebUI.Enabled {
+		schedules["openwebui"] = deployment.Spec.OpenWebUI.Schedules
+	}
+	if deployment.Spec.Tabby.Enabled {
+		schedules["tabby"] = deployment.Spec.Tabby.Schedules
+	}
+	return schedules
+}
+
+// scheduleRequeueAfter returns how long until the next schedule of any component fires, zero without schedules
+func scheduleRequeueAfter(deployment *llmgeeperiov1alpha1.LMDeployment) time.Duration {
+	now := time.Now()
+	var requeueAfter time.Duration
+	for _, schedules := range componentSchedules(deployment) {
+		if len(schedules) == 0 {
+			continue
+		}
+		window := activeScheduleWindow(schedules, now)
+		if window.next.IsZero() {
+			continue
+		}
+		if remaining := window.next.Sub(now) + scheduleRequeuePadding; requeueAfter == 0 || remaining < requeueAfter {
+			requeueAfter = remaining
+		}
+	}
+	return requeueAfter
+}
+
+// setScheduleStatus reports the active schedule of a component or model
+func setScheduleStatus(name string, schedules []llmgeeperiov1alpha1.ScheduleSpec, statuses *[]llmgeeperiov1alpha1.ScheduleStatus) {
+	if len(schedules) == 0 {
+		return
+	}
+
+	status := llmgeeperiov1alpha1.ScheduleStatus{Name: name}
+	window := activeScheduleWindow(schedules, time.Now())
+	if window.schedule != nil {
+		status.ActiveSchedule = window.schedule.Name
+		status.Replicas = window.schedule.Replicas
+		status.Since = &metav1.Time{Time: window.since}
+	}
+	if !window.next.IsZero() {
+		status.NextTransition = &metav1.Time{Time: window.next}
+	}
+	*statuses = append(*statuses, status)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+// workingHours runs 3 replicas on weekdays from 8 to 19 in Berlin and none otherwise
+var workingHours = []llmgeeperiov1alpha1.ScheduleSpec{
+	{Name: "working-hours", Cron: "0 8 * * 1-5", Timezone: "Europe/Berlin", Replicas: 3},
+	{Name: "night", Cron: "0 19 * * 1-5", Timezone: "Europe/Berlin", Replicas: 0},
+}
+
+func TestActiveScheduleWindow(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	t.Run("should activate the schedule that fired last", func(t *testing.T) {
+		window := activeScheduleWindow(workingHours, time.Date(2025, time.March, 5, 10, 0, 0, 0, berlin))
+		require.NotNil(t, window.schedule)
+		assert.Equal(t, "working-hours", window.schedule.Name)
+		assert.True(t, window.since.Equal(time.Date(2025, time.March, 5, 8, 0, 0, 0, berlin)))
+		assert.True(t, window.next.Equal(time.Date(2025, time.March, 5, 19, 0, 0, 0, berlin)))
+	})
+
+	t.Run("should keep the night schedule over the weekend", func(t *testing.T) {
+		window := activeScheduleWindow(workingHours, time.Date(2025, time.March, 8, 12, 0, 0, 0, time.UTC))
+		require.NotNil(t, window.schedule)
+		assert.Equal(t, "night", window.schedule.Name)
+		assert.True(t, window.next.Equal(time.Date(2025, time.March, 10, 8, 0, 0, 0, berlin)))
+	})
+
+	t.Run("should ignore invalid schedules", func(t *testing.T) {
+		window := activeScheduleWindow([]llmgeeperiov1alpha1.ScheduleSpec{
+			{Name: "broken", Cron: "not a cron", Replicas: 1},
+			{Name: "nowhere", Cron: "@daily", Timezone: "Nowhere/City", Replicas: 1},
+		}, time.Now())
+		assert.Nil(t, window.schedule)
+		assert.True(t, window.next.IsZero())
+	})
+}
+
+func TestSchedules(t *testing.T) {
+	scheme := newTestScheme(t)
+	reconciler := &LMDeploymentReconciler{Scheme: scheme}
+	// Fires every minute, always active
+	always := []llmgeeperiov1alpha1.ScheduleSpec{{Name: "always", Cron: "* * * * *", Replicas: 4}}
+
+	deployment := &llmgeeperiov1alpha1.LMDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "default"},
+		Spec: llmgeeperiov1alpha1.LMDeploymentSpec{
+			VLLM: llmgeeperiov1alpha1.VLLMSpec{
+				Enabled: true,
+				Models: []llmgeeperiov1alpha1.VLLMModelSpec{
+					{Name: "llama", Model: "meta-llama/Llama-3.1-8B-Instruct", Replicas: 1, Schedules: always},
+				},
+			},
+			OpenWebUI: llmgeeperiov1alpha1.OpenWebUISpec{Enabled: true, Replicas: 1, Schedules: workingHours},
+		},
+	}
+
+	t.Run("should run the replicas of the active schedule", func(t *testing.T) {
+		workload := reconciler.buildVLLMModelDeployment(deployment, deployment.Spec.VLLM.Models[0])
+		assert.Equal(t, int32(4), *workload.Spec.Replicas)
+	})
+
+	t.Run("should requeue at the next schedule boundary", func(t *testing.T) {
+		requeueAfter := scheduleRequeueAfter(deployment)
+		assert.Positive(t, requeueAfter)
+		assert.LessOrEqual(t, requeueAfter, time.Minute+scheduleRequeuePadding)
+	})
+
+	t.Run("should report the active schedule", func(t *testing.T) {
+		var statuses []llmgeeperiov1alpha1.ScheduleStatus
+		setScheduleStatus("llama", always, &statuses)
+		require.Len(t, statuses, 1)
+		assert.Equal(t, "always", statuses[0].ActiveSchedule)
+		assert.Equal(t, int32(4), statuses[0].Replicas)
+		assert.NotNil(t, statuses[0].Since)
+		assert.NotNil(t, statuses[0].NextTransition)
+	})
+
+	t.Run("should deploy Redis when a schedule runs several OpenWebUI replicas", func(t *testing.T) {
+		assert.True(t, redisDeployed(deployment))
+	})
+}
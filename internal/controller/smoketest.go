@@ -0,0 +1,385 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+const (
+	// defaultSmokeTestTimeout leaves a freshly started model time to load its weights
+	defaultSmokeTestTimeout = 10 * time.Minute
+
+	// smokeTestRetryMargin is the time left after the last retry for the sleep and the request, up to a minute each,
+	// so the script reports the failure before the Job deadline kills it
+	smokeTestRetryMargin = 90 * time.Second
+
+	// smokeTestScript retries the completion request until the model answers or RETRY_SECONDS passed.
+	// EXPECT is matched as a POSIX extended regular expression, the webhook validates it with the same syntax.
+	smokeTestScript = `deadline=$(( $(date +%s) + RETRY_SECONDS ))
+until response=$(curl -sf --max-time 60 -H "Authorization: Bearer $VLLM_API_KEY" -H "Content-Type: application/json" -d "$REQUEST" "$URL"); do
+  if [ "$(date +%s)" -ge "$deadline" ]; then
+    echo "$URL did not answer"
+    exit 1
+  fi
+  echo "waiting for $URL"
+  sleep 10
+done
+echo "$response"
+echo "$response" | grep -q '"choices"' || exit 1
+[ -z "$EXPECT" ] || echo "$response" | grep -Eq -e "$EXPECT"`
+
+	// smokeTestRevisionLabel identifies the rollout of a model a smoke test Job verifies
+	smokeTestRevisionLabel = "llm.geeper.io/revision"
+)
+
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+
+// smokeTestLabels returns the labels of the smoke test Jobs of a model
+func smokeTestLabels(deployment *llmgeeperiov1alpha1.LMDeployment, model string) map[string]string {
+	return map[string]string{
+		"app":            "vllm-smoke-test",
+		"llm-deployment": deployment.Name,
+		"vllm-model":     model,
+	}
+}
+
+// buildSmokeTestJob builds a Job sending a chat completion request for the served model to the service and expecting choices in the answer
+func (r *LMDeploymentReconciler) buildSmokeTestJob(deployment *llmgeeperiov1alpha1.LMDeployment, model, name string, service *corev1.Service, servedModel string, smokeTest *llmgeeperiov1alpha1.SmokeTestSpec) *batchv1.Job {
+	labels := smokeTestLabels(deployment, model)
+
+	prompt := smokeTest.Prompt
+	if prompt == "" {
+		prompt = "Say hello."
+	}
+	maxTokens := smokeTest.MaxTokens
+	if maxTokens == 0 {
+		maxTokens = 16
+	}
+	timeout := defaultSmokeTestTimeout
+	if smokeTest.Timeout != nil {
+		timeout = smokeTest.Timeout.Duration
+	}
+	request, _ := json.Marshal(map[string]any{
+		"model":      servedModel,
+		"messages":   []map[string]string{{"role": "user", "content": prompt}},
+		"max_tokens": maxTokens,
+	})
+
+	var port int32 = 8000
+	if len(service.Spec.Ports) > 0 {
+		port = service.Spec.Ports[0].Port
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: deployment.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          ptr.To(int32(0)),
+			ActiveDeadlineSeconds: ptr.To(int64(timeout.Seconds())),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{
+						{
+							Name:    "smoke-test",
+							Image:   r.imageOrDefault(smokeTest.Image, r.operatorConfig().Images.SmokeTest),
+							Command: []string{"sh", "-c", smokeTestScript},
+							Env: []corev1.EnvVar{
+								{
+									Name:  "URL",
+									Value: fmt.Sprintf("http://%s:%d/v1/chat/completions", service.Name, port),
+								},
+								{
+									Name:  "REQUEST",
+									Value: string(request),
+								},
+								{
+									Name:  "EXPECT",
+									Value: smokeTest.Expect,
+								},
+								{
+									Name:  "RETRY_SECONDS",
+									Value: strconv.Itoa(int(max(timeout-smokeTestRetryMargin, 0).Seconds())),
+								},
+								{
+									Name: "VLLM_API_KEY",
+									ValueFrom: &corev1.EnvVarSource{
+										SecretKeyRef: &corev1.SecretKeySelector{
+											LocalObjectReference: corev1.LocalObjectReference{
+												Name: deployment.GetVLLMApiKeySecretName(),
+											},
+											Key: llmgeeperiov1alpha1.VLLMApiKeySecretKey,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Set owner reference
+	_ = controllerutil.SetControllerReference(deployment, job, r.Scheme)
+	return job
+}
+
+// ensureSmokeTest creates a smoke test Job unless it already exists, Jobs are not updated as they only run once
+func (r *LMDeploymentReconciler) ensureSmokeTest(ctx context.Context, job *batchv1.Job) error {
+	existing := &batchv1.Job{}
+	err := r.Get(ctx, types.NamespacedName{Name: job.Name, Namespace: job.Namespace}, existing)
+	if errors.IsNotFound(err) {
+		if err := r.Create(ctx, job); err != nil {
+			return fmt.Errorf("failed to create smoke test %s: %w", job.Name, err)
+		}
+		return nil
+	}
+	return err
+}
+
+// deleteStaleSmokeTests removes the smoke test Jobs of a model except the ones to keep
+func (r *LMDeploymentReconciler) deleteStaleSmokeTests(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment, model string, keep ...string) error {
+	jobs := &batchv1.JobList{}
+	if err := r.List(ctx, jobs, client.InNamespace(deployment.Namespace), client.MatchingLabels(smokeTestLabels(deployment, model))); err != nil {
+		return fmt.Errorf("failed to list smoke tests: %w", err)
+	}
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if containsString(keep, job.Name) || !metav1.IsControlledBy(job, deployment) {
+			continue
+		}
+		if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete smoke test %s: %w", job.Name, err)
+		}
+	}
+	return nil
+}
+
+// smokeTestResult returns the result of the named smoke test Job
+func (r *LMDeploymentReconciler) smokeTestResult(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment, name string) string {
+	job := &batchv1.Job{}
+	if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: deployment.Namespace}, job); err != nil {
+		return llmgeeperiov1alpha1.SmokeTestPending
+	}
+	result, _ := jobResult(job)
+	return result
+}
+
+// jobResult returns the result of a smoke test or benchmark Job and when it finished
+func jobResult(job *batchv1.Job) (string, time.Time) {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return llmgeeperiov1alpha1.SmokeTestSucceeded, condition.LastTransitionTime.Time
+		case batchv1.JobFailed:
+			return llmgeeperiov1alpha1.SmokeTestFailed, condition.LastTransitionTime.Time
+		}
+	}
+	return llmgeeperiov1alpha1.SmokeTestRunning, time.Time{}
+}
+
+// workloadRolledOut reports whether a workload runs replicas and all of them run its current pod template and are ready
+func workloadRolledOut(workload *appsv1.Deployment) bool {
+	if workload.Spec.Replicas == nil || *workload.Spec.Replicas == 0 || workload.Status.ObservedGeneration < workload.Generation {
+		return false
+	}
+	replicas := *workload.Spec.Replicas
+	return workload.Status.Replicas == replicas && workload.Status.UpdatedReplicas == replicas && workload.Status.ReadyReplicas == replicas
+}
+
+// rolloutRevision returns the hash identifying the pod template of a workload and the smoke test verifying it
+func rolloutRevision(workload *appsv1.Deployment, smokeTest *llmgeeperiov1alpha1.SmokeTestSpec) string {
+	template, _ := json.Marshal(workload.Spec.Template)
+	spec, _ := json.Marshal(smokeTest)
+	hasher := newConfigHasher()
+	hasher.addString("template", string(template))
+	hasher.addString("smokeTest", string(spec))
+	return hasher.sum()
+}
+
+// smokeTestRun returns the run of the smoke test of a revision due at the given time and how long until the next run.
+// Without an interval a revision is tested once.
+func smokeTestRun(revision string, interval *metav1.Duration, now time.Time) (string, time.Duration) {
+	if interval == nil || interval.Duration <= 0 {
+		return revision, 0
+	}
+	slot := now.UnixNano() / int64(interval.Duration)
+	next := time.Unix(0, (slot+1)*int64(interval.Duration)).Sub(now)
+
+	hasher := newConfigHasher()
+	hasher.addString("revision", revision)
+	hasher.addString("slot", fmt.Sprintf("%d", slot))
+	return hasher.sum(), next
+}
+
+// reconcileVLLMSmokeTest tests a model once its rollout finished, and periodically with an interval.
+// Models with an idle timeout are tested through their backend service so the test doesn't keep them running.
+// It returns when the next periodic run is due.
+func (r *LMDeploymentReconciler) reconcileVLLMSmokeTest(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment, modelSpec llmgeeperiov1alpha1.VLLMModelSpec) (time.Duration, error) {
+	if modelSpec.SmokeTest == nil {
+		return 0, r.deleteStaleSmokeTests(ctx, deployment, modelSpec.Name)
+	}
+
+	// The Deployment watch reconciles again once the rollout finished
+	workload := &appsv1.Deployment{}
+	err := r.Get(ctx, types.NamespacedName{Name: deployment.GetVLLMModelDeploymentName(modelSpec.Name), Namespace: deployment.Namespace}, workload)
+	if errors.IsNotFound(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if !workloadRolledOut(workload) {
+		return 0, nil
+	}
+
+	revision := rolloutRevision(workload, modelSpec.SmokeTest)
+	run, next := smokeTestRun(revision, modelSpec.SmokeTest.Interval, time.Now())
+	service := r.buildVLLMModelService(deployment, modelSpec)
+	if modelSpec.IdleTimeout != nil {
+		service = r.buildVLLMModelBackendService(deployment, modelSpec)
+	}
+	job := r.buildSmokeTestJob(deployment, modelSpec.Name, deployment.GetVLLMModelSmokeTestName(modelSpec.Name, run), service, modelSpec.Model, modelSpec.SmokeTest)
+	job.Labels = mergeStringMaps(job.Labels, map[string]string{smokeTestRevisionLabel: revision})
+	if err := r.ensureSmokeTest(ctx, job); err != nil {
+		return 0, err
+	}
+
+	// Keep the last finished run of the revision until the new one finished, it is reported meanwhile
+	keep := []string{job.Name}
+	if last, err := r.lastSmokeTest(ctx, deployment, modelSpec.Name, revision, job.Name); err != nil {
+		return 0, err
+	} else if last != nil {
+		keep = append(keep, last.Name)
+	}
+	return next, r.deleteStaleSmokeTests(ctx, deployment, modelSpec.Name, keep...)
+}
+
+// lastSmokeTest returns the last finished smoke test Job of a revision of a model, except the excluded one
+func (r *LMDeploymentReconciler) lastSmokeTest(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment, model, revision, exclude string) (*batchv1.Job, error) {
+	jobs := &batchv1.JobList{}
+	labels := mergeStringMaps(smokeTestLabels(deployment, model), map[string]string{smokeTestRevisionLabel: revision})
+	if err := r.List(ctx, jobs, client.InNamespace(deployment.Namespace), client.MatchingLabels(labels)); err != nil {
+		return nil, fmt.Errorf("failed to list smoke tests: %w", err)
+	}
+
+	var last *batchv1.Job
+	var lastFinished time.Time
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if job.Name == exclude {
+			continue
+		}
+		if result, finished := jobResult(job); result != llmgeeperiov1alpha1.SmokeTestRunning && (last == nil || finished.After(lastFinished)) {
+			last, lastFinished = job, finished
+		}
+	}
+	return last, nil
+}
+
+// setSmokeTestStatus reports the last finished smoke test of the current rollout of a model,
+// Running while the first test of a rollout runs and Pending until it started
+func (r *LMDeploymentReconciler) setSmokeTestStatus(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment, modelSpec llmgeeperiov1alpha1.VLLMModelSpec, workload *appsv1.Deployment, statuses *[]llmgeeperiov1alpha1.SmokeTestStatus) {
+	if modelSpec.SmokeTest == nil {
+		return
+	}
+
+	status := llmgeeperiov1alpha1.SmokeTestStatus{Name: modelSpec.Name, Result: llmgeeperiov1alpha1.SmokeTestPending}
+	if workload != nil {
+		status.Revision = rolloutRevision(workload, modelSpec.SmokeTest)
+		if last, err := r.lastSmokeTest(ctx, deployment, modelSpec.Name, status.Revision, ""); err == nil && last != nil {
+			result, finished := jobResult(last)
+			status.Result = result
+			status.Job = last.Name
+			status.LastRunTime = &metav1.Time{Time: finished}
+		} else if workloadRolledOut(workload) {
+			run, _ := smokeTestRun(status.Revision, modelSpec.SmokeTest.Interval, time.Now())
+			if name := deployment.GetVLLMModelSmokeTestName(modelSpec.Name, run); r.smokeTestResult(ctx, deployment, name) == llmgeeperiov1alpha1.SmokeTestRunning {
+				status.Result = llmgeeperiov1alpha1.SmokeTestRunning
+				status.Job = name
+			}
+		}
+	}
+	*statuses = append(*statuses, status)
+}
+
+// setModelVerifiedCondition reports whether the smoke tests of all models succeeded, it is removed when no model is tested
+func setModelVerifiedCondition(deployment *llmgeeperiov1alpha1.LMDeployment, statuses []llmgeeperiov1alpha1.SmokeTestStatus, conditions *[]metav1.Condition) {
+	if len(statuses) == 0 {
+		meta.RemoveStatusCondition(conditions, llmgeeperiov1alpha1.ConditionModelVerified)
+		return
+	}
+
+	var failed, pending []string
+	for _, status := range statuses {
+		switch status.Result {
+		case llmgeeperiov1alpha1.SmokeTestSucceeded:
+		case llmgeeperiov1alpha1.SmokeTestFailed:
+			failed = append(failed, status.Name)
+		default:
+			pending = append(pending, status.Name)
+		}
+	}
+
+	condition := metav1.Condition{
+		Type:               llmgeeperiov1alpha1.ConditionModelVerified,
+		Status:             metav1.ConditionTrue,
+		Reason:             "SmokeTestsSucceeded",
+		Message:            "The smoke tests of all models succeeded",
+		ObservedGeneration: deployment.Generation,
+	}
+	switch {
+	case len(failed) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "SmokeTestFailed"
+		condition.Message = fmt.Sprintf("The smoke test failed for models: %s", strings.Join(failed, ", "))
+	case len(pending) > 0:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = "SmokeTestPending"
+		condition.Message = fmt.Sprintf("Waiting for the smoke test of models: %s", strings.Join(pending, ", "))
+	}
+	meta.SetStatusCondition(conditions, condition)
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+func TestSmokeTestRun(t *testing.T) {
+	now := time.Date(2025, time.March, 5, 10, 20, 0, 0, time.UTC)
+
+	t.Run("should test a revision once without an interval", func(t *testing.T) {
+		run, next := smokeTestRun("revision", nil, now)
+		assert.Equal(t, "revision", run)
+		assert.Zero(t, next)
+	})
+
+	t.Run("should run again once the interval passed", func(t *testing.T) {
+		interval := &metav1.Duration{Duration: time.Hour}
+		run, next := smokeTestRun("revision", interval, now)
+		assert.Equal(t, 40*time.Minute, next)
+
+		same, _ := smokeTestRun("revision", interval, now.Add(39*time.Minute))
+		assert.Equal(t, run, same)
+		later, _ := smokeTestRun("revision", interval, now.Add(41*time.Minute))
+		assert.NotEqual(t, run, later)
+		other, _ := smokeTestRun("other", interval, now)
+		assert.NotEqual(t, run, other)
+	})
+}
+
+func TestSmokeTest_Rollout(t *testing.T) {
+	ctx := context.Background()
+	scheme := newTestScheme(t)
+	deployment := &llmgeeperiov1alpha1.LMDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "default", UID: "uid"},
+		Spec: llmgeeperiov1alpha1.LMDeploymentSpec{
+			VLLM: llmgeeperiov1alpha1.VLLMSpec{
+				Enabled: true,
+				Models: []llmgeeperiov1alpha1.VLLMModelSpec{
+					{
+						Name:      "llama",
+						Model:     "meta-llama/Llama-3.1-8B-Instruct",
+						Replicas:  2,
+						SmokeTest: &llmgeeperiov1alpha1.SmokeTestSpec{Prompt: "What is 2+2?", Expect: "4"},
+					},
+				},
+			},
+		},
+	}
+	modelSpec := deployment.Spec.VLLM.Models[0]
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	reconciler := &LMDeploymentReconciler{Client: c, Scheme: scheme}
+
+	workload := reconciler.buildVLLMModelDeployment(deployment, modelSpec)
+	require.NoError(t, c.Create(ctx, workload))
+	listJobs := func() []batchv1.Job {
+		jobs := &batchv1.JobList{}
+		require.NoError(t, c.List(ctx, jobs, client.InNamespace("default")))
+		return jobs.Items
+	}
+	report := func() llmgeeperiov1alpha1.SmokeTestStatus {
+		require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(workload), workload))
+		var statuses []llmgeeperiov1alpha1.SmokeTestStatus
+		reconciler.setSmokeTestStatus(ctx, deployment, modelSpec, workload, &statuses)
+		require.Len(t, statuses, 1)
+		setModelVerifiedCondition(deployment, statuses, &deployment.Status.VLLMStatus.Conditions)
+		return statuses[0]
+	}
+
+	t.Run("should wait for the rollout to finish", func(t *testing.T) {
+		_, err := reconciler.reconcileVLLMSmokeTest(ctx, deployment, modelSpec)
+		require.NoError(t, err)
+		assert.Empty(t, listJobs())
+
+		assert.Equal(t, llmgeeperiov1alpha1.SmokeTestPending, report().Result)
+		condition := meta.FindStatusCondition(deployment.Status.VLLMStatus.Conditions, llmgeeperiov1alpha1.ConditionModelVerified)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionUnknown, condition.Status)
+	})
+
+	t.Run("should test the model through its service once rolled out", func(t *testing.T) {
+		workload.Status = appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 2, ReadyReplicas: 2}
+		require.NoError(t, c.Status().Update(ctx, workload))
+
+		_, err := reconciler.reconcileVLLMSmokeTest(ctx, deployment, modelSpec)
+		require.NoError(t, err)
+		jobs := listJobs()
+		require.Len(t, jobs, 1)
+		env := jobs[0].Spec.Template.Spec.Containers[0].Env
+		assert.Equal(t, "http://test-deployment-vllm-llama:8000/v1/chat/completions", env[0].Value)
+		assert.Contains(t, env[1].Value, "What is 2+2?")
+		assert.Equal(t, "4", env[2].Value)
+		assert.Equal(t, "RETRY_SECONDS", env[3].Name)
+		assert.Equal(t, "510", env[3].Value, "retries stop before the 10m deadline")
+		assert.Equal(t, llmgeeperiov1alpha1.SmokeTestRunning, report().Result)
+
+		jobs[0].Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, LastTransitionTime: metav1.Now()}}
+		require.NoError(t, c.Status().Update(ctx, &jobs[0]))
+
+		status := report()
+		assert.Equal(t, llmgeeperiov1alpha1.SmokeTestFailed, status.Result)
+		assert.Equal(t, jobs[0].Name, status.Job)
+		condition := meta.FindStatusCondition(deployment.Status.VLLMStatus.Conditions, llmgeeperiov1alpha1.ConditionModelVerified)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Contains(t, condition.Message, "llama")
+	})
+
+	t.Run("should test again after the next rollout", func(t *testing.T) {
+		workload.Spec.Template.Spec.Containers[0].Image = "vllm/vllm-openai:v0.10.0"
+		require.NoError(t, c.Update(ctx, workload))
+		workload.Status = appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 2, ReadyReplicas: 2}
+		require.NoError(t, c.Status().Update(ctx, workload))
+
+		_, err := reconciler.reconcileVLLMSmokeTest(ctx, deployment, modelSpec)
+		require.NoError(t, err)
+		jobs := listJobs()
+		require.Len(t, jobs, 1, "the test of the previous rollout is removed")
+		assert.Equal(t, llmgeeperiov1alpha1.SmokeTestRunning, report().Result)
+
+		jobs[0].Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue, LastTransitionTime: metav1.Now()}}
+		require.NoError(t, c.Status().Update(ctx, &jobs[0]))
+		assert.Equal(t, llmgeeperiov1alpha1.SmokeTestSucceeded, report().Result)
+		assert.True(t, meta.IsStatusConditionTrue(deployment.Status.VLLMStatus.Conditions, llmgeeperiov1alpha1.ConditionModelVerified))
+	})
+
+	t.Run("should remove the tests and the condition without a smoke test", func(t *testing.T) {
+		modelSpec.SmokeTest = nil
+		_, err := reconciler.reconcileVLLMSmokeTest(ctx, deployment, modelSpec)
+		require.NoError(t, err)
+		assert.Empty(t, listJobs())
+
+		setModelVerifiedCondition(deployment, nil, &deployment.Status.VLLMStatus.Conditions)
+		assert.Nil(t, meta.FindStatusCondition(deployment.Status.VLLMStatus.Conditions, llmgeeperiov1alpha1.ConditionModelVerified))
+		assert.NoError(t, c.Get(ctx, types.NamespacedName{Name: workload.Name, Namespace: "default"}, &appsv1.Deployment{}))
+	})
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+)
+
+func newSuspendTestDeployment() *llmgeeperiov1alpha1.LMDeployment {
+	return &llmgeeperiov1alpha1.LMDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-deployment",
+			Namespace: "default",
+		},
+		Spec: llmgeeperiov1alpha1.LMDeploymentSpec{
+			Ollama: llmgeeperiov1alpha1.OllamaSpec{
+				Enabled:  true,
+				Replicas: 2,
+				Models:   []string{"llama2:7b"},
+			},
+			VLLM: llmgeeperiov1alpha1.VLLMSpec{
+				Enabled: true,
+				Models: []llmgeeperiov1alpha1.VLLMModelSpec{
+					{Name: "llama", Model: "meta-llama/Llama-2-7b-chat-hf", Replicas: 3},
+				},
+			},
+			OpenWebUI: llmgeeperiov1alpha1.OpenWebUISpec{
+				Enabled:   true,
+				Replicas:  2,
+				Pipelines: &llmgeeperiov1alpha1.PipelinesSpec{Enabled: true},
+			},
+			Tabby: llmgeeperiov1alpha1.TabbySpec{
+				Enabled:  true,
+				Replicas: 2,
+			},
+		},
+	}
+}
+
+func TestSuspend_ScalesWorkloadsToZero(t *testing.T) {
+	reconciler := &LMDeploymentReconciler{Scheme: newTestScheme(t)}
+	deployment := newSuspendTestDeployment()
+
+	build := func() map[string]*appsv1.Deployment {
+		return map[string]*appsv1.Deployment{
+			"ollama":    reconciler.buildOllamaDeployment(deployment),
+			"vllm":      reconciler.buildVLLMModelDeployment(deployment, deployment.Spec.VLLM.Models[0]),
+			"router":    reconciler.buildVLLMRouterDeployment(deployment),
+			"openwebui": reconciler.buildOpenWebUIDeployment(deployment),
+			"pipelines": reconciler.buildPipelinesDeployment(deployment),
+			"redis":     reconciler.buildRedisDeployment(deployment),
+			"tabby":     reconciler.buildTabbyDeployment(deployment),
+		}
+	}
+
+	t.Run("should keep configured replicas when not suspended", func(t *testing.T) {
+		deployments := build()
+		assert.Equal(t, int32(2), *deployments["ollama"].Spec.Replicas)
+		assert.Equal(t, int32(3), *deployments["vllm"].Spec.Replicas)
+		assert.Equal(t, int32(1), *deployments["redis"].Spec.Replicas)
+		assert.Equal(t, int32(2), *deployments["tabby"].Spec.Replicas)
+	})
+
+	t.Run("should scale every workload to zero when suspended", func(t *testing.T) {
+		deployment.Spec.Suspend = true
+		for name, d := range build() {
+			require.NotNil(t, d.Spec.Replicas, name)
+			assert.Equal(t, int32(0), *d.Spec.Replicas, name)
+		}
+	})
+}
+
+func TestSuspend_StatusConditions(t *testing.T) {
+	reconciler := &LMDeploymentReconciler{}
+
+	t.Run("should report suspend and pause as false by default", func(t *testing.T) {
+		deployment := newSuspendTestDeployment()
+		reconciler.setSuspendConditions(deployment)
+
+		assert.True(t, meta.IsStatusConditionFalse(deployment.Status.Conditions, llmgeeperiov1alpha1.ConditionSuspended))
+		assert.True(t, meta.IsStatusConditionFalse(deployment.Status.Conditions, llmgeeperiov1alpha1.ConditionReconcilePaused))
+	})
+
+	t.Run("should report suspend and pause as true when requested", func(t *testing.T) {
+		deployment := newSuspendTestDeployment()
+		deployment.Spec.Suspend = true
+		deployment.Annotations = map[string]string{llmgeeperiov1alpha1.ReconcilePausedAnnotation: "true"}
+		reconciler.setSuspendConditions(deployment)
+
+		assert.True(t, meta.IsStatusConditionTrue(deployment.Status.Conditions, llmgeeperiov1alpha1.ConditionSuspended))
+		assert.True(t, meta.IsStatusConditionTrue(deployment.Status.Conditions, llmgeeperiov1alpha1.ConditionReconcilePaused))
+	})
+}
+
+func TestSuspend_PausedReconcileIsNoop(t *testing.T) {
+	scheme := newTestScheme(t)
+	deployment := newSuspendTestDeployment()
+	deployment.Finalizers = []string{FinalizerName}
+	deployment.Annotations = map[string]string{llmgeeperiov1alpha1.ReconcilePausedAnnotation: "true"}
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(deployment).
+		WithStatusSubresource(deployment).
+		Build()
+	reconciler := &LMDeploymentReconciler{Client: k8sClient, Scheme: scheme}
+
+	_, err := reconciler.Reconcile(t.Context(), reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: deployment.Name, Namespace: deployment.Namespace},
+	})
+	require.NoError(t, err)
+
+	deployments := &appsv1.DeploymentList{}
+	require.NoError(t, k8sClient.List(t.Context(), deployments))
+	assert.Empty(t, deployments.Items)
+
+	updated := &llmgeeperiov1alpha1.LMDeployment{}
+	require.NoError(t, k8sClient.Get(t.Context(), types.NamespacedName{Name: deployment.Name, Namespace: deployment.Namespace}, updated))
+	assert.Equal(t, llmgeeperiov1alpha1.PhasePaused, updated.Status.Phase)
+	assert.True(t, meta.IsStatusConditionTrue(updated.Status.Conditions, llmgeeperiov1alpha1.ConditionReconcilePaused))
+}
+
+// newTestScheme returns a scheme with the core Kubernetes and LMDeployment types registered
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, llmgeeperiov1alpha1.AddToScheme(scheme))
+	return scheme
+}
@@ -10,11 +10,13 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+	operatorconfig "github.com/geeper-io/llm-operator/internal/config"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
@@ -39,9 +41,18 @@ func (r *LMDeploymentReconciler) reconcileTabby(ctx context.Context, deployment
 
 	// Create or update Tabby deployment
 	tabbyDeployment := r.buildTabbyDeployment(deployment)
+	r.pinImageDigests(deployment, &tabbyDeployment.Spec.Template)
+
+	// Restart Tabby when config.toml changes, the init container only copies it at start
+	hasher := newConfigHasher()
+	hasher.addData(tabbySecret.Name, tabbySecret.Data)
+	setConfigHash(tabbyDeployment, hasher.sum())
 	if err := r.createOrUpdateDeployment(ctx, tabbyDeployment); err != nil {
 		return err
 	}
+	if err := r.reconcilePodDisruptionBudget(ctx, deployment, tabbyDeployment, deployment.Spec.Tabby.Rollout); err != nil {
+		return err
+	}
 
 	// Create or update Tabby service
 	tabbyService := r.buildTabbyService(deployment)
@@ -57,6 +68,16 @@ func (r *LMDeploymentReconciler) reconcileTabby(ctx context.Context, deployment
 		}
 	}
 
+	// Create or update Tabby HTTPRoute if a Gateway is configured, remove it otherwise
+	if deployment.Spec.Tabby.Gateway != nil {
+		tabbyRoute := r.buildTabbyHTTPRoute(deployment)
+		if err := r.createOrUpdateHTTPRoute(ctx, tabbyRoute); err != nil {
+			return err
+		}
+	} else if err := r.deleteHTTPRoute(ctx, deployment, deployment.GetTabbyHTTPRouteName()); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -71,12 +92,13 @@ func (r *LMDeploymentReconciler) buildTabbyDeployment(deployment *llmgeeperiov1a
 	// Build Tabby deployment
 	image := deployment.Spec.Tabby.Image
 	if image == "" {
-		image = "tabbyml/tabby:latest"
+		image = r.operatorConfig().Image(r.operatorConfig().Images.Tabby)
 	}
 	replicas := deployment.Spec.Tabby.Replicas
 	if replicas == 0 {
 		replicas = 1
 	}
+	replicas = scheduledReplicas(deployment.Spec.Tabby.Schedules, replicas)
 	servicePort := deployment.Spec.Tabby.Service.Port
 	if servicePort == 0 {
 		servicePort = 8080
@@ -158,7 +180,7 @@ func (r *LMDeploymentReconciler) buildTabbyDeployment(deployment *llmgeeperiov1a
 			Labels:    labels,
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: &replicas,
+			Replicas: r.desiredReplicas(deployment, replicas),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: labels,
 			},
@@ -171,7 +193,7 @@ func (r *LMDeploymentReconciler) buildTabbyDeployment(deployment *llmgeeperiov1a
 					InitContainers: []corev1.Container{
 						{
 							Name:    "tabby-config-init",
-							Image:   "busybox:1.35",
+							Image:   r.operatorConfig().Image(r.operatorConfig().Images.Init),
 							Command: []string{"/bin/sh"},
 							Args: []string{
 								"-c",
@@ -192,7 +214,7 @@ func (r *LMDeploymentReconciler) buildTabbyDeployment(deployment *llmgeeperiov1a
 									Protocol:      corev1.ProtocolTCP,
 								},
 							},
-							Resources:    r.buildResourceRequirements(deployment.Spec.Tabby.Resources),
+							Resources:    r.buildResourceRequirements(operatorconfig.ResourcesOrDefault(deployment.Spec.Tabby.Resources, r.operatorConfig().Resources.Tabby)),
 							Env:          envVars,
 							VolumeMounts: volumeMounts,
 						},
@@ -203,6 +225,10 @@ func (r *LMDeploymentReconciler) buildTabbyDeployment(deployment *llmgeeperiov1a
 		},
 	}
 
+	// Apply pod template overrides
+	r.applyPodTemplateOverrides(&tabbyDeployment.Spec.Template, deployment.Spec.Tabby.PodTemplateOverrides)
+	r.applyRolloutStrategy(tabbyDeployment, deployment.Spec.Tabby.Rollout)
+
 	// Set owner reference
 	_ = controllerutil.SetControllerReference(deployment, tabbyDeployment, r.Scheme)
 	return tabbyDeployment
@@ -267,42 +293,24 @@ func (r *LMDeploymentReconciler) buildTabbyIngress(deployment *llmgeeperiov1alph
 		ingressHost = fmt.Sprintf("tabby-%s.localhost", deployment.Name)
 	}
 
-	tabbyIngress := &networkingv1.Ingress{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      deployment.GetTabbyIngressName(),
-			Namespace: deployment.Namespace,
-			Labels:    labels,
-		},
-		Spec: networkingv1.IngressSpec{
-			Rules: []networkingv1.IngressRule{
-				{
-					Host: ingressHost,
-					IngressRuleValue: networkingv1.IngressRuleValue{
-						HTTP: &networkingv1.HTTPIngressRuleValue{
-							Paths: []networkingv1.HTTPIngressPath{
-								{
-									Path:     "/",
-									PathType: &[]networkingv1.PathType{networkingv1.PathTypePrefix}[0],
-									Backend: networkingv1.IngressBackend{
-										Service: &networkingv1.IngressServiceBackend{
-											Name: deployment.GetTabbyServiceName(),
-											Port: networkingv1.ServiceBackendPort{
-												Number: servicePort,
-											},
-										},
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-		},
+	return r.buildIngress(deployment, deployment.GetTabbyIngressName(), labels, deployment.Spec.Tabby.Ingress, ingressHost,
+		deployment.GetTabbyServiceName(), servicePort)
+}
+
+// buildTabbyHTTPRoute builds the Tabby HTTPRoute object
+func (r *LMDeploymentReconciler) buildTabbyHTTPRoute(deployment *llmgeeperiov1alpha1.LMDeployment) *unstructured.Unstructured {
+	labels := map[string]string{
+		"app":            "tabby",
+		"llm-deployment": deployment.Name,
 	}
 
-	// Set owner reference
-	_ = controllerutil.SetControllerReference(deployment, tabbyIngress, r.Scheme)
-	return tabbyIngress
+	servicePort := deployment.Spec.Tabby.Service.Port
+	if servicePort == 0 {
+		servicePort = 8080
+	}
+
+	return r.buildHTTPRoute(deployment, deployment.GetTabbyHTTPRouteName(), labels, *deployment.Spec.Tabby.Gateway,
+		deployment.GetTabbyServiceName(), servicePort)
 }
 
 // buildTabbySecret builds the Tabby Secret for configuration
@@ -338,10 +346,7 @@ func (r *LMDeploymentReconciler) buildTabbyPVC(deployment *llmgeeperiov1alpha1.L
 	}
 
 	// Set default values
-	var storageClass *string
-	if deployment.Spec.Tabby.Persistence.StorageClass != "" {
-		storageClass = &deployment.Spec.Tabby.Persistence.StorageClass
-	}
+	storageClass := r.operatorConfig().StorageClassOrDefault(deployment.Spec.Tabby.Persistence.StorageClass)
 	size := deployment.Spec.Tabby.Persistence.Size
 	if size == "" {
 		size = "10Gi" // Default size
@@ -395,7 +400,7 @@ func (r *LMDeploymentReconciler) generateTabbyConfig(ctx context.Context, deploy
 
 		config = TabbyConfig{
 			Model: TabbyModelConfig{
-				Completion: TabbyCompletionConfig{
+				Completion: &TabbyCompletionConfig{
 					HTTP: TabbyHTTPConfig{
 						Kind:           "openai/completion",
 						ModelName:      deployment.Spec.Tabby.CompletionModel,
@@ -404,7 +409,7 @@ func (r *LMDeploymentReconciler) generateTabbyConfig(ctx context.Context, deploy
 						PromptTemplate: "<PRE> {prefix} <SUF>{suffix} <MID>",
 					},
 				},
-				Chat: TabbyChatConfig{
+				Chat: &TabbyChatConfig{
 					HTTP: TabbyHTTPConfig{
 						Kind:            "openai/chat",
 						ModelName:       deployment.Spec.Tabby.ChatModel,
@@ -413,14 +418,10 @@ func (r *LMDeploymentReconciler) generateTabbyConfig(ctx context.Context, deploy
 						APIKey:          apiKey,
 					},
 				},
-				Embedding: TabbyEmbeddingConfig{
-					Local: TabbyLocalConfig{
-						ModelID: "Nomic-Embed-Text",
-					},
-				},
+				Embedding: tabbyEmbeddingConfig(deployment, apiKey),
 			},
 		}
-	} else {
+	} else if deployment.Spec.Ollama.Enabled || len(deployment.Spec.External) == 0 {
 		// Use Ollama configuration (default)
 		ollamaHost := fmt.Sprintf("%s.%s:%d",
 			deployment.GetOllamaServiceName(),
@@ -429,7 +430,7 @@ func (r *LMDeploymentReconciler) generateTabbyConfig(ctx context.Context, deploy
 
 		config = TabbyConfig{
 			Model: TabbyModelConfig{
-				Completion: TabbyCompletionConfig{
+				Completion: &TabbyCompletionConfig{
 					HTTP: TabbyHTTPConfig{
 						Kind:           "ollama/completion",
 						ModelName:      deployment.Spec.Tabby.CompletionModel,
@@ -437,7 +438,7 @@ func (r *LMDeploymentReconciler) generateTabbyConfig(ctx context.Context, deploy
 						PromptTemplate: "<PRE> {prefix} <SUF>{suffix} <MID>",
 					},
 				},
-				Chat: TabbyChatConfig{
+				Chat: &TabbyChatConfig{
 					HTTP: TabbyHTTPConfig{
 						Kind:            "openai/chat",
 						ModelName:       deployment.Spec.Tabby.ChatModel,
@@ -445,15 +446,36 @@ func (r *LMDeploymentReconciler) generateTabbyConfig(ctx context.Context, deploy
 						APIEndpoint:     fmt.Sprintf("http://%s/v1", ollamaHost),
 					},
 				},
-				Embedding: TabbyEmbeddingConfig{
-					Local: TabbyLocalConfig{
-						ModelID: "Nomic-Embed-Text",
-					},
-				},
+				Embedding: tabbyEmbeddingConfig(deployment, ""),
+			},
+		}
+	} else {
+		// Only external providers serve models
+		config = TabbyConfig{
+			Model: TabbyModelConfig{
+				Embedding: tabbyEmbeddingConfig(deployment, ""),
 			},
 		}
 	}
 
+	// Models of external providers are requested from the provider
+	if provider := deployment.Spec.ExternalProvider(deployment.Spec.Tabby.CompletionModel); provider != nil {
+		httpConfig, err := r.tabbyExternalHTTPConfig(ctx, deployment, provider, "openai/completion", deployment.Spec.Tabby.CompletionModel)
+		if err != nil {
+			return "", err
+		}
+		httpConfig.PromptTemplate = "<PRE> {prefix} <SUF>{suffix} <MID>"
+		config.Model.Completion = &TabbyCompletionConfig{HTTP: httpConfig}
+	}
+	if provider := deployment.Spec.ExternalProvider(deployment.Spec.Tabby.ChatModel); provider != nil {
+		httpConfig, err := r.tabbyExternalHTTPConfig(ctx, deployment, provider, "openai/chat", deployment.Spec.Tabby.ChatModel)
+		if err != nil {
+			return "", err
+		}
+		httpConfig.SupportedModels = provider.Models
+		config.Model.Chat = &TabbyChatConfig{HTTP: httpConfig}
+	}
+
 	// Encode to TOML
 	var buf bytes.Buffer
 	encoder := toml.NewEncoder(&buf)
@@ -464,10 +486,30 @@ func (r *LMDeploymentReconciler) generateTabbyConfig(ctx context.Context, deploy
 	return buf.String(), nil
 }
 
-// getVLLMModelNames extracts model names from VLLMModelSpec slice
+// tabbyExternalHTTPConfig builds the Tabby HTTP model config of a model of an external provider, config.toml holds the API key in plain text
+func (r *LMDeploymentReconciler) tabbyExternalHTTPConfig(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment, provider *llmgeeperiov1alpha1.ExternalProviderSpec, kind, model string) (TabbyHTTPConfig, error) {
+	httpConfig := TabbyHTTPConfig{
+		Kind:        kind,
+		ModelName:   model,
+		APIEndpoint: provider.BaseURL,
+	}
+	if provider.APIKeySecretRef != nil {
+		apiKey, err := r.secretKeyValue(ctx, deployment.Namespace, provider.APIKeySecretRef)
+		if err != nil {
+			return httpConfig, fmt.Errorf("failed to read the API key of provider %s for Tabby config: %w", provider.Name, err)
+		}
+		httpConfig.APIKey = apiKey
+	}
+	return httpConfig, nil
+}
+
+// getVLLMModelNames extracts the names of the chat models from VLLMModelSpec slice, embedding and reranking models can't chat
 func getVLLMModelNames(models []llmgeeperiov1alpha1.VLLMModelSpec) []string {
 	names := make([]string, 0, len(models))
 	for _, model := range models {
+		if model.Task == "embed" || model.Task == "score" {
+			continue
+		}
 		names = append(names, model.Model)
 	}
 	return names
@@ -479,9 +521,9 @@ type TabbyConfig struct {
 }
 
 type TabbyModelConfig struct {
-	Completion TabbyCompletionConfig `toml:"completion"`
-	Chat       TabbyChatConfig       `toml:"chat"`
-	Embedding  TabbyEmbeddingConfig  `toml:"embedding"`
+	Completion *TabbyCompletionConfig `toml:"completion,omitempty"`
+	Chat       *TabbyChatConfig       `toml:"chat,omitempty"`
+	Embedding  TabbyEmbeddingConfig   `toml:"embedding"`
 }
 
 type TabbyCompletionConfig struct {
@@ -493,7 +535,8 @@ type TabbyChatConfig struct {
 }
 
 type TabbyEmbeddingConfig struct {
-	Local TabbyLocalConfig `toml:"local"`
+	Local *TabbyLocalConfig `toml:"local,omitempty"`
+	HTTP  *TabbyHTTPConfig  `toml:"http,omitempty"`
 }
 
 type TabbyHTTPConfig struct {
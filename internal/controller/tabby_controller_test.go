@@ -360,3 +360,50 @@ func TestTabbyController_ConfigGeneration(t *testing.T) {
 		})
 	})
 }
+
+func TestTabbyController_ExternalProviders(t *testing.T) {
+	deployment := &llmgeeperiov1alpha1.LMDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-deployment",
+			Namespace: "default",
+		},
+		Spec: llmgeeperiov1alpha1.LMDeploymentSpec{
+			External: []llmgeeperiov1alpha1.ExternalProviderSpec{
+				{
+					Name:    "openai",
+					BaseURL: "https://api.openai.com/v1",
+					APIKeySecretRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "openai"},
+						Key:                  "apiKey",
+					},
+					Models: []string{"gpt-4o", "gpt-4o-mini"},
+				},
+			},
+			Tabby: llmgeeperiov1alpha1.TabbySpec{
+				Enabled:   true,
+				ChatModel: "gpt-4o",
+			},
+		},
+	}
+	reconciler := &LMDeploymentReconciler{Client: &testClient{secret: &corev1.Secret{
+		Data: map[string][]byte{"apiKey": []byte("sk-test")},
+	}}}
+
+	config, err := reconciler.generateTabbyConfig(t.Context(), deployment)
+	require.NoError(t, err)
+
+	expectedConfig := `[model]
+  [model.chat]
+    [model.chat.http]
+      kind = "openai/chat"
+      model_name = "gpt-4o"
+      api_endpoint = "https://api.openai.com/v1"
+      api_key = "sk-test"
+      supported_models = ["gpt-4o", "gpt-4o-mini"]
+  [model.embedding]
+    [model.embedding.local]
+      model_id = "Nomic-Embed-Text"
+`
+	assert.Equal(t, expectedConfig, config)
+	assert.Contains(t, referencedSecretNames(deployment), "openai", "the API key change rolls out")
+}
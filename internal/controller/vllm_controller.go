@@ -19,18 +19,22 @@ package controller
 import (
 	"context"
 	"fmt"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+	operatorconfig "github.com/geeper-io/llm-operator/internal/config"
 )
 
 // ensureVLLMApiKeySecret ensures the vLLM API key secret exists and returns the API key
@@ -85,49 +89,123 @@ func (r *LMDeploymentReconciler) ensureVLLMApiKeySecret(ctx context.Context, dep
 	return apiKey, nil
 }
 
-// reconcileVLLM reconciles the vLLM deployment
-func (r *LMDeploymentReconciler) reconcileVLLM(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment) error {
+// reconcileVLLM reconciles the vLLM deployment.
+// It returns when the next model with an idle timeout becomes idle or the next periodic smoke test is due.
+func (r *LMDeploymentReconciler) reconcileVLLM(ctx context.Context, deployment *llmgeeperiov1alpha1.LMDeployment) (time.Duration, error) {
 	// Ensure vLLM API key secret exists if enabled
-	_, err := r.ensureVLLMApiKeySecret(ctx, deployment)
+	apiKey, err := r.ensureVLLMApiKeySecret(ctx, deployment)
 	if err != nil {
-		return fmt.Errorf("failed to ensure vLLM API key secret: %w", err)
+		return 0, fmt.Errorf("failed to ensure vLLM API key secret: %w", err)
 	}
 
+	// Let the activators of models with an idle timeout scale them
+	if err := r.reconcileVLLMActivatorRBAC(ctx, deployment); err != nil {
+		return 0, fmt.Errorf("failed to reconcile vLLM activator access: %w", err)
+	}
+
+	// Restart model servers and router when the API key is rotated
+	hasher := newConfigHasher()
+	hasher.addString(llmgeeperiov1alpha1.VLLMApiKeySecretKey, apiKey)
+	configHash := hasher.sum()
+
 	// Create or update vLLM model deployments
+	var requeueAfter time.Duration
 	for _, modelSpec := range deployment.Spec.VLLM.Models {
-		// Create or update model deployment
-		vllmDeployment := r.buildVLLMModelDeployment(deployment, modelSpec)
+		// Create or update model deployment, a running canary takes its share of the replicas
+		vllmDeployment, canaryDeployment := r.buildVLLMModelDeployments(deployment, modelSpec)
+		r.pinImageDigests(deployment, &vllmDeployment.Spec.Template)
+		setConfigHash(vllmDeployment, configHash)
+
+		// Idle models are scaled to zero and not autoscaled until the activator scales them up again
+		idle, idleAfter, err := r.applyIdleScale(ctx, deployment, modelSpec, vllmDeployment)
+		if err != nil {
+			return 0, err
+		}
+		if idleAfter > 0 && (requeueAfter == 0 || idleAfter < requeueAfter) {
+			requeueAfter = idleAfter
+		}
+		autoscaling := modelSpec.Autoscaling
+		if idle {
+			autoscaling = nil
+		}
+
 		if err := r.createOrUpdateDeployment(ctx, vllmDeployment); err != nil {
-			return err
+			return 0, err
+		}
+		if err := r.reconcilePodDisruptionBudget(ctx, deployment, vllmDeployment, vllmModelRollout(deployment, modelSpec)); err != nil {
+			return 0, err
+		}
+		if err := r.reconcileAutoscaler(ctx, deployment, vllmDeployment, autoscaling); err != nil {
+			return 0, err
 		}
 
 		// Create or update model service
 		vllmService := r.buildVLLMModelService(deployment, modelSpec)
 		if err := r.createOrUpdateService(ctx, vllmService); err != nil {
-			return err
+			return 0, err
+		}
+		if err := r.reconcileVLLMActivator(ctx, deployment, modelSpec); err != nil {
+			return 0, err
+		}
+		if err := r.reconcileVLLMCanary(ctx, deployment, modelSpec, canaryDeployment, configHash); err != nil {
+			return 0, err
+		}
+
+		// Verify the model answers once its rollout finished, and again at the smoke test interval
+		smokeTestAfter, err := r.reconcileVLLMSmokeTest(ctx, deployment, modelSpec)
+		if err != nil {
+			return 0, err
+		}
+		if smokeTestAfter > 0 && (requeueAfter == 0 || smokeTestAfter < requeueAfter) {
+			requeueAfter = smokeTestAfter
 		}
 
 		// Create or update model PVC if persistence is enabled
 		if modelSpec.Persistence != nil && modelSpec.Persistence.Enabled {
 			vllmPVC := r.buildVLLMModelPVC(deployment, modelSpec)
 			if err := r.ensurePVC(ctx, vllmPVC); err != nil {
-				return err
+				return 0, err
 			}
 		}
 	}
 
 	// Create or update vLLM router
 	routerDeployment := r.buildVLLMRouterDeployment(deployment)
+	r.pinImageDigests(deployment, &routerDeployment.Spec.Template)
+	setConfigHash(routerDeployment, configHash)
 	if err := r.createOrUpdateDeployment(ctx, routerDeployment); err != nil {
-		return err
+		return 0, err
+	}
+	if err := r.reconcilePodDisruptionBudget(ctx, deployment, routerDeployment, deployment.Spec.VLLM.Router.Rollout); err != nil {
+		return 0, err
 	}
 
 	routerService := r.buildVLLMRouterService(deployment)
 	if err := r.createOrUpdateService(ctx, routerService); err != nil {
-		return err
+		return 0, err
+	}
+
+	// Create or update router ingress if enabled, the router requires the vLLM API key
+	if deployment.Spec.VLLM.Router.Ingress.Host != "" {
+		routerIngress := r.buildVLLMRouterIngress(deployment)
+		if err := r.createOrUpdateIngress(ctx, routerIngress); err != nil {
+			return 0, err
+		}
+	} else if err := r.deleteControlled(ctx, deployment, &networkingv1.Ingress{}, deployment.GetVLLMRouterIngressName()); err != nil {
+		return 0, err
+	}
+
+	// Create or update router HTTPRoute if a Gateway is configured, remove it otherwise
+	if deployment.Spec.VLLM.Router.Gateway != nil {
+		routerRoute := r.buildVLLMRouterHTTPRoute(deployment)
+		if err := r.createOrUpdateHTTPRoute(ctx, routerRoute); err != nil {
+			return 0, err
+		}
+	} else if err := r.deleteHTTPRoute(ctx, deployment, deployment.GetVLLMRouterHTTPRouteName()); err != nil {
+		return 0, err
 	}
 
-	return nil
+	return requeueAfter, nil
 }
 
 // buildVLLMModelDeployment builds a vLLM model deployment object
@@ -145,14 +223,15 @@ func (r *LMDeploymentReconciler) buildVLLMModelDeployment(deployment *llmgeeperi
 		image = deployment.Spec.VLLM.GlobalConfig.Image
 	}
 	if image == "" {
-		image = "vllm/vllm-openai:latest"
+		image = r.operatorConfig().Image(r.operatorConfig().Images.VLLM)
 	}
 
-	// Use model-specific replicas or default to 1
+	// Use model-specific replicas or default to 1, the active schedule takes precedence
 	replicas := modelSpec.Replicas
 	if replicas == 0 {
 		replicas = 1
 	}
+	replicas = scheduledReplicas(modelSpec.Schedules, replicas)
 
 	// Use model-specific service port or fall back to global default
 	servicePort := modelSpec.Service.Port
@@ -175,6 +254,7 @@ func (r *LMDeploymentReconciler) buildVLLMModelDeployment(deployment *llmgeeperi
 			},
 		},
 		Command: []string{"vllm", "serve", modelSpec.Model},
+		Args:    vllmArgs(modelSpec),
 		SecurityContext: &corev1.SecurityContext{
 			RunAsGroup:     ptr.To(int64(44)),
 			SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeUnconfined},
@@ -248,6 +328,12 @@ func (r *LMDeploymentReconciler) buildVLLMModelDeployment(deployment *llmgeeperi
 		}
 	}
 
+	// Pods of models with an idle timeout are only reached through the activator, the selector is immutable and stays unchanged
+	podLabels := labels
+	if modelSpec.IdleTimeout != nil {
+		podLabels = mergeStringMaps(labels, map[string]string{behindActivatorLabel: "true"})
+	}
+
 	vllmDeployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      deployment.GetVLLMModelDeploymentName(modelSpec.Name),
@@ -255,13 +341,13 @@ func (r *LMDeploymentReconciler) buildVLLMModelDeployment(deployment *llmgeeperi
 			Labels:    labels,
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: &replicas,
+			Replicas: r.scaledReplicas(deployment, replicas, modelSpec.Autoscaling),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: labels,
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: labels,
+					Labels: podLabels,
 				},
 				Spec: corev1.PodSpec{
 					Containers: []corev1.Container{container},
@@ -272,6 +358,14 @@ func (r *LMDeploymentReconciler) buildVLLMModelDeployment(deployment *llmgeeperi
 		},
 	}
 
+	// Apply pod template overrides, falling back to the global defaults
+	var defaultOverrides *llmgeeperiov1alpha1.PodTemplateOverrides
+	if deployment.Spec.VLLM.GlobalConfig != nil {
+		defaultOverrides = &deployment.Spec.VLLM.GlobalConfig.PodTemplateOverrides
+	}
+	r.applyPodTemplateOverrides(&vllmDeployment.Spec.Template, mergePodTemplateOverrides(modelSpec.PodTemplateOverrides, defaultOverrides))
+	r.applyRolloutStrategy(vllmDeployment, vllmModelRollout(deployment, modelSpec))
+
 	// Set owner reference
 	_ = controllerutil.SetControllerReference(deployment, vllmDeployment, r.Scheme)
 	return vllmDeployment
@@ -302,6 +396,12 @@ func (r *LMDeploymentReconciler) buildVLLMModelService(deployment *llmgeeperiov1
 		serviceType = corev1.ServiceTypeClusterIP
 	}
 
+	// Models with an idle timeout are served through their activator
+	selector := labels
+	if modelSpec.IdleTimeout != nil {
+		selector = vllmActivatorLabels(deployment, modelSpec)
+	}
+
 	vllmService := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      deployment.GetVLLMModelServiceName(modelSpec.Name),
@@ -318,7 +418,7 @@ func (r *LMDeploymentReconciler) buildVLLMModelService(deployment *llmgeeperiov1
 					Protocol:   corev1.ProtocolTCP,
 				},
 			},
-			Selector: labels,
+			Selector: selector,
 		},
 	}
 
@@ -343,7 +443,7 @@ func (r *LMDeploymentReconciler) buildVLLMModelPVC(deployment *llmgeeperiov1alph
 	}
 
 	storageClass := ""
-	if persistence != nil && persistence.StorageClass != "" {
+	if persistence != nil {
 		storageClass = persistence.StorageClass
 	}
 
@@ -362,7 +462,7 @@ func (r *LMDeploymentReconciler) buildVLLMModelPVC(deployment *llmgeeperiov1alph
 			AccessModes: []corev1.PersistentVolumeAccessMode{
 				corev1.ReadWriteOnce,
 			},
-			StorageClassName: &storageClass,
+			StorageClassName: r.operatorConfig().StorageClassOrDefault(storageClass),
 			Resources: corev1.VolumeResourceRequirements{
 				Requests: corev1.ResourceList{
 					corev1.ResourceStorage: resource.MustParse(size),
@@ -384,10 +484,7 @@ func (r *LMDeploymentReconciler) buildVLLMRouterDeployment(deployment *llmgeeper
 	}
 
 	// Use router-specific image or default
-	image := deployment.Spec.VLLM.Router.Image
-	if image == "" {
-		image = "lmcache/lmstack-router:latest"
-	}
+	image := r.imageOrDefault(deployment.Spec.VLLM.Router.Image, r.operatorConfig().Images.VLLMRouter)
 
 	// Use router-specific replicas or default to 1
 	replicas := deployment.Spec.VLLM.Router.Replicas
@@ -407,6 +504,12 @@ func (r *LMDeploymentReconciler) buildVLLMRouterDeployment(deployment *llmgeeper
 		modelEndpoints = append(modelEndpoints, fmt.Sprintf("%s:%d", deployment.GetVLLMModelServiceName(modelSpec.Name), servicePort))
 	}
 
+	// The router discovers the model pods, and the activators in place of the pods of models with an idle timeout
+	labelSelector := "app=vllm,llm-deployment=" + deployment.Name
+	if scaleToZeroEnabled(deployment) {
+		labelSelector = fmt.Sprintf("app in (vllm,vllm-activator),llm-deployment=%s,!%s", deployment.Name, behindActivatorLabel)
+	}
+
 	// Build container spec
 	container := corev1.Container{
 		Name:  "vllm-router",
@@ -423,9 +526,9 @@ func (r *LMDeploymentReconciler) buildVLLMRouterDeployment(deployment *llmgeeper
 			"--port", fmt.Sprintf("%d", servicePort),
 			"--service-discovery", "k8s",
 			"--k8s-namespace", "k8s",
-			"--k8s-label-selector", "app=vllm,llm-deployment=" + deployment.Name,
+			"--k8s-label-selector", labelSelector,
 		},
-		Resources: r.buildResourceRequirements(deployment.Spec.VLLM.Router.Resources),
+		Resources: r.buildResourceRequirements(operatorconfig.ResourcesOrDefault(deployment.Spec.VLLM.Router.Resources, r.operatorConfig().Resources.VLLMRouter)),
 		Env: []corev1.EnvVar{
 			{
 				Name:  "HOST",
@@ -466,7 +569,7 @@ func (r *LMDeploymentReconciler) buildVLLMRouterDeployment(deployment *llmgeeper
 			Labels:    labels,
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: &replicas,
+			Replicas: r.desiredReplicas(deployment, replicas),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: labels,
 			},
@@ -482,6 +585,10 @@ func (r *LMDeploymentReconciler) buildVLLMRouterDeployment(deployment *llmgeeper
 		},
 	}
 
+	// Apply pod template overrides
+	r.applyPodTemplateOverrides(&routerDeployment.Spec.Template, deployment.Spec.VLLM.Router.PodTemplateOverrides)
+	r.applyRolloutStrategy(routerDeployment, deployment.Spec.VLLM.Router.Rollout)
+
 	// Set owner reference
 	_ = controllerutil.SetControllerReference(deployment, routerDeployment, r.Scheme)
 	return routerDeployment
@@ -529,6 +636,45 @@ func (r *LMDeploymentReconciler) buildVLLMRouterService(deployment *llmgeeperiov
 	return routerService
 }
 
+// vllmArgs returns the arguments of a vLLM model server, the task comes before the user arguments
+func vllmArgs(modelSpec llmgeeperiov1alpha1.VLLMModelSpec) []string {
+	if modelSpec.Task == "" {
+		return modelSpec.Args
+	}
+	return append([]string{"--task", modelSpec.Task}, modelSpec.Args...)
+}
+
+// vllmRouterPort returns the port of the vLLM router service
+func vllmRouterPort(deployment *llmgeeperiov1alpha1.LMDeployment) int32 {
+	if deployment.Spec.VLLM.Router.Service.Port == 0 {
+		return 8000
+	}
+	return deployment.Spec.VLLM.Router.Service.Port
+}
+
+// buildVLLMRouterIngress builds the vLLM router ingress object
+func (r *LMDeploymentReconciler) buildVLLMRouterIngress(deployment *llmgeeperiov1alpha1.LMDeployment) *networkingv1.Ingress {
+	labels := map[string]string{
+		"app":            "vllm-router",
+		"llm-deployment": deployment.Name,
+	}
+
+	ingressSpec := deployment.Spec.VLLM.Router.Ingress
+	return r.buildIngress(deployment, deployment.GetVLLMRouterIngressName(), labels, ingressSpec, ingressSpec.Host,
+		deployment.GetVLLMRouterServiceName(), vllmRouterPort(deployment))
+}
+
+// buildVLLMRouterHTTPRoute builds the vLLM router HTTPRoute object
+func (r *LMDeploymentReconciler) buildVLLMRouterHTTPRoute(deployment *llmgeeperiov1alpha1.LMDeployment) *unstructured.Unstructured {
+	labels := map[string]string{
+		"app":            "vllm-router",
+		"llm-deployment": deployment.Name,
+	}
+
+	return r.buildHTTPRoute(deployment, deployment.GetVLLMRouterHTTPRouteName(), labels, *deployment.Spec.VLLM.Router.Gateway,
+		deployment.GetVLLMRouterServiceName(), vllmRouterPort(deployment))
+}
+
 // buildVLLMResourceRequirements builds resource requirements with fallback to global defaults
 func (r *LMDeploymentReconciler) buildVLLMResourceRequirements(modelResources llmgeeperiov1alpha1.ResourceRequirements, globalConfig *llmgeeperiov1alpha1.VLLMGlobalConfig) corev1.ResourceRequirements {
 	// Start with model-specific resources
@@ -545,8 +691,8 @@ func (r *LMDeploymentReconciler) buildVLLMResourceRequirements(modelResources ll
 		}
 	}
 
-	return corev1.ResourceRequirements{
-		Requests: requests,
-		Limits:   limits,
-	}
+	return r.buildResourceRequirements(operatorconfig.ResourcesOrDefault(
+		llmgeeperiov1alpha1.ResourceRequirements{Requests: requests, Limits: limits},
+		r.operatorConfig().Resources.VLLM,
+	))
 }
@@ -0,0 +1,94 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("should reject invalid expressions", func(t *testing.T) {
+		for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "0 9 * * mon-xyz", "*/0 * * * *", "0 17-9 * * *"} {
+			_, err := Parse(expr)
+			assert.Error(t, err, expr)
+		}
+	})
+
+	t.Run("should accept names, ranges, steps and descriptors", func(t *testing.T) {
+		for _, expr := range []string{"0 9 * * MON-FRI", "*/15 8-18 * jan,jul *", "5/10 * 1 * 7", "@daily"} {
+			_, err := Parse(expr)
+			assert.NoError(t, err, expr)
+		}
+	})
+}
+
+func TestSchedule_Next(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	// A Friday evening
+	now := time.Date(2025, time.March, 7, 18, 30, 0, 0, berlin)
+
+	t.Run("should find the next working day", func(t *testing.T) {
+		s, err := Parse("0 9 * * 1-5")
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2025, time.March, 10, 9, 0, 0, 0, berlin), s.Next(now))
+	})
+
+	t.Run("should fire strictly after the given time", func(t *testing.T) {
+		s, err := Parse("30 18 * * *")
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2025, time.March, 8, 18, 30, 0, 0, berlin), s.Next(now))
+	})
+
+	t.Run("should match either restricted day field", func(t *testing.T) {
+		s, err := Parse("0 0 1 * sun")
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2025, time.March, 9, 0, 0, 0, 0, berlin), s.Next(now))
+	})
+
+	t.Run("should never fire on impossible dates", func(t *testing.T) {
+		s, err := Parse("0 0 30 feb *")
+		require.NoError(t, err)
+		assert.True(t, s.Next(now).IsZero())
+	})
+}
+
+func TestSchedule_Prev(t *testing.T) {
+	now := time.Date(2025, time.March, 8, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should find the last working day", func(t *testing.T) {
+		s, err := Parse("0 19 * * mon-fri")
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2025, time.March, 7, 19, 0, 0, 0, time.UTC), s.Prev(now))
+	})
+
+	t.Run("should include the given time", func(t *testing.T) {
+		s, err := Parse("0 12 * * *")
+		require.NoError(t, err)
+		assert.Equal(t, now, s.Prev(now))
+	})
+
+	t.Run("should find leap days", func(t *testing.T) {
+		s, err := Parse("0 0 29 2 *")
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC), s.Prev(now))
+	})
+}
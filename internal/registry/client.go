@@ -0,0 +1,257 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package registry resolves container image tags to digests using the OCI distribution API.
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/distribution/reference"
+)
+
+// manifestMediaTypes are the manifest types accepted when resolving a tag, indexes first so that
+// multi-arch images resolve to the digest of the index rather than of a single platform
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// Client resolves image references to digests
+type Client interface {
+	// Digest returns the digest of the manifest the image reference points to, authenticating with the
+	// credentials the keychain holds for the registry of the image
+	Digest(ctx context.Context, image string, keychain Keychain) (string, error)
+}
+
+// Options configures the HTTP registry client
+type Options struct {
+	// HTTPClient is the client used for registry requests, http.DefaultClient with a timeout when nil
+	HTTPClient *http.Client
+
+	// PlainHTTP lists the registry hosts that are accessed without TLS
+	PlainHTTP []string
+}
+
+// httpClient resolves digests against the OCI distribution API
+type httpClient struct {
+	client    *http.Client
+	plainHTTP map[string]bool
+}
+
+// NewClient creates a registry client using the OCI distribution API
+func NewClient(opts Options) Client {
+	c := &httpClient{
+		client:    opts.HTTPClient,
+		plainHTTP: map[string]bool{},
+	}
+	if c.client == nil {
+		c.client = &http.Client{Timeout: 30 * time.Second}
+	}
+	for _, host := range opts.PlainHTTP {
+		c.plainHTTP[host] = true
+	}
+	return c
+}
+
+// Digest implements Client
+func (c *httpClient) Digest(ctx context.Context, image string, keychain Keychain) (string, error) {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return "", fmt.Errorf("invalid image reference %q: %w", image, err)
+	}
+	if digested, ok := named.(reference.Digested); ok {
+		return digested.Digest().String(), nil
+	}
+
+	tag := "latest"
+	if tagged, ok := named.(reference.Tagged); ok {
+		tag = tagged.Tag()
+	}
+
+	host := reference.Domain(named)
+	credentials, authenticated := keychain.lookup(host)
+	if host == "docker.io" {
+		host = "registry-1.docker.io"
+	}
+	scheme := "https"
+	if c.plainHTTP[host] {
+		scheme = "http"
+	}
+	repository := reference.Path(named)
+	manifestURL := fmt.Sprintf("%s://%s/v2/%s/manifests/%s", scheme, host, repository, tag)
+
+	resp, err := c.requestManifest(ctx, http.MethodHead, manifestURL, "")
+	if err != nil {
+		return "", err
+	}
+	_ = resp.Body.Close()
+
+	// Retry with the credentials or a pull token when the registry requires authentication
+	authorization := ""
+	if resp.StatusCode == http.StatusUnauthorized {
+		var creds *Credentials
+		if authenticated {
+			creds = &credentials
+		}
+		authorization, err = c.authorize(ctx, resp.Header.Get("WWW-Authenticate"), repository, creds)
+		if err != nil {
+			return "", fmt.Errorf("failed to authenticate to %s: %w", host, err)
+		}
+		resp, err = c.requestManifest(ctx, http.MethodHead, manifestURL, authorization)
+		if err != nil {
+			return "", err
+		}
+		_ = resp.Body.Close()
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to resolve %s: registry returned %s", image, resp.Status)
+	}
+	if digest := resp.Header.Get("Docker-Content-Digest"); digest != "" {
+		return digest, nil
+	}
+
+	// Not every registry returns the digest on HEAD requests, hash the manifest instead
+	resp, err = c.requestManifest(ctx, http.MethodGet, manifestURL, authorization)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to resolve %s: registry returned %s", image, resp.Status)
+	}
+	if digest := resp.Header.Get("Docker-Content-Digest"); digest != "" {
+		return digest, nil
+	}
+	hash := sha256.New()
+	if _, err := io.Copy(hash, resp.Body); err != nil {
+		return "", fmt.Errorf("failed to read manifest of %s: %w", image, err)
+	}
+	return fmt.Sprintf("sha256:%x", hash.Sum(nil)), nil
+}
+
+// requestManifest sends a manifest request accepting all supported manifest types
+func (c *httpClient) requestManifest(ctx context.Context, method, manifestURL, authorization string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request manifest %s: %w", manifestURL, err)
+	}
+	return resp, nil
+}
+
+// authorize answers an authentication challenge with the Authorization header of the retried request.
+// Basic challenges are answered with the credentials, Bearer challenges with a pull token the credentials are
+// exchanged for, or an anonymous one without credentials.
+func (c *httpClient) authorize(ctx context.Context, challenge, repository string, credentials *Credentials) (string, error) {
+	scheme, params := parseChallenge(challenge)
+	switch {
+	case strings.EqualFold(scheme, "basic") && credentials != nil:
+		req := &http.Request{Header: http.Header{}}
+		req.SetBasicAuth(credentials.Username, credentials.Password)
+		return req.Header.Get("Authorization"), nil
+	case strings.EqualFold(scheme, "bearer") && params["realm"] != "":
+		token, err := c.fetchToken(ctx, params, repository, credentials)
+		if err != nil {
+			return "", err
+		}
+		return "Bearer " + token, nil
+	default:
+		return "", fmt.Errorf("unsupported authentication challenge %q", challenge)
+	}
+}
+
+// fetchToken requests a pull token from the realm announced in a Bearer challenge
+func (c *httpClient) fetchToken(ctx context.Context, params map[string]string, repository string, credentials *Credentials) (string, error) {
+	tokenURL, err := url.Parse(params["realm"])
+	if err != nil {
+		return "", fmt.Errorf("invalid token realm %q: %w", params["realm"], err)
+	}
+	query := tokenURL.Query()
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = fmt.Sprintf("repository:%s:pull", repository)
+	}
+	query.Set("scope", scope)
+	tokenURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if credentials != nil {
+		req.SetBasicAuth(credentials.Username, credentials.Password)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token endpoint returned %s", resp.Status)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	return body.AccessToken, nil
+}
+
+// parseChallenge parses a WWW-Authenticate header such as Bearer realm="...",service="..."
+func parseChallenge(challenge string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	params := map[string]string{}
+	for rest != "" {
+		var key, value string
+		key, rest, _ = strings.Cut(strings.TrimLeft(rest, " ,"), "=")
+		if strings.HasPrefix(rest, `"`) {
+			value, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		if key != "" {
+			params[strings.ToLower(strings.TrimSpace(key))] = value
+		}
+	}
+	return scheme, params
+}
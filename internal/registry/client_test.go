@@ -0,0 +1,206 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+// newTestRegistry starts a local registry serving a single tag, optionally behind anonymous token auth
+func newTestRegistry(t *testing.T, requireToken bool, digestHeader bool) *httptest.Server {
+	var server *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "repository:team/app:pull", r.URL.Query().Get("scope"))
+		_, _ = fmt.Fprint(w, `{"token":"anonymous"}`)
+	})
+	mux.HandleFunc("/v2/team/app/manifests/", func(w http.ResponseWriter, r *http.Request) {
+		if requireToken && r.Header.Get("Authorization") != "Bearer anonymous" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if !strings.HasSuffix(r.URL.Path, "/v1") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Contains(t, r.Header.Get("Accept"), "application/vnd.oci.image.index.v1+json")
+		if digestHeader {
+			w.Header().Set("Docker-Content-Digest", testDigest)
+		}
+		if r.Method == http.MethodGet {
+			_, _ = fmt.Fprint(w, `{"schemaVersion":2}`)
+		}
+	})
+	server = httptest.NewTLSServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newPrivateRegistry starts a local registry serving a single tag to the user "robot", through a pull token the
+// credentials are exchanged for or, with basic, through basic auth on the registry itself
+func newPrivateRegistry(t *testing.T, basic bool) *httptest.Server {
+	var server *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "robot" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"access_token":"private"}`)
+	})
+	mux.HandleFunc("/v2/team/app/manifests/", func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		switch {
+		case basic && (!ok || username != "robot" || password != "secret"):
+			w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		case !basic && r.Header.Get("Authorization") != "Bearer private":
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Docker-Content-Digest", testDigest)
+	})
+	server = httptest.NewTLSServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient_Digest(t *testing.T) {
+	t.Run("should resolve a tag using the digest header", func(t *testing.T) {
+		server := newTestRegistry(t, false, true)
+		client := NewClient(Options{HTTPClient: server.Client()})
+
+		digest, err := client.Digest(t.Context(), strings.TrimPrefix(server.URL, "https://")+"/team/app:v1", nil)
+		require.NoError(t, err)
+		assert.Equal(t, testDigest, digest)
+	})
+
+	t.Run("should fetch an anonymous token when required", func(t *testing.T) {
+		server := newTestRegistry(t, true, true)
+		client := NewClient(Options{HTTPClient: server.Client()})
+
+		digest, err := client.Digest(t.Context(), strings.TrimPrefix(server.URL, "https://")+"/team/app:v1", nil)
+		require.NoError(t, err)
+		assert.Equal(t, testDigest, digest)
+	})
+
+	t.Run("should exchange the credentials of the registry for a token", func(t *testing.T) {
+		server := newPrivateRegistry(t, false)
+		host := strings.TrimPrefix(server.URL, "https://")
+		client := NewClient(Options{HTTPClient: server.Client()})
+
+		digest, err := client.Digest(t.Context(), host+"/team/app:v1", Keychain{host: {Username: "robot", Password: "secret"}})
+		require.NoError(t, err)
+		assert.Equal(t, testDigest, digest)
+
+		_, err = client.Digest(t.Context(), host+"/team/app:v1", nil)
+		assert.ErrorContains(t, err, "401")
+	})
+
+	t.Run("should send the credentials to registries using basic auth", func(t *testing.T) {
+		server := newPrivateRegistry(t, true)
+		host := strings.TrimPrefix(server.URL, "https://")
+		client := NewClient(Options{HTTPClient: server.Client()})
+
+		digest, err := client.Digest(t.Context(), host+"/team/app:v1", Keychain{host: {Username: "robot", Password: "secret"}})
+		require.NoError(t, err)
+		assert.Equal(t, testDigest, digest)
+	})
+
+	t.Run("should hash the manifest when no digest header is returned", func(t *testing.T) {
+		server := newTestRegistry(t, false, false)
+		client := NewClient(Options{HTTPClient: server.Client()})
+
+		digest, err := client.Digest(t.Context(), strings.TrimPrefix(server.URL, "https://")+"/team/app:v1", nil)
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(`{"schemaVersion":2}`))), digest)
+	})
+
+	t.Run("should fail for unknown tags", func(t *testing.T) {
+		server := newTestRegistry(t, false, true)
+		client := NewClient(Options{HTTPClient: server.Client()})
+
+		_, err := client.Digest(t.Context(), strings.TrimPrefix(server.URL, "https://")+"/team/app:v2", nil)
+		assert.ErrorContains(t, err, "404")
+	})
+
+	t.Run("should use plain HTTP for configured registries", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Docker-Content-Digest", testDigest)
+		}))
+		t.Cleanup(server.Close)
+		host := strings.TrimPrefix(server.URL, "http://")
+		client := NewClient(Options{PlainHTTP: []string{host}})
+
+		digest, err := client.Digest(t.Context(), host+"/team/app:v1", nil)
+		require.NoError(t, err)
+		assert.Equal(t, testDigest, digest)
+	})
+
+	t.Run("should return digests of pinned references without a request", func(t *testing.T) {
+		client := NewClient(Options{})
+
+		digest, err := client.Digest(t.Context(), "redis:7-alpine@"+testDigest, nil)
+		require.NoError(t, err)
+		assert.Equal(t, testDigest, digest)
+	})
+}
+
+func TestNewKeychain(t *testing.T) {
+	keychain, err := NewKeychain(
+		[]byte(`{"auths":{"https://index.docker.io/v1/":{"auth":"`+base64.StdEncoding.EncodeToString([]byte("robot:secret"))+`"}}}`),
+		[]byte(`{"Registry.Example.com":{"username":"legacy","password":"pass"}}`),
+		[]byte(`{"auths":{"registry.example.com":{"username":"second","password":"pass"}}}`),
+	)
+	require.NoError(t, err)
+
+	credentials, ok := keychain.lookup("docker.io")
+	assert.True(t, ok)
+	assert.Equal(t, Credentials{Username: "robot", Password: "secret"}, credentials)
+	credentials, ok = keychain.lookup("registry.example.com")
+	assert.True(t, ok)
+	assert.Equal(t, "legacy", credentials.Username, "the first pull secret wins")
+	_, ok = keychain.lookup("ghcr.io")
+	assert.False(t, ok)
+
+	_, err = NewKeychain([]byte(`{"auths":{"ghcr.io":{"auth":"invalid"}}}`))
+	assert.Error(t, err)
+}
+
+func TestParseChallenge(t *testing.T) {
+	scheme, params := parseChallenge(`Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/redis:pull"`)
+	assert.Equal(t, "Bearer", scheme)
+	assert.Equal(t, map[string]string{
+		"realm":   "https://auth.docker.io/token",
+		"service": "registry.docker.io",
+		"scope":   "repository:library/redis:pull",
+	}, params)
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Credentials authenticate to a registry
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// Keychain holds the credentials of registries by host, lookups without credentials are anonymous
+type Keychain map[string]Credentials
+
+// dockerHubHosts are the names Docker Hub is referred to by in docker config files
+var dockerHubHosts = map[string]bool{"docker.io": true, "index.docker.io": true, "registry-1.docker.io": true}
+
+// NewKeychain reads the credentials of image pull secrets, in the .dockerconfigjson or the legacy .dockercfg format.
+// The first config holding credentials for a registry wins, like the kubelet trying the pull secrets in order.
+func NewKeychain(dockerConfigs ...[]byte) (Keychain, error) {
+	keychain := Keychain{}
+	for _, data := range dockerConfigs {
+		var config struct {
+			Auths map[string]dockerAuth `json:"auths"`
+		}
+		if err := json.Unmarshal(data, &config); err != nil {
+			return nil, fmt.Errorf("invalid docker config: %w", err)
+		}
+		auths := config.Auths
+		if auths == nil {
+			if err := json.Unmarshal(data, &auths); err != nil {
+				return nil, fmt.Errorf("invalid docker config: %w", err)
+			}
+		}
+		for server, auth := range auths {
+			credentials, err := auth.credentials()
+			if err != nil {
+				return nil, fmt.Errorf("invalid credentials of %s: %w", server, err)
+			}
+			host := normalizeHost(server)
+			if _, ok := keychain[host]; !ok && credentials.Username != "" {
+				keychain[host] = credentials
+			}
+		}
+	}
+	return keychain, nil
+}
+
+// lookup returns the credentials of a registry host
+func (k Keychain) lookup(host string) (Credentials, bool) {
+	credentials, ok := k[normalizeHost(host)]
+	return credentials, ok
+}
+
+// dockerAuth is a registry entry of a docker config file
+type dockerAuth struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Auth     string `json:"auth"`
+}
+
+// credentials returns the user name and password of the entry, decoding them from auth when set
+func (a dockerAuth) credentials() (Credentials, error) {
+	if a.Auth == "" {
+		return Credentials{Username: a.Username, Password: a.Password}, nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(a.Auth)
+	if err != nil {
+		return Credentials{}, err
+	}
+	username, password, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return Credentials{}, fmt.Errorf("auth is not a user name and password")
+	}
+	return Credentials{Username: username, Password: password}, nil
+}
+
+// normalizeHost reduces a registry server of a docker config, e.g. https://index.docker.io/v1/, to its host
+func normalizeHost(server string) string {
+	host := strings.TrimPrefix(strings.TrimPrefix(strings.ToLower(server), "https://"), "http://")
+	host, _, _ = strings.Cut(host, "/")
+	if dockerHubHosts[host] {
+		return "docker.io"
+	}
+	return host
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sentinel queries Redis Sentinel for the state of the master and replicas it monitors.
+package sentinel
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client reads the state of a master monitored by Sentinel
+type Client interface {
+	// Master returns the master monitored under name and its replicas, as seen by the Sentinel at addr
+	Master(ctx context.Context, addr, name string) (*MasterState, error)
+}
+
+// MasterState is the state of a master as seen by a Sentinel
+type MasterState struct {
+	// Host is the address the master is announced with
+	Host string
+
+	// Flags are the flags Sentinel reports for the master, e.g. master, s_down or o_down
+	Flags []string
+
+	// OtherSentinels is the number of other Sentinels monitoring the master
+	OtherSentinels int
+
+	// Quorum is the number of Sentinels that have to agree the master is down
+	Quorum int
+
+	// Replicas are the replicas of the master
+	Replicas []ReplicaState
+}
+
+// Down reports whether the master is subjectively or objectively down
+func (m *MasterState) Down() bool {
+	return hasDownFlag(m.Flags)
+}
+
+// ReplicaState is the state of a replica as seen by a Sentinel
+type ReplicaState struct {
+	// Host is the address the replica is announced with
+	Host string
+
+	// Flags are the flags Sentinel reports for the replica, e.g. slave, s_down or disconnected
+	Flags []string
+
+	// MasterLinkStatus is ok while the replica is connected to the master
+	MasterLinkStatus string
+}
+
+// Healthy reports whether the replica is up and replicating from the master
+func (r ReplicaState) Healthy() bool {
+	return !hasDownFlag(r.Flags) && !slices.Contains(r.Flags, "disconnected") && r.MasterLinkStatus == "ok"
+}
+
+func hasDownFlag(flags []string) bool {
+	return slices.Contains(flags, "s_down") || slices.Contains(flags, "o_down")
+}
+
+// Options configures the Sentinel client
+type Options struct {
+	// Timeout bounds connecting to and querying a Sentinel, 5 seconds when zero
+	Timeout time.Duration
+}
+
+// tcpClient speaks the Redis protocol to Sentinel over TCP
+type tcpClient struct {
+	timeout time.Duration
+}
+
+// NewClient creates a Sentinel client
+func NewClient(opts Options) Client {
+	c := &tcpClient{timeout: opts.Timeout}
+	if c.timeout == 0 {
+		c.timeout = 5 * time.Second
+	}
+	return c
+}
+
+// Master implements Client
+func (c *tcpClient) Master(ctx context.Context, addr, name string) (*MasterState, error) {
+	dialer := &net.Dialer{Timeout: c.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to sentinel %s: %w", addr, err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	deadline := time.Now().Add(c.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+	reader := bufio.NewReader(conn)
+
+	reply, err := command(conn, reader, "SENTINEL", "MASTER", name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query master %s: %w", name, err)
+	}
+	fields, err := fieldMap(reply)
+	if err != nil {
+		return nil, err
+	}
+	master := &MasterState{
+		Host:  fields["ip"],
+		Flags: strings.Split(fields["flags"], ","),
+	}
+	master.OtherSentinels, _ = strconv.Atoi(fields["num-other-sentinels"])
+	master.Quorum, _ = strconv.Atoi(fields["quorum"])
+
+	reply, err = command(conn, reader, "SENTINEL", "REPLICAS", name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query replicas of %s: %w", name, err)
+	}
+	replicas, ok := reply.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected replicas reply %v", reply)
+	}
+	for _, replica := range replicas {
+		fields, err := fieldMap(replica)
+		if err != nil {
+			return nil, err
+		}
+		master.Replicas = append(master.Replicas, ReplicaState{
+			Host:             fields["ip"],
+			Flags:            strings.Split(fields["flags"], ","),
+			MasterLinkStatus: fields["master-link-status"],
+		})
+	}
+	return master, nil
+}
+
+// command sends a command as an array of bulk strings and reads its reply
+func command(w io.Writer, r *bufio.Reader, args ...string) (interface{}, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return nil, err
+	}
+	return readReply(r)
+}
+
+// readReply reads a reply of the Redis protocol, errors are returned as such
+func readReply(r *bufio.Reader) (interface{}, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, errors.New("empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, errors.New(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil || n < 0 {
+			return nil, err
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		return string(buf[:n]), nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil || n < 0 {
+			return nil, err
+		}
+		items := make([]interface{}, 0, n)
+		for range n {
+			item, err := readReply(r)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	}
+	return nil, fmt.Errorf("unexpected reply %q", line)
+}
+
+// fieldMap converts the flat list of names and values Sentinel describes an instance with to a map
+func fieldMap(reply interface{}) (map[string]string, error) {
+	items, ok := reply.([]interface{})
+	if !ok || len(items)%2 != 0 {
+		return nil, fmt.Errorf("unexpected reply %v", reply)
+	}
+	fields := make(map[string]string, len(items)/2)
+	for i := 0; i < len(items); i += 2 {
+		key, _ := items[i].(string)
+		value, _ := items[i+1].(string)
+		fields[key] = value
+	}
+	return fields, nil
+}
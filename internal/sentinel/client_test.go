@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sentinel
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bulkArray encodes a flat list of strings as a Redis protocol array
+func bulkArray(items ...string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(items))
+	for _, item := range items {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(item), item)
+	}
+	return b.String()
+}
+
+// newTestSentinel starts a local Sentinel answering each command with the reply registered for it
+func newTestSentinel(t *testing.T, replies map[string]string) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		reader := bufio.NewReader(conn)
+		for {
+			request, err := readReply(reader)
+			if err != nil {
+				return
+			}
+			var args []string
+			for _, arg := range request.([]interface{}) {
+				args = append(args, arg.(string))
+			}
+			reply, ok := replies[strings.Join(args, " ")]
+			if !ok {
+				reply = "-ERR unknown command\r\n"
+			}
+			_, _ = conn.Write([]byte(reply))
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func TestMaster(t *testing.T) {
+	replicas := "*2\r\n" +
+		bulkArray("name", "redis-1:6379", "ip", "redis-1", "flags", "slave", "master-link-status", "ok") +
+		bulkArray("name", "redis-2:6379", "ip", "redis-2", "flags", "s_down,slave,disconnected", "master-link-status", "err")
+	addr := newTestSentinel(t, map[string]string{
+		"SENTINEL MASTER mymaster":   bulkArray("name", "mymaster", "ip", "redis-0", "flags", "master", "num-other-sentinels", "2", "quorum", "2"),
+		"SENTINEL REPLICAS mymaster": replicas,
+	})
+
+	master, err := NewClient(Options{}).Master(context.Background(), addr, "mymaster")
+	require.NoError(t, err)
+	assert.Equal(t, "redis-0", master.Host)
+	assert.False(t, master.Down())
+	assert.Equal(t, 2, master.OtherSentinels)
+	assert.Equal(t, 2, master.Quorum)
+	require.Len(t, master.Replicas, 2)
+	assert.True(t, master.Replicas[0].Healthy())
+	assert.False(t, master.Replicas[1].Healthy())
+}
+
+func TestMasterUnknown(t *testing.T) {
+	addr := newTestSentinel(t, map[string]string{
+		"SENTINEL MASTER other": "-ERR No such master with that name\r\n",
+	})
+
+	_, err := NewClient(Options{}).Master(context.Background(), addr, "other")
+	assert.ErrorContains(t, err, "No such master")
+}
@@ -19,9 +19,16 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"slices"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -31,6 +38,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+	operatorconfig "github.com/geeper-io/llm-operator/internal/config"
+	"github.com/geeper-io/llm-operator/internal/cron"
 )
 
 // nolint:unused
@@ -38,10 +47,12 @@ import (
 var lmdeploymentlog = logf.Log.WithName("lmdeployment-resource")
 
 // SetupLMDeploymentWebhookWithManager registers the webhook for LMDeployment in the manager.
-func SetupLMDeploymentWebhookWithManager(mgr ctrl.Manager) error {
+// The defaulter reads the default images from the operator config, the built-in defaults are used when it is nil.
+// The validator reads the default resources from it.
+func SetupLMDeploymentWebhookWithManager(mgr ctrl.Manager, config *operatorconfig.Store) error {
 	return ctrl.NewWebhookManagedBy(mgr).For(&llmgeeperiov1alpha1.LMDeployment{}).
-		WithValidator(&LMDeploymentCustomValidator{}).
-		WithDefaulter(&LMDeploymentCustomDefaulter{}).
+		WithValidator(&LMDeploymentCustomValidator{Config: config}).
+		WithDefaulter(&LMDeploymentCustomDefaulter{Config: config}).
 		Complete()
 }
 
@@ -52,7 +63,10 @@ func SetupLMDeploymentWebhookWithManager(mgr ctrl.Manager) error {
 //
 // NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
 // as it is used only for temporary operations and does not need to be deeply copied.
-type LMDeploymentCustomDefaulter struct{}
+type LMDeploymentCustomDefaulter struct {
+	// Config provides the operator-level default images
+	Config *operatorconfig.Store
+}
 
 var _ webhook.CustomDefaulter = &LMDeploymentCustomDefaulter{}
 
@@ -86,18 +100,36 @@ func (d *LMDeploymentCustomDefaulter) Default(_ context.Context, obj runtime.Obj
 		d.defaultTabby(lmDeployment)
 	}
 
+	if lmDeployment.Spec.EmbeddingsBackend() == llmgeeperiov1alpha1.EmbeddingsBackendTEI {
+		d.defaultEmbeddings(lmDeployment)
+	}
+
 	return nil
 }
 
+// defaultEmbeddings sets the text-embeddings-inference defaults when the operator serves the embedding model
+func (d *LMDeploymentCustomDefaulter) defaultEmbeddings(lmDeployment *llmgeeperiov1alpha1.LMDeployment) {
+	config := d.Config.Get()
+
+	tei := &lmDeployment.Spec.Embeddings.TEI
+	if tei.Image == "" {
+		tei.Image = config.Image(config.Images.TEI)
+	}
+	if tei.Replicas == 0 {
+		tei.Replicas = 1
+	}
+}
+
 func (d *LMDeploymentCustomDefaulter) defaultOllama(lmDeployment *llmgeeperiov1alpha1.LMDeployment) {
+	config := d.Config.Get()
 	if lmDeployment.Spec.Ollama.Image == "" {
 		switch lmDeployment.Spec.Ollama.Flavor {
 		case "amd":
-			lmDeployment.Spec.Ollama.Image = "ollama/ollama:rocm"
+			lmDeployment.Spec.Ollama.Image = config.Image(config.Images.OllamaROCm)
 		case "nvidia":
 			fallthrough
 		default:
-			lmDeployment.Spec.Ollama.Image = "ollama/ollama:latest"
+			lmDeployment.Spec.Ollama.Image = config.Image(config.Images.Ollama)
 		}
 	}
 	if lmDeployment.Spec.Ollama.Replicas == 0 {
@@ -112,6 +144,8 @@ func (d *LMDeploymentCustomDefaulter) defaultOllama(lmDeployment *llmgeeperiov1a
 }
 
 func (d *LMDeploymentCustomDefaulter) defaultVLLM(lmDeployment *llmgeeperiov1alpha1.LMDeployment) {
+	config := d.Config.Get()
+
 	// Set global defaults if global config is not specified
 	if lmDeployment.Spec.VLLM.GlobalConfig == nil {
 		lmDeployment.Spec.VLLM.GlobalConfig = &llmgeeperiov1alpha1.VLLMGlobalConfig{}
@@ -119,7 +153,7 @@ func (d *LMDeploymentCustomDefaulter) defaultVLLM(lmDeployment *llmgeeperiov1alp
 
 	// Set global image default
 	if lmDeployment.Spec.VLLM.GlobalConfig.Image == "" {
-		lmDeployment.Spec.VLLM.GlobalConfig.Image = "vllm/vllm-openai:latest"
+		lmDeployment.Spec.VLLM.GlobalConfig.Image = config.Image(config.Images.VLLM)
 	}
 
 	// Set global service defaults
@@ -168,7 +202,7 @@ func (d *LMDeploymentCustomDefaulter) defaultVLLM(lmDeployment *llmgeeperiov1alp
 	}
 
 	if lmDeployment.Spec.VLLM.Router.Image == "" {
-		lmDeployment.Spec.VLLM.Router.Image = "lmcache/lmstack-router:latest"
+		lmDeployment.Spec.VLLM.Router.Image = config.Image(config.Images.VLLMRouter)
 	}
 	if lmDeployment.Spec.VLLM.Router.Replicas == 0 {
 		lmDeployment.Spec.VLLM.Router.Replicas = 1
@@ -183,8 +217,9 @@ func (d *LMDeploymentCustomDefaulter) defaultVLLM(lmDeployment *llmgeeperiov1alp
 }
 
 func (d *LMDeploymentCustomDefaulter) defaultOpenWebUI(lmDeployment *llmgeeperiov1alpha1.LMDeployment) {
+	config := d.Config.Get()
 	if lmDeployment.Spec.OpenWebUI.Image == "" {
-		lmDeployment.Spec.OpenWebUI.Image = "ghcr.io/open-webui/open-webui:main"
+		lmDeployment.Spec.OpenWebUI.Image = config.Image(config.Images.OpenWebUI)
 	}
 	if lmDeployment.Spec.OpenWebUI.Replicas == 0 {
 		lmDeployment.Spec.OpenWebUI.Replicas = 1
@@ -198,7 +233,7 @@ func (d *LMDeploymentCustomDefaulter) defaultOpenWebUI(lmDeployment *llmgeeperio
 
 	// Set OpenWebUI Redis defaults
 	if lmDeployment.Spec.OpenWebUI.Redis.Image == "" {
-		lmDeployment.Spec.OpenWebUI.Redis.Image = "redis:7-alpine"
+		lmDeployment.Spec.OpenWebUI.Redis.Image = config.Image(config.Images.Redis)
 	}
 	if lmDeployment.Spec.OpenWebUI.Redis.Service.Port == 0 {
 		lmDeployment.Spec.OpenWebUI.Redis.Service.Port = 6379
@@ -210,6 +245,30 @@ func (d *LMDeploymentCustomDefaulter) defaultOpenWebUI(lmDeployment *llmgeeperio
 		lmDeployment.Spec.OpenWebUI.Redis.Persistence.Size = "1Gi"
 	}
 
+	// Set PostgreSQL defaults when the operator runs the database
+	if lmDeployment.Spec.OpenWebUI.DatabaseType() == llmgeeperiov1alpha1.DatabaseTypePostgres {
+		postgres := &lmDeployment.Spec.OpenWebUI.Database.Postgres
+		if postgres.Image == "" {
+			postgres.Image = config.Image(config.Images.Postgres)
+		}
+		if postgres.Database == "" {
+			postgres.Database = "openwebui"
+		}
+		if postgres.Persistence.Size == "" {
+			postgres.Persistence.Size = "10Gi"
+		}
+	}
+
+	// Set Qdrant defaults when the operator runs the vector database
+	if vectorDB := lmDeployment.Spec.OpenWebUI.VectorDB; lmDeployment.Spec.OpenWebUI.VectorDBType() == llmgeeperiov1alpha1.VectorDBTypeQdrant && vectorDB.External == nil {
+		if vectorDB.Qdrant.Image == "" {
+			vectorDB.Qdrant.Image = config.Image(config.Images.Qdrant)
+		}
+		if vectorDB.Qdrant.Persistence.Size == "" {
+			vectorDB.Qdrant.Persistence.Size = "10Gi"
+		}
+	}
+
 	// Set default Langfuse configuration if enabled
 	if lmDeployment.Spec.OpenWebUI.Langfuse != nil && lmDeployment.Spec.OpenWebUI.Langfuse.Enabled {
 		// Set default project name if not provided
@@ -226,7 +285,7 @@ func (d *LMDeploymentCustomDefaulter) defaultOpenWebUI(lmDeployment *llmgeeperio
 	// Set OpenWebUI Pipelines defaults (for both manual and auto-enabled)
 	if lmDeployment.Spec.OpenWebUI.Pipelines != nil && lmDeployment.Spec.OpenWebUI.Pipelines.Enabled {
 		if lmDeployment.Spec.OpenWebUI.Pipelines.Image == "" {
-			lmDeployment.Spec.OpenWebUI.Pipelines.Image = "ghcr.io/open-webui/pipelines:main"
+			lmDeployment.Spec.OpenWebUI.Pipelines.Image = config.Image(config.Images.Pipelines)
 		}
 		if lmDeployment.Spec.OpenWebUI.Pipelines.Replicas == 0 {
 			lmDeployment.Spec.OpenWebUI.Pipelines.Replicas = 1
@@ -244,8 +303,9 @@ func (d *LMDeploymentCustomDefaulter) defaultOpenWebUI(lmDeployment *llmgeeperio
 }
 
 func (d *LMDeploymentCustomDefaulter) defaultTabby(lmDeployment *llmgeeperiov1alpha1.LMDeployment) {
+	config := d.Config.Get()
 	if lmDeployment.Spec.Tabby.Image == "" {
-		lmDeployment.Spec.Tabby.Image = "tabbyml/tabby:latest"
+		lmDeployment.Spec.Tabby.Image = config.Image(config.Images.Tabby)
 	}
 	if lmDeployment.Spec.Tabby.Replicas == 0 {
 		lmDeployment.Spec.Tabby.Replicas = 1
@@ -268,7 +328,10 @@ func (d *LMDeploymentCustomDefaulter) defaultTabby(lmDeployment *llmgeeperiov1al
 //
 // NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
 // as this struct is used only for temporary operations and does not need to be deeply copied.
-type LMDeploymentCustomValidator struct{}
+type LMDeploymentCustomValidator struct {
+	// Config provides the operator-level default resources
+	Config *operatorconfig.Store
+}
 
 var _ webhook.CustomValidator = &LMDeploymentCustomValidator{}
 
@@ -302,10 +365,12 @@ func (v *LMDeploymentCustomValidator) ValidateDelete(ctx context.Context, obj ru
 func (l *LMDeploymentCustomValidator) validate(lmDeployment *llmgeeperiov1alpha1.LMDeployment) (admission.Warnings, error) {
 	var allErrs field.ErrorList
 
-	if !lmDeployment.Spec.Ollama.Enabled && !lmDeployment.Spec.VLLM.Enabled {
-		allErrs = append(allErrs, field.Required(field.NewPath("spec"), "at least one of Ollama or vLLM must be enabled"))
+	if !lmDeployment.Spec.Ollama.Enabled && !lmDeployment.Spec.VLLM.Enabled && len(lmDeployment.Spec.External) == 0 {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec"), "at least one of Ollama, vLLM or an external provider must be enabled"))
 	}
 
+	allErrs = append(allErrs, l.validateExternal(lmDeployment)...)
+
 	if lmDeployment.Spec.VLLM.Enabled {
 		if err := l.validateVLLM(lmDeployment); err != nil {
 			allErrs = append(allErrs, err...)
@@ -332,11 +397,71 @@ func (l *LMDeploymentCustomValidator) validate(lmDeployment *llmgeeperiov1alpha1
 		}
 	}
 
+	if lmDeployment.Spec.Embeddings != nil {
+		allErrs = append(allErrs, l.validateEmbeddings(lmDeployment)...)
+	}
+
+	allErrs = append(allErrs, validateGatewayTimeouts(lmDeployment.Spec.OpenWebUI.Gateway, field.NewPath("spec", "openwebui", "gateway"))...)
+	allErrs = append(allErrs, validateGatewayTimeouts(lmDeployment.Spec.Tabby.Gateway, field.NewPath("spec", "tabby", "gateway"))...)
+	allErrs = append(allErrs, validateGatewayTimeouts(lmDeployment.Spec.Ollama.Gateway, field.NewPath("spec", "ollama", "gateway"))...)
+	allErrs = append(allErrs, validateGatewayTimeouts(lmDeployment.Spec.VLLM.Router.Gateway, field.NewPath("spec", "vllm", "router", "gateway"))...)
+
+	warnings := l.deprecationWarnings(lmDeployment)
 	if len(allErrs) == 0 {
-		return nil, nil
+		return warnings, nil
+	}
+
+	return warnings, &field.Error{Type: field.ErrorTypeInvalid, Field: "spec", Detail: allErrs.ToAggregate().Error()}
+}
+
+// validateGatewayTimeouts validates that the HTTPRoute timeouts can be written as Gateway API durations,
+// which are whole hours, minutes, seconds and milliseconds below 100000h
+func validateGatewayTimeouts(gateway *llmgeeperiov1alpha1.GatewaySpec, gatewayPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if gateway == nil || gateway.Timeouts == nil {
+		return allErrs
+	}
+
+	timeoutsPath := gatewayPath.Child("timeouts")
+	for _, t := range []struct {
+		name    string
+		timeout *metav1.Duration
+	}{{"request", gateway.Timeouts.Request}, {"backendRequest", gateway.Timeouts.BackendRequest}} {
+		name, timeout := t.name, t.timeout
+		switch {
+		case timeout == nil:
+		case timeout.Duration < 0:
+			allErrs = append(allErrs, field.Invalid(timeoutsPath.Child(name), timeout.Duration.String(), "timeout must not be negative"))
+		case timeout.Duration%time.Millisecond != 0:
+			allErrs = append(allErrs, field.Invalid(timeoutsPath.Child(name), timeout.Duration.String(), "timeout must be a whole number of milliseconds"))
+		case timeout.Duration >= 100000*time.Hour:
+			allErrs = append(allErrs, field.Invalid(timeoutsPath.Child(name), timeout.Duration.String(), "timeout must be below 100000h"))
+		}
+	}
+
+	// A zero request timeout disables it, otherwise a single backend request can't take longer than the whole request
+	request, backendRequest := gateway.Timeouts.Request, gateway.Timeouts.BackendRequest
+	if request != nil && backendRequest != nil && request.Duration > 0 && backendRequest.Duration > request.Duration {
+		allErrs = append(allErrs, field.Invalid(timeoutsPath.Child("backendRequest"), backendRequest.Duration.String(), "backend request timeout must not exceed the request timeout"))
+	}
+
+	return allErrs
+}
+
+// deprecationWarnings warns about deprecated fields that are still set
+func (l *LMDeploymentCustomValidator) deprecationWarnings(lmDeployment *llmgeeperiov1alpha1.LMDeployment) admission.Warnings {
+	var warnings admission.Warnings
+	redisPath := field.NewPath("spec", "openwebui", "redis")
+	redis := lmDeployment.Spec.OpenWebUI.Redis
+
+	if redis.Password != "" {
+		warnings = append(warnings, fmt.Sprintf("%s is deprecated and stored in plain text, use %s instead", redisPath.Child("password"), redisPath.Child("passwordSecretRef")))
+	}
+	if redis.RedisURL != "" {
+		warnings = append(warnings, fmt.Sprintf("%s is deprecated and stored in plain text, use %s instead", redisPath.Child("redisUrl"), redisPath.Child("urlSecretRef")))
 	}
 
-	return nil, &field.Error{Type: field.ErrorTypeInvalid, Field: "spec", Detail: allErrs.ToAggregate().Error()}
+	return warnings
 }
 
 // validateOllama validates Ollama configuration
@@ -349,9 +474,140 @@ func (l *LMDeploymentCustomValidator) validateOllama(lmDeployment *llmgeeperiov1
 		allErrs = append(allErrs, field.Required(ollamaPath.Child("models"), "at least one model must be specified"))
 	}
 
+	// Ollama exposes no inference metrics to scale on
+	if autoscaling := lmDeployment.Spec.Ollama.Autoscaling; autoscaling != nil {
+		autoscalingPath := ollamaPath.Child("autoscaling")
+		if autoscaling.TargetRunningRequests != nil || autoscaling.TargetWaitingRequests != nil || autoscaling.TargetKVCacheUtilization != nil {
+			allErrs = append(allErrs, field.Forbidden(autoscalingPath, "Ollama can only be autoscaled on targetCPUUtilization"))
+		}
+		if autoscaling.TargetCPUUtilization == nil {
+			allErrs = append(allErrs, field.Required(autoscalingPath.Child("targetCPUUtilization"), "Ollama is autoscaled on CPU utilization"))
+		}
+		resources := operatorconfig.ResourcesOrDefault(lmDeployment.Spec.Ollama.Resources, l.Config.Get().Resources.Ollama)
+		allErrs = append(allErrs, validateCPUTarget(autoscaling, resources, autoscalingPath)...)
+	}
+
+	allErrs = append(allErrs, l.validateSchedules(lmDeployment.Spec.Ollama.Schedules, lmDeployment.Spec.Ollama.Autoscaling, ollamaPath.Child("schedules"))...)
+
+	return allErrs
+}
+
+// validateCanary validates the canary of a vLLM model
+func (l *LMDeploymentCustomValidator) validateCanary(modelSpec llmgeeperiov1alpha1.VLLMModelSpec, canaryPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	canary := modelSpec.Canary
+
+	if canary.Promote && canary.Abort {
+		allErrs = append(allErrs, field.Invalid(canaryPath.Child("abort"), canary.Abort, "a canary can't be promoted and aborted at once"))
+	}
+	if canary.Weight < 1 || canary.Weight > 100 {
+		allErrs = append(allErrs, field.Invalid(canaryPath.Child("weight"), canary.Weight, "weight must be between 1 and 100"))
+	} else {
+		// The canary runs the weight of the replicas rounded down, it needs at least one with every replica count
+		replicas := []int32{max(modelSpec.Replicas, 1)}
+		for _, schedule := range modelSpec.Schedules {
+			if schedule.Replicas > 0 {
+				replicas = append(replicas, schedule.Replicas)
+			}
+		}
+		for _, count := range replicas {
+			if count*canary.Weight/100 < 1 {
+				allErrs = append(allErrs, field.Invalid(canaryPath.Child("weight"), canary.Weight,
+					fmt.Sprintf("%d%% of %d replicas is less than one canary replica, raise the weight to at least %d%% or add replicas", canary.Weight, count, (100+count-1)/count)))
+				break
+			}
+		}
+	}
+	if modelSpec.Autoscaling != nil {
+		allErrs = append(allErrs, field.Forbidden(canaryPath, "canaries split fixed replicas and can't be combined with autoscaling"))
+	}
+	if modelSpec.IdleTimeout != nil {
+		allErrs = append(allErrs, field.Forbidden(canaryPath, "canaries can't be combined with an idle timeout"))
+	}
+	if canary.SmokeTest != nil {
+		allErrs = append(allErrs, l.validateSmokeTest(canary.SmokeTest, canaryPath.Child("smokeTest"))...)
+	}
+	return allErrs
+}
+
+// validateSmokeTest validates the smoke test of a vLLM model or canary
+func (l *LMDeploymentCustomValidator) validateSmokeTest(smokeTest *llmgeeperiov1alpha1.SmokeTestSpec, smokeTestPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if smokeTest.Timeout != nil && smokeTest.Timeout.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(smokeTestPath.Child("timeout"), smokeTest.Timeout.Duration.String(), "smoke test timeout must be positive"))
+	}
+	if smokeTest.Interval != nil && smokeTest.Interval.Duration < time.Minute {
+		allErrs = append(allErrs, field.Invalid(smokeTestPath.Child("interval"), smokeTest.Interval.Duration.String(), "smoke test interval must be at least 1m"))
+	}
+	// The Job matches the answer with grep -E, so only the POSIX extended syntax is accepted
+	if _, err := regexp.CompilePOSIX(smokeTest.Expect); err != nil {
+		allErrs = append(allErrs, field.Invalid(smokeTestPath.Child("expect"), smokeTest.Expect, fmt.Sprintf("invalid POSIX extended regular expression: %v", err)))
+	}
+	return allErrs
+}
+
+// validateSchedules validates the schedules of a component, they can't be combined with an autoscaler setting the replicas as well
+func (l *LMDeploymentCustomValidator) validateSchedules(schedules []llmgeeperiov1alpha1.ScheduleSpec, autoscaling *llmgeeperiov1alpha1.AutoscalingSpec, schedulesPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if len(schedules) > 0 && autoscaling != nil {
+		allErrs = append(allErrs, field.Forbidden(schedulesPath, "schedules can't be combined with autoscaling"))
+	}
+
+	for i, schedule := range schedules {
+		schedulePath := schedulesPath.Index(i)
+		if _, err := cron.Parse(schedule.Cron); err != nil {
+			allErrs = append(allErrs, field.Invalid(schedulePath.Child("cron"), schedule.Cron, err.Error()))
+		}
+		if _, err := time.LoadLocation(schedule.Timezone); err != nil {
+			allErrs = append(allErrs, field.Invalid(schedulePath.Child("timezone"), schedule.Timezone, "unknown time zone"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateAutoscaling validates the autoscaling configuration of a model server
+func (l *LMDeploymentCustomValidator) validateAutoscaling(autoscaling *llmgeeperiov1alpha1.AutoscalingSpec, autoscalingPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	usesInferenceMetrics := autoscaling.TargetRunningRequests != nil || autoscaling.TargetWaitingRequests != nil || autoscaling.TargetKVCacheUtilization != nil
+	if autoscaling.Engine == "KEDA" && usesInferenceMetrics && autoscaling.PrometheusAddress == "" {
+		allErrs = append(allErrs, field.Required(autoscalingPath.Child("prometheusAddress"), "KEDA queries the request and KV-cache targets from Prometheus"))
+	}
+
 	return allErrs
 }
 
+// validateCPUTarget validates that a workload autoscaled on CPU utilization requests CPU, the utilization is relative to the request.
+// A CPU limit alone is enough as the request defaults to it.
+func validateCPUTarget(autoscaling *llmgeeperiov1alpha1.AutoscalingSpec, resources llmgeeperiov1alpha1.ResourceRequirements, autoscalingPath *field.Path) field.ErrorList {
+	if autoscaling.TargetCPUUtilization == nil {
+		return nil
+	}
+	if _, ok := resources.Requests[corev1.ResourceCPU]; ok {
+		return nil
+	}
+	if _, ok := resources.Limits[corev1.ResourceCPU]; ok {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(autoscalingPath.Child("targetCPUUtilization"), *autoscaling.TargetCPUUtilization,
+		"CPU utilization can only be targeted when the container requests CPU")}
+}
+
+// vllmResources returns the resources of a vLLM model, falling back to the global and operator defaults like the controller
+func (l *LMDeploymentCustomValidator) vllmResources(lmDeployment *llmgeeperiov1alpha1.LMDeployment, modelSpec llmgeeperiov1alpha1.VLLMModelSpec) llmgeeperiov1alpha1.ResourceRequirements {
+	resources := modelSpec.Resources
+	if globalConfig := lmDeployment.Spec.VLLM.GlobalConfig; globalConfig != nil {
+		if len(resources.Requests) == 0 {
+			resources.Requests = globalConfig.Resources.Requests
+		}
+		if len(resources.Limits) == 0 {
+			resources.Limits = globalConfig.Resources.Limits
+		}
+	}
+	return operatorconfig.ResourcesOrDefault(resources, l.Config.Get().Resources.VLLM)
+}
+
 // validateVLLM validates vLLM configuration
 func (l *LMDeploymentCustomValidator) validateVLLM(lmDeployment *llmgeeperiov1alpha1.LMDeployment) field.ErrorList {
 	var allErrs field.ErrorList
@@ -391,6 +647,40 @@ func (l *LMDeploymentCustomValidator) validateVLLM(lmDeployment *llmgeeperiov1al
 					allErrs = append(allErrs, field.Required(modelPath.Child("persistence", "size"), "persistence size must be specified when persistence is enabled"))
 				}
 			}
+
+			// Validate autoscaling configuration if specified
+			if modelSpec.Autoscaling != nil {
+				allErrs = append(allErrs, l.validateAutoscaling(modelSpec.Autoscaling, modelPath.Child("autoscaling"))...)
+				allErrs = append(allErrs, validateCPUTarget(modelSpec.Autoscaling, l.vllmResources(lmDeployment, modelSpec), modelPath.Child("autoscaling"))...)
+			}
+
+			// Validate idle timeout
+			if modelSpec.IdleTimeout != nil && modelSpec.IdleTimeout.Duration <= 0 {
+				allErrs = append(allErrs, field.Invalid(modelPath.Child("idleTimeout"), modelSpec.IdleTimeout.Duration.String(), "idle timeout must be positive"))
+			}
+
+			// Validate schedules, a model behind an activator is scaled to zero by its idle timeout
+			allErrs = append(allErrs, l.validateSchedules(modelSpec.Schedules, modelSpec.Autoscaling, modelPath.Child("schedules"))...)
+			if modelSpec.IdleTimeout != nil {
+				for j, schedule := range modelSpec.Schedules {
+					if schedule.Replicas == 0 {
+						allErrs = append(allErrs, field.Invalid(modelPath.Child("schedules").Index(j).Child("replicas"), schedule.Replicas, "models with an idle timeout are scaled to zero by the activator"))
+					}
+				}
+			}
+
+			// Validate smoke test, it sends a completion request
+			if modelSpec.SmokeTest != nil {
+				allErrs = append(allErrs, l.validateSmokeTest(modelSpec.SmokeTest, modelPath.Child("smokeTest"))...)
+				if modelSpec.Task == "embed" || modelSpec.Task == "score" {
+					allErrs = append(allErrs, field.Forbidden(modelPath.Child("smokeTest"), fmt.Sprintf("models with the %s task don't serve completions", modelSpec.Task)))
+				}
+			}
+
+			// Validate canary, the replicas are split by its weight
+			if modelSpec.Canary != nil {
+				allErrs = append(allErrs, l.validateCanary(modelSpec, modelPath.Child("canary"))...)
+			}
 		}
 	}
 
@@ -434,6 +724,18 @@ func (l *LMDeploymentCustomValidator) validateOpenWebUI(lmDeployment *llmgeeperi
 	var allErrs field.ErrorList
 	openwebuiPath := field.NewPath("spec", "openwebui")
 
+	allErrs = append(allErrs, l.validateSchedules(lmDeployment.Spec.OpenWebUI.Schedules, nil, openwebuiPath.Child("schedules"))...)
+
+	// Validate the database, replicas can't share the SQLite file in their ReadWriteOnce volume
+	allErrs = append(allErrs, l.validateDatabase(&lmDeployment.Spec.OpenWebUI, openwebuiPath.Child("database"))...)
+	allErrs = append(allErrs, l.validateVectorDB(&lmDeployment.Spec.OpenWebUI, openwebuiPath.Child("vectorDB"))...)
+	allErrs = append(allErrs, l.validateAuth(&lmDeployment.Spec.OpenWebUI, openwebuiPath.Child("auth"))...)
+	allErrs = append(allErrs, l.validateBootstrap(lmDeployment.Spec.OpenWebUI.Bootstrap, openwebuiPath.Child("bootstrap"))...)
+
+	// Validate the Redis credentials, Redis is also used without being enabled by multiple replicas
+	allErrs = append(allErrs, l.validateRedisCredentials(&lmDeployment.Spec.OpenWebUI.Redis, openwebuiPath.Child("redis"))...)
+	allErrs = append(allErrs, l.validateRedisSentinel(&lmDeployment.Spec.OpenWebUI.Redis, openwebuiPath.Child("redis"))...)
+
 	// Validate Redis configuration if enabled
 	if lmDeployment.Spec.OpenWebUI.Redis.Enabled {
 		redisPath := openwebuiPath.Child("redis")
@@ -481,11 +783,247 @@ func (l *LMDeploymentCustomValidator) validateOpenWebUI(lmDeployment *llmgeeperi
 	return allErrs
 }
 
+// validateRedisSentinel validates that sentinel mode applies to the Redis deployed by the operator and can fail over
+func (l *LMDeploymentCustomValidator) validateRedisSentinel(redis *llmgeeperiov1alpha1.RedisSpec, redisPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if !redis.SentinelMode() {
+		return allErrs
+	}
+
+	if redis.External() {
+		allErrs = append(allErrs, field.Forbidden(redisPath.Child("mode"), "sentinel mode only applies to the Redis deployed by the operator"))
+	}
+	replicas := redis.Sentinel.Replicas
+	if replicas == 0 {
+		replicas = 3
+	}
+	if redis.Sentinel.Quorum > replicas {
+		allErrs = append(allErrs, field.Invalid(redisPath.Child("sentinel", "quorum"), redis.Sentinel.Quorum, "quorum must not exceed the number of replicas"))
+	}
+
+	return allErrs
+}
+
+// validateRedisCredentials validates that the Redis credentials are either referenced or in plain text
+func (l *LMDeploymentCustomValidator) validateRedisCredentials(redis *llmgeeperiov1alpha1.RedisSpec, redisPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if ref := redis.URLSecretRef; ref != nil {
+		allErrs = append(allErrs, validateSecretKeySelector(ref, redisPath.Child("urlSecretRef"))...)
+		if redis.RedisURL != "" {
+			allErrs = append(allErrs, field.Forbidden(redisPath.Child("redisUrl"), "only one of redisUrl and urlSecretRef may be specified"))
+		}
+	}
+	if ref := redis.PasswordSecretRef; ref != nil {
+		allErrs = append(allErrs, validateSecretKeySelector(ref, redisPath.Child("passwordSecretRef"))...)
+		if redis.Password != "" {
+			allErrs = append(allErrs, field.Forbidden(redisPath.Child("password"), "only one of password and passwordSecretRef may be specified"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateSecretKeySelector validates that a secret key reference names both the secret and the key
+func validateSecretKeySelector(ref *corev1.SecretKeySelector, refPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if ref.Name == "" {
+		allErrs = append(allErrs, field.Required(refPath.Child("name"), "secret name must be specified"))
+	}
+	if ref.Key == "" {
+		allErrs = append(allErrs, field.Required(refPath.Child("key"), "secret key must be specified"))
+	}
+	return allErrs
+}
+
+// validateDatabase validates the OpenWebUI database configuration
+func (l *LMDeploymentCustomValidator) validateDatabase(openwebui *llmgeeperiov1alpha1.OpenWebUISpec, databasePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	switch openwebui.DatabaseType() {
+	case llmgeeperiov1alpha1.DatabaseTypeSQLite:
+		replicas := openwebui.Replicas
+		for _, schedule := range openwebui.Schedules {
+			replicas = max(replicas, schedule.Replicas)
+		}
+		if replicas > 1 {
+			allErrs = append(allErrs, field.Forbidden(databasePath.Child("type"), fmt.Sprintf("OpenWebUI with %d replicas can't share SQLite, use postgres or an external database", replicas)))
+		}
+	case llmgeeperiov1alpha1.DatabaseTypeExternal:
+		if openwebui.Database.URLSecretRef == nil {
+			allErrs = append(allErrs, field.Required(databasePath.Child("urlSecretRef"), "the DATABASE_URL secret must be specified for an external database"))
+		}
+	case llmgeeperiov1alpha1.DatabaseTypePostgres:
+		if size := openwebui.Database.Postgres.Persistence.Size; size != "" {
+			if _, err := resource.ParseQuantity(size); err != nil {
+				allErrs = append(allErrs, field.Invalid(databasePath.Child("postgres", "persistence", "size"), size, "must be a valid quantity"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateExternal validates the external providers
+func (l *LMDeploymentCustomValidator) validateExternal(lmDeployment *llmgeeperiov1alpha1.LMDeployment) field.ErrorList {
+	var allErrs field.ErrorList
+	externalPath := field.NewPath("spec", "external")
+
+	// Tabby uses the first provider listing a model, a model listed twice would be ambiguous
+	providers := map[string]string{}
+	for i, provider := range lmDeployment.Spec.External {
+		providerPath := externalPath.Index(i)
+		if u, err := url.Parse(provider.BaseURL); err != nil || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(providerPath.Child("baseURL"), provider.BaseURL, "must be an http or https URL"))
+		}
+		if provider.APIKeySecretRef != nil && provider.APIKeySecretRef.Name == "" {
+			allErrs = append(allErrs, field.Required(providerPath.Child("apiKeySecretRef", "name"), "secret name must be specified"))
+		}
+		for j, model := range provider.Models {
+			if other, ok := providers[model]; ok {
+				allErrs = append(allErrs, field.Invalid(providerPath.Child("models").Index(j), model, fmt.Sprintf("already listed by provider %s", other)))
+				continue
+			}
+			providers[model] = provider.Name
+		}
+	}
+
+	return allErrs
+}
+
+// validateVectorDB validates the OpenWebUI vector database configuration
+func (l *LMDeploymentCustomValidator) validateVectorDB(openwebui *llmgeeperiov1alpha1.OpenWebUISpec, vectorDBPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	switch vectorDBType := openwebui.VectorDBType(); vectorDBType {
+	case llmgeeperiov1alpha1.VectorDBTypeChroma:
+		if openwebui.VectorDB != nil && openwebui.VectorDB.External != nil {
+			allErrs = append(allErrs, field.Forbidden(vectorDBPath.Child("external"), "chroma is embedded in OpenWebUI and can't be external"))
+		}
+	case llmgeeperiov1alpha1.VectorDBTypeQdrant:
+		if size := openwebui.VectorDB.Qdrant.Persistence.Size; openwebui.VectorDB.External == nil && size != "" {
+			if _, err := resource.ParseQuantity(size); err != nil {
+				allErrs = append(allErrs, field.Invalid(vectorDBPath.Child("qdrant", "persistence", "size"), size, "must be a valid quantity"))
+			}
+		}
+	default:
+		if openwebui.VectorDB.External == nil {
+			allErrs = append(allErrs, field.Required(vectorDBPath.Child("external"), fmt.Sprintf("the connection to the %s database must be specified, the operator only runs Qdrant", vectorDBType)))
+		}
+	}
+
+	if vectorDBType := openwebui.VectorDBType(); vectorDBType != llmgeeperiov1alpha1.VectorDBTypeOpenSearch &&
+		openwebui.VectorDB != nil && openwebui.VectorDB.External != nil && openwebui.VectorDB.External.Username != "" {
+		allErrs = append(allErrs, field.Forbidden(vectorDBPath.Child("external", "username"), fmt.Sprintf("%s authenticates without a user name", vectorDBType)))
+	}
+
+	return allErrs
+}
+
+// validateAuth validates the OpenWebUI sign in configuration
+func (l *LMDeploymentCustomValidator) validateAuth(openwebui *llmgeeperiov1alpha1.OpenWebUISpec, authPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	auth := openwebui.Auth
+	if auth == nil {
+		return allErrs
+	}
+
+	oidc := auth.OIDC
+	if oidc == nil {
+		if auth.DisablePasswordLogin {
+			allErrs = append(allErrs, field.Required(authPath.Child("oidc"), "users can't sign in without the password login or OIDC"))
+		}
+		return allErrs
+	}
+
+	oidcPath := authPath.Child("oidc")
+	if u, err := url.Parse(oidc.ProviderURL); err != nil || u.Host == "" {
+		allErrs = append(allErrs, field.Invalid(oidcPath.Child("providerURL"), oidc.ProviderURL, "must be an http or https URL"))
+	}
+	if oidc.ClientID == "" {
+		allErrs = append(allErrs, field.Required(oidcPath.Child("clientID"), "client ID must be specified"))
+	}
+	allErrs = append(allErrs, validateSecretKeySelector(&oidc.ClientSecretRef, oidcPath.Child("clientSecretRef"))...)
+	if oidc.RedirectURI != "" {
+		if u, err := url.Parse(oidc.RedirectURI); err != nil || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(oidcPath.Child("redirectURI"), oidc.RedirectURI, "must be an http or https URL"))
+		}
+	} else if openwebui.Ingress.Host == "" && (openwebui.Gateway == nil || openwebui.Gateway.URL() == "") {
+		allErrs = append(allErrs, field.Required(oidcPath.Child("redirectURI"),
+			"the redirect URI can't be derived without an ingress host or a non-wildcard gateway hostname"))
+	}
+
+	return allErrs
+}
+
+// validateBootstrap validates the OpenWebUI admin account
+func (l *LMDeploymentCustomValidator) validateBootstrap(bootstrap *llmgeeperiov1alpha1.OpenWebUIBootstrapSpec, bootstrapPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if bootstrap == nil || bootstrap.Admin == nil {
+		return allErrs
+	}
+
+	admin := bootstrap.Admin
+	adminPath := bootstrapPath.Child("admin")
+	if _, err := mail.ParseAddress(admin.Email); err != nil {
+		allErrs = append(allErrs, field.Invalid(adminPath.Child("email"), admin.Email, "must be an email address"))
+	}
+	allErrs = append(allErrs, validateSecretKeySelector(&admin.PasswordSecretRef, adminPath.Child("passwordSecretRef"))...)
+
+	return allErrs
+}
+
+// validateEmbeddings validates that the embedding and reranking models are served by the deployment
+func (l *LMDeploymentCustomValidator) validateEmbeddings(lmDeployment *llmgeeperiov1alpha1.LMDeployment) field.ErrorList {
+	var allErrs field.ErrorList
+	embeddings := lmDeployment.Spec.Embeddings
+	embeddingsPath := field.NewPath("spec", "embeddings")
+
+	// vllmModelTask returns the task of a vllm.models entry, false if the deployment doesn't serve it
+	vllmModelTask := func(name string) (string, bool) {
+		if !lmDeployment.Spec.VLLM.Enabled {
+			return "", false
+		}
+		for _, model := range lmDeployment.Spec.VLLM.Models {
+			if model.Name == name {
+				return model.Task, true
+			}
+		}
+		return "", false
+	}
+
+	switch lmDeployment.Spec.EmbeddingsBackend() {
+	case llmgeeperiov1alpha1.EmbeddingsBackendVLLM:
+		if task, ok := vllmModelTask(embeddings.Model); !ok {
+			allErrs = append(allErrs, field.Invalid(embeddingsPath.Child("model"), embeddings.Model, "must be the name of a vllm.models entry"))
+		} else if task != "embed" {
+			allErrs = append(allErrs, field.Invalid(embeddingsPath.Child("model"), embeddings.Model, "the vllm.models entry must have the embed task"))
+		}
+	case llmgeeperiov1alpha1.EmbeddingsBackendOllama:
+		if !lmDeployment.Spec.Ollama.Enabled || !slices.Contains(lmDeployment.Spec.Ollama.Models, embeddings.Model) {
+			allErrs = append(allErrs, field.Invalid(embeddingsPath.Child("model"), embeddings.Model, "must be listed in ollama.models"))
+		}
+	}
+
+	if embeddings.Reranker != nil {
+		rerankerPath := embeddingsPath.Child("reranker", "model")
+		if task, ok := vllmModelTask(embeddings.Reranker.Model); !ok {
+			allErrs = append(allErrs, field.Invalid(rerankerPath, embeddings.Reranker.Model, "must be the name of a vllm.models entry"))
+		} else if task != "score" {
+			allErrs = append(allErrs, field.Invalid(rerankerPath, embeddings.Reranker.Model, "the vllm.models entry must have the score task"))
+		}
+	}
+
+	return allErrs
+}
+
 // validateTabby validates Tabby configuration
 func (l *LMDeploymentCustomValidator) validateTabby(lmDeployment *llmgeeperiov1alpha1.LMDeployment) field.ErrorList {
 	var allErrs field.ErrorList
 	tabbyPath := field.NewPath("spec", "tabby")
 
+	allErrs = append(allErrs, l.validateSchedules(lmDeployment.Spec.Tabby.Schedules, nil, tabbyPath.Child("schedules"))...)
+
 	// Validate device
 	if lmDeployment.Spec.Tabby.Device != "" {
 		validDevices := []string{"cpu", "cuda", "rocm", "metal", "vulkan"}
@@ -503,7 +1041,7 @@ func (l *LMDeploymentCustomValidator) validateTabby(lmDeployment *llmgeeperiov1a
 
 	// Validate chat model if specified
 	if lmDeployment.Spec.Tabby.ChatModel != "" {
-		// Check if the chat model exists in Ollama or vLLM models
+		// Check if the chat model exists in Ollama, vLLM or external provider models
 		found := false
 		if lmDeployment.Spec.VLLM.Enabled {
 			if lmDeployment.Spec.VLLM.Models != nil {
@@ -523,18 +1061,24 @@ func (l *LMDeploymentCustomValidator) validateTabby(lmDeployment *llmgeeperiov1a
 				}
 			}
 		}
+		if lmDeployment.Spec.ExternalProvider(lmDeployment.Spec.Tabby.ChatModel) != nil {
+			found = true
+		}
 		if !found {
 			modelSource := "spec.vllm.models"
 			if !lmDeployment.Spec.VLLM.Enabled {
 				modelSource = "spec.ollama.models"
 			}
+			if len(lmDeployment.Spec.External) > 0 {
+				modelSource += " or spec.external[].models"
+			}
 			allErrs = append(allErrs, field.Invalid(tabbyPath.Child("chatModel"), lmDeployment.Spec.Tabby.ChatModel, fmt.Sprintf("chat model must be one of the models specified in %s", modelSource)))
 		}
 	}
 
 	// Validate completion model if specified
 	if lmDeployment.Spec.Tabby.CompletionModel != "" {
-		// Check if the completion model exists in Ollama or vLLM models
+		// Check if the completion model exists in Ollama, vLLM or external provider models
 		found := false
 		if lmDeployment.Spec.VLLM.Enabled {
 			if lmDeployment.Spec.VLLM.Models != nil {
@@ -554,11 +1098,17 @@ func (l *LMDeploymentCustomValidator) validateTabby(lmDeployment *llmgeeperiov1a
 				}
 			}
 		}
+		if lmDeployment.Spec.ExternalProvider(lmDeployment.Spec.Tabby.CompletionModel) != nil {
+			found = true
+		}
 		if !found {
 			modelSource := "spec.vllm.models"
 			if !lmDeployment.Spec.VLLM.Enabled {
 				modelSource = "spec.ollama.models"
 			}
+			if len(lmDeployment.Spec.External) > 0 {
+				modelSource += " or spec.external[].models"
+			}
 			allErrs = append(allErrs, field.Invalid(tabbyPath.Child("completionModel"), lmDeployment.Spec.Tabby.CompletionModel, fmt.Sprintf("completion model must be one of the models specified in %s", modelSource)))
 		}
 	}
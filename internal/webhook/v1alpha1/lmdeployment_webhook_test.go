@@ -21,6 +21,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	llmgeeperiov1alpha1 "github.com/geeper-io/llm-operator/api/v1alpha1"
+	operatorconfig "github.com/geeper-io/llm-operator/internal/config"
 	// TODO (user): Add any additional imports if needed
 )
 
@@ -59,6 +60,28 @@ var _ = Describe("LMDeployment Webhook", func() {
 		//     By("checking that the default values are set")
 		//     Expect(obj.SomeFieldWithDefault).To(Equal("default_value"))
 		// })
+
+		It("Should default images from the operator config", func() {
+			By("configuring a registry mirror and a pinned Tabby image")
+			cfg := operatorconfig.Default()
+			cfg.RegistryMirror = "registry.internal/mirror"
+			cfg.Images.Tabby = "tabbyml/tabby:0.30.0"
+			store, err := operatorconfig.NewStore("")
+			Expect(err).NotTo(HaveOccurred())
+			store.Set(cfg)
+			defaulter.Config = store
+
+			By("calling the Default method to apply defaults")
+			obj.Spec.Tabby.Enabled = true
+			obj.Spec.OpenWebUI.Enabled = true
+			obj.Spec.OpenWebUI.Redis.Image = "redis:7.2"
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+
+			By("checking that the configured images are used")
+			Expect(obj.Spec.Tabby.Image).To(Equal("registry.internal/mirror/tabbyml/tabby:0.30.0"))
+			Expect(obj.Spec.OpenWebUI.Image).To(Equal("registry.internal/mirror/ghcr.io/open-webui/open-webui:main"))
+			Expect(obj.Spec.OpenWebUI.Redis.Image).To(Equal("redis:7.2"))
+		})
 	})
 
 	Context("When creating or updating LMDeployment under Validating Webhook", func() {
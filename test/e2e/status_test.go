@@ -61,6 +61,8 @@ spec:
     enabled: true
     replicas: 2
     image: ghcr.io/open-webui/open-webui:main
+    database:
+      type: postgres
     redis:
       enabled: true
       image: redis:7-alpine
@@ -90,7 +90,7 @@ spec:
   tabby:
     enabled: true
     chatModel: "non-existent-model"`,
-		"at least one of Ollama or vLLM must be enabled",
+		"at least one of Ollama, vLLM or an external provider must be enabled",
 	)
 
 	webhookTestSuite.testInvalidConfiguration(